@@ -5,16 +5,23 @@
 package vsphere
 
 import (
+	"bufio"
 	"context"
 	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -23,6 +30,8 @@ import (
 	"github.com/vmware/govmomi/session"
 	"github.com/vmware/govmomi/session/cache"
 	"github.com/vmware/govmomi/session/keepalive"
+	"github.com/vmware/govmomi/sts"
+	"github.com/vmware/govmomi/vapi/authentication"
 	"github.com/vmware/govmomi/vapi/rest"
 	"github.com/vmware/govmomi/vapi/tags"
 	"github.com/vmware/govmomi/vim25"
@@ -30,7 +39,12 @@ import (
 	"github.com/vmware/govmomi/vim25/soap"
 	"github.com/vmware/govmomi/vim25/types"
 	"github.com/vmware/govmomi/vsan"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/logging"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/ovfdeploy"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/provider"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/structure"
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+	"golang.org/x/net/http/httpproxy"
 )
 
 // Client is the client connection manager for the vSphere provider. It
@@ -52,6 +66,35 @@ type Client struct {
 
 	// client timeout for certain operations
 	timeout time.Duration
+
+	// The TLS and proxy configuration for requests to remote OVF and OVA
+	// files, which are made outside of the vSphere clients.
+	httpConfig *ovfdeploy.HTTPConfig
+
+	// Limits the number of concurrent tasks submitted by the provider.
+	taskLimiter *taskLimiter
+
+	// The retry policy for operations failing with transient faults, shared
+	// by the SOAP task helpers and the REST client.
+	retryPolicy viapi.RetryPolicy
+
+	// Tags and custom attributes applied to every resource that supports them,
+	// from default_tags and default_custom_attributes.
+	defaultTags             []string
+	defaultCustomAttributes map[string]string
+
+	// The categories of tags looked up when merging default_tags.
+	tagCategories *tagCategoryCache
+}
+
+// runTask runs f, which submits a task of the supplied class and waits for it,
+// once the concurrent task limits allow it. name describes the operation in
+// log messages. timeout bounds the wait for a free slot, with 0 waiting
+// indefinitely.
+func (c *Client) runTask(timeout time.Duration, class taskClass, name string, f func() error) error {
+	ctx, cancel := provider.WithTimeout(timeout)
+	defer cancel()
+	return c.taskLimiter.run(ctx, class, name, f)
 }
 
 // TagsManager returns the embedded tags manager used for tags, after determining
@@ -100,6 +143,53 @@ type Config struct {
 	RestSessionPath string
 	KeepAlive       int
 	APITimeout      time.Duration
+	CAFile          string
+	CABundle        string
+	Thumbprint      string
+	TLSMinVersion   string
+	ProxyURL        string
+	NoProxy         string
+
+	// Limits on concurrent task submission. A value of 0 is unlimited.
+	MaxConcurrentTasks            int
+	MaxConcurrentCloneTasks       int
+	MaxConcurrentRelocateTasks    int
+	MaxConcurrentReconfigureTasks int
+	MaxConcurrentOvfImportTasks   int
+
+	// The retry policy for operations failing with transient faults.
+	RetryPolicy viapi.RetryPolicy
+
+	// Alternatives to user and password authentication.
+	SAMLToken         string
+	ClientCertificate string
+	ClientKey         string
+	OIDCToken         string
+
+	// Protection of persisted sessions. A SessionMaxAge of 0 does not expire
+	// sessions.
+	SessionEncryptionKey     string
+	SessionEncryptionKeyFile string
+	SessionMaxAge            time.Duration
+
+	// Tags and custom attributes applied to every resource that supports them.
+	DefaultTags             []string
+	DefaultCustomAttributes map[string]string
+
+	// Whether the sessions were loaded from disk rather than created.
+	vimSessionRestored  bool
+	restSessionRestored bool
+
+	// The SAML token obtained for the SOAP login, reused for the REST login so
+	// that a single-use token is only issued once.
+	loginToken string
+}
+
+// tlsVersions maps the values accepted by tls_min_version to their crypto/tls
+// counterparts.
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
 }
 
 // NewConfig returns a new Config from a supplied ResourceData.
@@ -133,11 +223,57 @@ func NewConfig(d *schema.ResourceData) (*Config, error) {
 		RestSessionPath: d.Get("rest_session_path").(string),
 		KeepAlive:       d.Get("vim_keep_alive").(int),
 		APITimeout:      timeout,
+		CAFile:          d.Get("ca_file").(string),
+		CABundle:        d.Get("ca_bundle").(string),
+		Thumbprint:      d.Get("vsphere_server_thumbprint").(string),
+		TLSMinVersion:   d.Get("tls_min_version").(string),
+		ProxyURL:        d.Get("proxy_url").(string),
+		NoProxy:         d.Get("no_proxy").(string),
+
+		MaxConcurrentTasks:            d.Get("max_concurrent_tasks").(int),
+		MaxConcurrentCloneTasks:       d.Get("max_concurrent_clone_tasks").(int),
+		MaxConcurrentRelocateTasks:    d.Get("max_concurrent_relocate_tasks").(int),
+		MaxConcurrentReconfigureTasks: d.Get("max_concurrent_reconfigure_tasks").(int),
+		MaxConcurrentOvfImportTasks:   d.Get("max_concurrent_ovf_import_tasks").(int),
+
+		RetryPolicy: viapi.RetryPolicy{
+			Attempts: d.Get("api_retry_attempts").(int),
+			Backoff:  time.Duration(d.Get("api_retry_backoff").(int)) * time.Second,
+		},
+
+		SAMLToken:         d.Get("saml_token").(string),
+		ClientCertificate: d.Get("client_certificate").(string),
+		ClientKey:         d.Get("client_key").(string),
+		OIDCToken:         d.Get("oidc_token").(string),
+
+		SessionEncryptionKey:     d.Get("session_encryption_key").(string),
+		SessionEncryptionKeyFile: d.Get("session_encryption_key_file").(string),
+		SessionMaxAge:            time.Duration(d.Get("session_max_age").(int)) * time.Minute,
+
+		DefaultTags: structure.SliceInterfacesToStrings(d.Get("default_tags").(*schema.Set).List()),
+	}
+	if attrs := d.Get("default_custom_attributes").(map[string]interface{}); len(attrs) > 0 {
+		c.DefaultCustomAttributes = make(map[string]string)
+		for k, v := range attrs {
+			c.DefaultCustomAttributes[k] = v.(string)
+		}
+	}
+
+	if !c.tokenAuth() && (c.User == "" || c.Password == "") {
+		return nil, fmt.Errorf("user and password must be provided unless one of saml_token, client_certificate or oidc_token is used")
 	}
 
 	return c, nil
 }
 
+// tokenAuth returns true if the provider logs in with a SAML token, rather
+// than with a user name and password. The token is either supplied directly,
+// issued by the STS for a solution user certificate, or exchanged for an OIDC
+// token issued by a federated identity provider.
+func (c *Config) tokenAuth() bool {
+	return c.SAMLToken != "" || c.ClientCertificate != "" || c.OIDCToken != ""
+}
+
 // vimURL returns a URL to pass to the VIM SOAP client.
 func (c *Config) vimURL() (*url.URL, error) {
 	u, err := url.Parse("https://" + c.VSphereServer + "/sdk")
@@ -145,7 +281,9 @@ func (c *Config) vimURL() (*url.URL, error) {
 		return nil, fmt.Errorf("error parse url: %s", err)
 	}
 
-	u.User = url.UserPassword(c.User, c.Password)
+	if !c.tokenAuth() {
+		u.User = url.UserPassword(c.User, c.Password)
+	}
 
 	return u, nil
 }
@@ -164,11 +302,30 @@ func (c *Config) Client() (*Client, error) {
 		return nil, fmt.Errorf("error setting up client debug: %s", err)
 	}
 
+	tlsConfig, err := c.TLSConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error setting up TLS configuration: %s", err)
+	}
+	proxy, err := c.ProxyFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error setting up proxy configuration: %s", err)
+	}
+	// Requests to remote OVF and OVA files are made outside of the vSphere
+	// clients, so they need the TLS and proxy configuration handed over
+	// separately.
+	client.httpConfig = &ovfdeploy.HTTPConfig{
+		TLSClientConfig: tlsConfig,
+		Proxy:           proxy,
+	}
+
 	// Set up the VIM/govmomi client connection, or load a previous session
 	client.vimClient, err = c.SavedVimSessionOrNew(u)
 	if err != nil {
 		return nil, err
 	}
+	client.vimClient.RoundTripper = logging.NewSOAPRoundTripper(client.vimClient.RoundTripper, logging.SubsystemSOAP)
+	client.retryPolicy = c.RetryPolicy
+	viapi.SetRetryPolicy(client.vimClient.Client, c.RetryPolicy)
 
 	log.Printf("[DEBUG] VMWare vSphere Client configured for URL: %s", c.VSphereServer)
 
@@ -180,6 +337,9 @@ func (c *Config) Client() (*Client, error) {
 		if err != nil {
 			return nil, err
 		}
+		if c.tokenAuth() {
+			s.LoginREST = c.restLoginByToken(client.vimClient.Client)
+		}
 		client.restClient, err = c.SavedRestSessionOrNew(s)
 		if err != nil {
 			return nil, err
@@ -199,6 +359,7 @@ func (c *Config) Client() (*Client, error) {
 		if err != nil {
 			return nil, err
 		}
+		pc.RoundTripper = logging.NewSOAPRoundTripper(pc.RoundTripper, logging.SubsystemPBM)
 		client.pbmClient = pc
 	} else {
 		log.Printf("[DEBUG] Connected endpoint does not support policy based management")
@@ -209,6 +370,7 @@ func (c *Config) Client() (*Client, error) {
 		if err != nil {
 			return nil, err
 		}
+		vc.RoundTripper = logging.NewSOAPRoundTripper(vc.RoundTripper, logging.SubsystemVSAN)
 		client.vsanClient = vc
 	} else {
 		log.Printf("[DEBUG] Connected endpoint does not support vSAN service")
@@ -223,6 +385,15 @@ func (c *Config) Client() (*Client, error) {
 	}
 
 	client.timeout = c.APITimeout
+	client.taskLimiter = newTaskLimiter(c.MaxConcurrentTasks, map[taskClass]int{
+		taskClassClone:       c.MaxConcurrentCloneTasks,
+		taskClassRelocate:    c.MaxConcurrentRelocateTasks,
+		taskClassReconfigure: c.MaxConcurrentReconfigureTasks,
+		taskClassOvfImport:   c.MaxConcurrentOvfImportTasks,
+	})
+	client.defaultTags = c.DefaultTags
+	client.defaultCustomAttributes = c.DefaultCustomAttributes
+	client.tagCategories = newTagCategoryCache()
 
 	return client, nil
 }
@@ -232,7 +403,9 @@ func (c *Config) restURL() (*cache.Session, error) {
 	if err != nil {
 		return nil, err
 	}
-	u.User = url.UserPassword(c.User, c.Password)
+	if !c.tokenAuth() {
+		u.User = url.UserPassword(c.User, c.Password)
+	}
 	s := &cache.Session{
 		URL:      u,
 		Insecure: c.InsecureFlag,
@@ -246,22 +419,253 @@ func (c *Config) SavedRestSessionOrNew(s *cache.Session) (*rest.Client, error) {
 	defer cancel()
 
 	s.DirREST = c.RestSessionPath
-	s.Passthrough = !c.Persist
-	restClient := new(rest.Client)
-	err := s.Login(ctx, restClient, nil)
+	// Sessions are saved by the provider rather than by the session cache, so
+	// that they can be encrypted and expired.
+	s.Passthrough = true
+	restClient, err := c.LoadRestClient(ctx, s)
 	if err != nil {
 		return nil, err
 	}
+	if restClient == nil {
+		restClient = new(rest.Client)
+		if err := s.Login(ctx, restClient, c.configureTransport); err != nil {
+			return nil, err
+		}
+	}
 	// Setup keepalive functionality
 	var f func() error
 	t := keepalive.NewHandlerREST(restClient, time.Duration(c.KeepAlive)*time.Minute, f)
 	t.Start()
-	restClient.Transport = t
+	restClient.Transport = viapi.NewRESTRetryRoundTripper(logging.NewRESTRoundTripper(t), c.RetryPolicy)
 
 	log.Println("[DEBUG] CIS REST client configuration successful")
 	return restClient, nil
 }
 
+// TLSConfig returns the TLS configuration for connections made by the
+// provider, built from the CA bundle and minimum TLS version settings. The
+// pinned thumbprint is applied to vSphere client connections by
+// configureTransport.
+func (c *Config) TLSConfig() (*tls.Config, error) {
+	minVersion := uint16(tls.VersionTLS12)
+	if c.TLSMinVersion != "" {
+		v, ok := tlsVersions[c.TLSMinVersion]
+		if !ok {
+			return nil, fmt.Errorf("unsupported TLS version %q", c.TLSMinVersion)
+		}
+		minVersion = v
+	}
+	config := &tls.Config{
+		MinVersion:         minVersion,
+		InsecureSkipVerify: c.InsecureFlag, //nolint:gosec
+	}
+
+	if c.CAFile != "" || c.CABundle != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			log.Printf("[DEBUG] Could not load system certificate pool, using the CA bundle only: %s", err)
+			pool = x509.NewCertPool()
+		}
+		bundle := []byte(c.CABundle)
+		if c.CAFile != "" {
+			bundle, err = os.ReadFile(filepath.Clean(c.CAFile))
+			if err != nil {
+				return nil, fmt.Errorf("error reading CA bundle: %s", err)
+			}
+		}
+		if !pool.AppendCertsFromPEM(bundle) {
+			return nil, fmt.Errorf("CA bundle does not contain any PEM-encoded certificates")
+		}
+		config.RootCAs = pool
+	}
+
+	return config, nil
+}
+
+// configureTransport applies the provider TLS and proxy configuration to a
+// SOAP client. The PBM, vSAN and REST clients are created from the SOAP
+// client and share its transport.
+//
+// If a thumbprint is pinned, connections to the vSphere server are only
+// permitted if its certificate matches the thumbprint, regardless of the
+// trusted CAs. Connections to other hosts, such as ESXi hosts receiving
+// uploads, are verified as usual.
+func (c *Config) configureTransport(sc *soap.Client) error {
+	config, err := c.TLSConfig()
+	if err != nil {
+		return err
+	}
+	if c.ClientCertificate != "" {
+		cert, err := tls.LoadX509KeyPair(c.ClientCertificate, c.ClientKey)
+		if err != nil {
+			return fmt.Errorf("error loading client certificate: %s", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	proxy, err := c.ProxyFunc()
+	if err != nil {
+		return err
+	}
+	t := sc.DefaultTransport()
+	t.TLSClientConfig = config
+	t.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxy(req.URL)
+	}
+	if c.Thumbprint == "" {
+		return nil
+	}
+
+	pin, err := normalizeThumbprint(c.Thumbprint)
+	if err != nil {
+		return err
+	}
+	u, err := c.vimURL()
+	if err != nil {
+		return err
+	}
+	pinned := config.Clone()
+	pinned.ServerName = u.Hostname()
+	pinned.InsecureSkipVerify = true //nolint:gosec
+	pinned.VerifyConnection = func(cs tls.ConnectionState) error {
+		if len(cs.PeerCertificates) == 0 {
+			return fmt.Errorf("no certificate presented by %s", u.Hostname())
+		}
+		sum := sha256.Sum256(cs.PeerCertificates[0].Raw)
+		if hex.EncodeToString(sum[:]) != pin {
+			return fmt.Errorf("certificate of %s does not match the pinned thumbprint", u.Hostname())
+		}
+		return nil
+	}
+	isServer := func(addr string) bool {
+		host, _, err := net.SplitHostPort(addr)
+		return err == nil && strings.EqualFold(host, u.Hostname())
+	}
+	// The transport only uses DialTLSContext for connections that are not
+	// proxied, so connections to the vSphere server are tunnelled through the
+	// proxy by the dialer itself to verify the pinned thumbprint.
+	t.Proxy = func(req *http.Request) (*url.URL, error) {
+		if strings.EqualFold(req.URL.Hostname(), u.Hostname()) {
+			return nil, nil
+		}
+		return proxy(req.URL)
+	}
+	dial := t.DialTLSContext
+	t.DialTLSContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if isServer(addr) {
+			conn, err := dialProxy(ctx, proxy, network, addr)
+			if err != nil {
+				return nil, err
+			}
+			tlsConn := tls.Client(conn, pinned)
+			if err := tlsConn.HandshakeContext(ctx); err != nil {
+				_ = conn.Close()
+				return nil, err
+			}
+			return tlsConn, nil
+		}
+		if dial != nil {
+			return dial(ctx, network, addr)
+		}
+		d := &tls.Dialer{Config: config}
+		return d.DialContext(ctx, network, addr)
+	}
+	return nil
+}
+
+// ProxyFunc returns a function that returns the proxy server to use for a
+// request URL, or nil if the request should not be proxied. If proxy_url is
+// not set, the standard HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment
+// variables are used.
+func (c *Config) ProxyFunc() (func(*url.URL) (*url.URL, error), error) {
+	env := httpproxy.FromEnvironment()
+	if c.ProxyURL == "" {
+		return env.ProxyFunc(), nil
+	}
+	if _, err := url.Parse(c.ProxyURL); err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %s", err)
+	}
+	noProxy := c.NoProxy
+	if noProxy == "" {
+		noProxy = env.NoProxy
+	}
+	config := &httpproxy.Config{
+		HTTPProxy:  c.ProxyURL,
+		HTTPSProxy: c.ProxyURL,
+		NoProxy:    noProxy,
+	}
+	return config.ProxyFunc(), nil
+}
+
+// dialProxy opens a connection to addr, tunnelled with the CONNECT method
+// through the proxy server returned by proxy, if any.
+func dialProxy(ctx context.Context, proxy func(*url.URL) (*url.URL, error), network, addr string) (net.Conn, error) {
+	var d net.Dialer
+	p, err := proxy(&url.URL{Scheme: "https", Host: addr})
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return d.DialContext(ctx, network, addr)
+	}
+
+	proxyAddr := p.Host
+	if p.Port() == "" {
+		port := "80"
+		if p.Scheme == "https" {
+			port = "443"
+		}
+		proxyAddr = net.JoinHostPort(p.Hostname(), port)
+	}
+	conn, err := d.DialContext(ctx, network, proxyAddr)
+	if err != nil {
+		return nil, err
+	}
+	if p.Scheme == "https" {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: p.Hostname(), MinVersion: tls.VersionTLS12})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+		conn = tlsConn
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: make(http.Header),
+	}
+	if p.User != nil {
+		password, _ := p.User.Password()
+		auth := base64.StdEncoding.EncodeToString([]byte(p.User.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+auth)
+	}
+	if err := req.Write(conn); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = conn.Close()
+		return nil, fmt.Errorf("proxy %s refused connection to %s: %s", p.Host, addr, resp.Status)
+	}
+	return conn, nil
+}
+
+// normalizeThumbprint converts a SHA-256 thumbprint, with or without colon
+// separators, to lower case hex.
+func normalizeThumbprint(thumbprint string) (string, error) {
+	tp := strings.ToLower(strings.ReplaceAll(thumbprint, ":", ""))
+	if b, err := hex.DecodeString(tp); err != nil || len(b) != sha256.Size {
+		return "", fmt.Errorf("invalid SHA-256 thumbprint %q", thumbprint)
+	}
+	return tp, nil
+}
+
 // EnableDebug turns on govmomi API operation logging, if appropriate settings
 // are set on the provider.
 func (c *Config) EnableDebug() error {
@@ -297,7 +701,9 @@ func (c *Config) EnableDebug() error {
 		Path: r,
 	}
 
-	debug.SetProvider(&p)
+	// The traces are redacted, as they would otherwise contain the
+	// credentials, session cookies and customization secrets sent to vSphere.
+	debug.SetProvider(logging.NewRedactingDebugProvider(&p))
 	return nil
 }
 
@@ -307,7 +713,9 @@ func (c *Config) vimURLWithoutPassword() (*url.URL, error) {
 		return nil, err
 	}
 	withoutCredentials := u
-	withoutCredentials.User = url.User(u.User.Username())
+	if u.User != nil {
+		withoutCredentials.User = url.User(u.User.Username())
+	}
 	return withoutCredentials, nil
 }
 
@@ -355,7 +763,9 @@ func (c *Config) vimSessionFile() (string, error) {
 // Note the logic in this function has been largely adapted from govc and is
 // designed to be compatible with it.
 func (c *Config) SaveVimClient(client *govmomi.Client) error {
-	if !c.Persist {
+	// A session loaded from disk is not saved again, so that session_max_age
+	// counts from the time the session was created.
+	if !c.Persist || c.vimSessionRestored {
 		return nil
 	}
 
@@ -365,35 +775,84 @@ func (c *Config) SaveVimClient(client *govmomi.Client) error {
 	}
 
 	log.Printf("[DEBUG] Will persist SOAP client session data to %q", p)
-	err = os.MkdirAll(filepath.Dir(p), 0700)
+	b, err := json.Marshal(client.Client)
 	if err != nil {
 		return err
 	}
 
-	f, err := os.OpenFile(p, os.O_CREATE|os.O_WRONLY, 0600)
-	if err != nil {
-		return err
-	}
+	return c.writeSessionFile(p, b)
+}
 
-	defer func() {
-		if err = f.Close(); err != nil {
-			log.Printf("[DEBUG] Error closing SOAP client session file %q: %s", p, err)
-		}
-	}()
+// SaveRestClient saves a REST client session to the REST session path, in the
+// same location as govc.
+func (c *Config) SaveRestClient(client *rest.Client, s *cache.Session) error {
+	if !c.Persist || c.restSessionRestored || client == nil {
+		return nil
+	}
 
-	err = json.NewEncoder(f).Encode(client.Client)
+	p := restSessionCacheFile(s)
+	log.Printf("[DEBUG] Will persist REST client session data to %q", p)
+	b, err := json.Marshal(client)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return c.writeSessionFile(p, b)
 }
 
-func (c *Config) SaveRestClient(client *rest.Client, s *cache.Session) error {
+// LoadRestClient loads a saved REST session from disk, previously saved by
+// SaveRestClient, checking it for validity before returning it. A nil client
+// means that the session is no longer valid and should be created from
+// scratch.
+func (c *Config) LoadRestClient(ctx context.Context, s *cache.Session) (*rest.Client, error) {
 	if !c.Persist {
-		return nil
+		return nil, nil
+	}
+
+	p := restSessionCacheFile(s)
+	log.Printf("[DEBUG] Attempting to locate REST client session data in %q", p)
+	b, ok, err := c.readSessionFile(p)
+	if err != nil {
+		return nil, fmt.Errorf("error opening REST client session: %s", err)
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	client := new(rest.Client)
+	if err := json.Unmarshal(b, client); err != nil {
+		return nil, fmt.Errorf("error decoding REST client session: %s", err)
+	}
+	if !client.Valid() {
+		log.Println("[DEBUG] Cached REST client session data not valid, new session necessary")
+		return nil, nil
+	}
+
+	// The TLS settings are not part of the saved session.
+	if err := c.configureTransport(client.Client); err != nil {
+		return nil, err
 	}
-	return s.Save(client)
+	session, err := client.Session(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving current REST session: %s", err)
+	}
+	if session == nil {
+		log.Println("[DEBUG] Unauthenticated REST session, new session necessary")
+		return nil, nil
+	}
+
+	log.Println("[DEBUG] Cached REST client session loaded successfully")
+	c.restSessionRestored = true
+	return client, nil
+}
+
+// restSessionCacheFile returns the path of the REST session file for s, named
+// like the session files of the govmomi session cache.
+func restSessionCacheFile(s *cache.Session) string {
+	u := s.Endpoint()
+	u.Path = rest.Path
+	key := fmt.Sprintf("%s#insecure=%t", u.String(), s.Insecure)
+	return filepath.Join(s.DirREST, fmt.Sprintf("%064x", sha256.Sum256([]byte(key))))
 }
 
 // restoreVimClient loads the saved session from disk. Note that this is a helper
@@ -408,28 +867,26 @@ func (c *Config) restoreVimClient(client *vim25.Client) (bool, error) {
 		return false, fmt.Errorf("error determining SOAP session filename: %s", err)
 	}
 	log.Printf("[DEBUG] Attempting to locate SOAP client session data in %q", p)
-	f, err := os.Open(p)
+	b, ok, err := c.readSessionFile(p)
 	if err != nil {
-		if os.IsNotExist(err) {
-			log.Printf("[DEBUG] SOAP client session data not found in %q", p)
-			return false, nil
-		}
-
 		return false, fmt.Errorf("error opening SOAP client session: %s", err)
 	}
+	if !ok {
+		return false, nil
+	}
 
-	defer func() {
-		if err = f.Close(); err != nil {
-			log.Printf("[DEBUG] Error closing SOAP client session file %q: %s", p, err)
-		}
-	}()
-
-	dec := json.NewDecoder(f)
-	err = dec.Decode(client)
+	err = json.Unmarshal(b, client)
 	if err != nil {
 		return false, fmt.Errorf("error decoding SOAP client session: %s", err)
 	}
 
+	// The TLS settings are not part of the saved session.
+	if client.Client != nil {
+		if err := c.configureTransport(client.Client); err != nil {
+			return false, err
+		}
+	}
+
 	return true, nil
 }
 
@@ -475,6 +932,7 @@ func (c *Config) LoadVimClient() (*govmomi.Client, error) {
 	}
 
 	log.Println("[DEBUG] Cached SOAP client session loaded successfully")
+	c.vimSessionRestored = true
 	return &govmomi.Client{
 		Client:         client,
 		SessionManager: m,
@@ -493,17 +951,27 @@ func (c *Config) SavedVimSessionOrNew(u *url.URL) (*govmomi.Client, error) {
 	}
 	if client == nil {
 		log.Printf("[DEBUG] Creating new SOAP API session on endpoint %s", c.VSphereServer)
-		client, err = newClientWithKeepAlive(ctx, u, c.InsecureFlag, c.KeepAlive)
+		client, err = newClientWithKeepAlive(ctx, u, c.InsecureFlag, c.KeepAlive, c.configureTransport)
 		if err != nil {
 			return nil, fmt.Errorf("error setting up new vSphere SOAP client: %s", err)
 		}
+		if c.tokenAuth() {
+			if err := c.loginByToken(ctx, client); err != nil {
+				return nil, fmt.Errorf("error logging in to vSphere SOAP API with token: %s", err)
+			}
+		}
 		log.Println("[DEBUG] SOAP API session creation successful")
 	}
 	return client, nil
 }
 
-func newClientWithKeepAlive(ctx context.Context, u *url.URL, insecure bool, keepAlive int) (*govmomi.Client, error) {
+func newClientWithKeepAlive(ctx context.Context, u *url.URL, insecure bool, keepAlive int, config func(*soap.Client) error) (*govmomi.Client, error) {
 	soapClient := soap.NewClient(u, insecure)
+	if config != nil {
+		if err := config(soapClient); err != nil {
+			return nil, err
+		}
+	}
 	vimClient, err := vim25.NewClient(ctx, soapClient)
 	if err != nil {
 		return nil, err
@@ -528,6 +996,98 @@ func newClientWithKeepAlive(ctx context.Context, u *url.URL, insecure bool, keep
 	return c, nil
 }
 
+// samlToken returns the SAML token used to log in. A token supplied with
+// saml_token is used as is. An OIDC token is exchanged for a SAML bearer token
+// by the vCenter token exchange service. Otherwise, a holder-of-key token is
+// issued by the STS for the client certificate, on behalf of the user if one
+// is configured.
+func (c *Config) samlToken(ctx context.Context, vc *vim25.Client) (string, error) {
+	if c.SAMLToken != "" {
+		return c.SAMLToken, nil
+	}
+
+	if c.OIDCToken != "" {
+		log.Printf("[DEBUG] Exchanging OIDC token for a SAML token")
+		spec := authentication.TokenIssueSpec{
+			GrantType:          "urn:ietf:params:oauth:grant-type:token-exchange",
+			SubjectToken:       c.OIDCToken,
+			SubjectTokenType:   "urn:ietf:params:oauth:token-type:access_token",
+			RequestedTokenType: "urn:ietf:params:oauth:token-type:saml2",
+		}
+		info, err := authentication.NewManager(rest.NewClient(vc)).Issue(ctx, spec)
+		if err != nil {
+			return "", fmt.Errorf("error exchanging OIDC token: %s", err)
+		}
+		token, err := base64.StdEncoding.DecodeString(info.AccessToken)
+		if err != nil {
+			return "", fmt.Errorf("error decoding exchanged SAML token: %s", err)
+		}
+		return string(token), nil
+	}
+
+	log.Printf("[DEBUG] Requesting holder-of-key SAML token from the STS")
+	stsClient, err := sts.NewClient(ctx, vc)
+	if err != nil {
+		return "", fmt.Errorf("error creating STS client: %s", err)
+	}
+	req := sts.TokenRequest{
+		Certificate: vc.Certificate(),
+		Renewable:   true,
+		Delegatable: true,
+	}
+	if c.User != "" {
+		req.Userinfo = url.UserPassword(c.User, c.Password)
+	}
+	signer, err := stsClient.Issue(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("error issuing SAML token: %s", err)
+	}
+	return signer.Token, nil
+}
+
+// loginByToken logs in to the vSphere SOAP API with a SAML token. The request
+// is signed with the client certificate for holder-of-key tokens.
+func (c *Config) loginByToken(ctx context.Context, client *govmomi.Client) error {
+	token, err := c.samlToken(ctx, client.Client)
+	if err != nil {
+		return err
+	}
+	c.loginToken = token
+	header := soap.Header{
+		Security: &sts.Signer{
+			Certificate: client.Certificate(),
+			Token:       token,
+		},
+	}
+	// LoginByToken requires the service version in the SOAPAction header to be
+	// supported by the endpoint.
+	if client.Version == vim25.Version {
+		_ = client.UseServiceVersion()
+	}
+	return client.SessionManager.LoginByToken(client.WithHeader(ctx, header))
+}
+
+// restLoginByToken returns a function that logs in to the vSphere REST API
+// with a SAML token, for use as cache.Session.LoginREST. The token obtained for
+// the SOAP login is reused. A token is only obtained through the supplied SOAP
+// client when the SOAP session was restored from disk without a login.
+func (c *Config) restLoginByToken(vc *vim25.Client) func(context.Context, *rest.Client) error {
+	return func(ctx context.Context, rc *rest.Client) error {
+		token := c.loginToken
+		if token == "" {
+			var err error
+			if token, err = c.samlToken(ctx, vc); err != nil {
+				return err
+			}
+		}
+		signer := &sts.Signer{
+			Certificate: rc.Certificate(),
+			Token:       token,
+		}
+		return rc.LoginByToken(rc.WithSigner(ctx, signer))
+	}
+}
+
 func restSessionValid(client *rest.Client) bool {
 	sessionURL := client.URL().String() + "/com/vmware/cis/session?~action=get"
 	resp, err := client.Post(sessionURL, "", nil)
@@ -5,6 +5,8 @@
 package vsphere
 
 import (
+	"time"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/contentlibrary"
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/provider"
@@ -32,6 +34,21 @@ func dataSourceVSphereContentLibraryItem() *schema.Resource {
 				ForceNew:    true,
 				Description: "Type of content library item.",
 			},
+			"cached": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the content of the item is stored locally. Items in a subscribed library may not be cached until synchronized.",
+			},
+			"size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The size, in bytes, of the content library item files stored locally.",
+			},
+			"last_sync_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date and time when an item in a subscribed library was last synchronized.",
+			},
 		},
 	}
 }
@@ -44,6 +61,11 @@ func dataSourceVSphereContentLibraryItemRead(d *schema.ResourceData, meta interf
 		return provider.Error(d.Get("name").(string), "dataSourceVSphereContentLibraryItemRead", err)
 	}
 	_ = d.Set("type", item.Type)
+	_ = d.Set("cached", item.Cached)
+	_ = d.Set("size", item.Size)
+	if item.LastSyncTime != nil {
+		_ = d.Set("last_sync_time", item.LastSyncTime.Format(time.RFC3339))
+	}
 	d.SetId(item.ID)
 	return nil
 }
@@ -0,0 +1,137 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceVSphereHostFirewallRulesets() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceVSphereHostFirewallRulesetsRead,
+		Schema: map[string]*schema.Schema{
+			"host_system_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The managed object ID of the host to list the firewall rulesets of.",
+			},
+			"rulesets": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The firewall rulesets of the host.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The key of the firewall ruleset.",
+						},
+						"label": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The display label of the firewall ruleset.",
+						},
+						"enabled": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the firewall ruleset is enabled.",
+						},
+						"required": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the firewall ruleset is required and cannot be disabled.",
+						},
+						"service": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The key of the host service associated with the firewall ruleset.",
+						},
+						"allowed_all_ip": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether connections are allowed from any IP address.",
+						},
+						"allowed_ip_addresses": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The IP addresses allowed to connect to the services of the ruleset.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"allowed_ip_networks": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The networks allowed to connect to the services of the ruleset.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"rule": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The port definitions of the firewall ruleset.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"port": {
+										Type:        schema.TypeInt,
+										Computed:    true,
+										Description: "The port number.",
+									},
+									"end_port": {
+										Type:        schema.TypeInt,
+										Computed:    true,
+										Description: "The end of the port range, if the rule covers a range of ports.",
+									},
+									"direction": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The direction of the rule, either inbound or outbound.",
+									},
+									"port_type": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The port type of the rule, either src or dst.",
+									},
+									"protocol": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The protocol of the rule, such as tcp or udp.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceVSphereHostFirewallRulesetsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).vimClient
+	hsID := d.Get("host_system_id").(string)
+	fs, err := hostFirewallSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host firewall system: %s", err)
+	}
+	rulesets, err := hostFirewallRulesets(fs)
+	if err != nil {
+		return err
+	}
+
+	var result []interface{}
+	for _, rs := range rulesets {
+		m := flattenHostFirewallRulesetIPList(rs.AllowedHosts)
+		m["key"] = rs.Key
+		m["label"] = rs.Label
+		m["enabled"] = rs.Enabled
+		m["required"] = rs.Required
+		m["service"] = rs.Service
+		m["rule"] = flattenHostFirewallRules(rs.Rule)
+		result = append(result, m)
+	}
+
+	d.SetId(hsID)
+	return d.Set("rulesets", result)
+}
@@ -0,0 +1,50 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/testhelper"
+)
+
+func TestAccDataSourceVSphereHostFirewallRulesets_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			RunSweepers()
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereHostFirewallRulesetsConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("data.vsphere_host_firewall_rulesets.rulesets", "rulesets.*", map[string]string{
+						"key": "sshServer",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceVSphereHostFirewallRulesetsConfig() string {
+	return fmt.Sprintf(`
+%s
+
+data "vsphere_host" "esxi_host" {
+  name          = "%s"
+  datacenter_id = data.vsphere_datacenter.rootdc1.id
+}
+
+data "vsphere_host_firewall_rulesets" "rulesets" {
+  host_system_id = data.vsphere_host.esxi_host.id
+}
+`, testhelper.ConfigDataRootDC1(),
+		os.Getenv("TF_VAR_VSPHERE_ESXI3"))
+}
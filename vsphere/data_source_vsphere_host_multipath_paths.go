@@ -0,0 +1,155 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func dataSourceVSphereHostMultipathPaths() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceVSphereHostMultipathPathsRead,
+		Schema: map[string]*schema.Schema{
+			"host_system_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The managed object ID of the host to list the storage paths of.",
+			},
+			"lun_canonical_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The canonical name of a SCSI LUN to limit the results to.",
+			},
+			"lun": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The SCSI LUNs of the host and their paths.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"canonical_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The canonical name of the SCSI LUN.",
+						},
+						"path_selection_policy": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The path selection policy of the SCSI LUN.",
+						},
+						"storage_array_type_policy": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The storage array type policy of the SCSI LUN.",
+						},
+						"preferred_path": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The preferred path of the SCSI LUN, if it uses the VMW_PSP_FIXED policy.",
+						},
+						"path": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The paths to the SCSI LUN.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The name of the path.",
+									},
+									"adapter": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The device name of the host bus adapter of the path.",
+									},
+									"state": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The state of the path, such as active, standby, disabled or dead.",
+									},
+									"is_working_path": {
+										Type:        schema.TypeBool,
+										Computed:    true,
+										Description: "Whether the path is currently used for I/O.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceVSphereHostMultipathPathsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).vimClient
+	hsID := d.Get("host_system_id").(string)
+	ss, err := hostStorageSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host storage system: %s", err)
+	}
+	info, err := hostStorageDeviceInfo(client, ss)
+	if err != nil {
+		return err
+	}
+
+	var units []types.HostMultipathInfoLogicalUnit
+	if name := d.Get("lun_canonical_name").(string); name != "" {
+		lu, err := hostMultipathLogicalUnitFromCanonicalName(info, name)
+		if err != nil {
+			return err
+		}
+		units = append(units, *lu)
+	} else if info.MultipathInfo != nil {
+		units = info.MultipathInfo.Lun
+	}
+
+	names := hostScsiLunCanonicalNames(info)
+	adapters := hostBusAdapterDevices(info)
+	var luns []interface{}
+	for _, lu := range units {
+		luns = append(luns, flattenHostMultipathInfoLogicalUnit(lu, names, adapters))
+	}
+
+	d.SetId(hsID)
+	return d.Set("lun", luns)
+}
+
+// flattenHostMultipathInfoLogicalUnit returns the multipath information of a
+// SCSI LUN as a map suitable for the resource data.
+func flattenHostMultipathInfoLogicalUnit(lu types.HostMultipathInfoLogicalUnit, names, adapters map[string]string) map[string]interface{} {
+	var paths []interface{}
+	for _, p := range lu.Path {
+		state := p.State
+		if state == "" {
+			state = p.PathState
+		}
+		paths = append(paths, map[string]interface{}{
+			"name":            p.Name,
+			"adapter":         adapters[p.Adapter],
+			"state":           state,
+			"is_working_path": p.IsWorkingPath != nil && *p.IsWorkingPath,
+		})
+	}
+
+	m := map[string]interface{}{
+		"canonical_name": names[lu.Lun],
+		"path":           paths,
+	}
+	if lu.Policy != nil {
+		m["path_selection_policy"] = lu.Policy.GetHostMultipathInfoLogicalUnitPolicy().Policy
+		if fixed, ok := lu.Policy.(*types.HostMultipathInfoFixedLogicalUnitPolicy); ok {
+			m["preferred_path"] = fixed.Prefer
+		}
+	}
+	if lu.StorageArrayTypePolicy != nil {
+		m["storage_array_type_policy"] = lu.StorageArrayTypePolicy.Policy
+	}
+	return m
+}
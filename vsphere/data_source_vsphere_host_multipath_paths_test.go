@@ -0,0 +1,47 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/testhelper"
+)
+
+func TestAccDataSourceVSphereHostMultipathPaths_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			RunSweepers()
+			testAccPreCheck(t)
+			testAccCheckEnvVariables(t, []string{"TF_VAR_VSPHERE_DS_VMFS_ESXI1_DISK0"})
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereHostMultipathPathsConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vsphere_host_multipath_paths.paths", "lun.#", "1"),
+					resource.TestCheckResourceAttr("data.vsphere_host_multipath_paths.paths", "lun.0.canonical_name", os.Getenv("TF_VAR_VSPHERE_DS_VMFS_ESXI1_DISK0")),
+					resource.TestCheckResourceAttrSet("data.vsphere_host_multipath_paths.paths", "lun.0.path.0.state"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceVSphereHostMultipathPathsConfig() string {
+	return fmt.Sprintf(`
+%s
+
+data "vsphere_host_multipath_paths" "paths" {
+  host_system_id     = data.vsphere_host.roothost1.id
+  lun_canonical_name = "%s"
+}
+`, testhelper.CombineConfigs(testhelper.ConfigDataRootDC1(), testhelper.ConfigDataRootHost1()),
+		os.Getenv("TF_VAR_VSPHERE_DS_VMFS_ESXI1_DISK0"))
+}
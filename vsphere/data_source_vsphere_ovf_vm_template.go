@@ -164,6 +164,7 @@ func NewOvfHelperParamsFromVMDatasource(d *schema.ResourceData) *ovfdeploy.OvfHe
 func dataSourceVSphereOvfVMTemplateRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*Client).vimClient
 	ovfParams := NewOvfHelperParamsFromVMDatasource(d)
+	ovfParams.HTTPConfig = meta.(*Client).httpConfig
 	ovfHelper, err := ovfdeploy.NewOvfHelper(client, ovfParams)
 	if err != nil {
 		return fmt.Errorf("while extracting OVF parameters: %s", err)
@@ -1226,8 +1226,8 @@ func vmSweep(_ string) error {
 	}
 	for _, vm := range vms {
 		if regexp.MustCompile("testacc").Match([]byte(vm.Name())) {
-			_ = virtualmachine.PowerOff(vm)
-			_ = virtualmachine.Destroy(vm)
+			_ = virtualmachine.PowerOff(vm, defaultAPITimeout)
+			_ = virtualmachine.Destroy(vm, defaultAPITimeout)
 		}
 	}
 	return nil
@@ -1302,7 +1302,7 @@ func ccSweep(_ string) error {
 	}
 	for _, dsp := range dsps {
 		if regexp.MustCompile("testacc").Match([]byte(dsp.Name())) {
-			return clustercomputeresource.Delete(dsp)
+			return clustercomputeresource.Delete(dsp, defaultAPITimeout)
 		}
 	}
 	return nil
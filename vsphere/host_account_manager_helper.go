@@ -0,0 +1,97 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
+)
+
+// hostAccountManagerFromHostSystemID locates the HostLocalAccountManager of a
+// specified HostSystem managed object ID.
+func hostAccountManagerFromHostSystemID(client *govmomi.Client, hsID string) (*object.HostAccountManager, error) {
+	hs, err := hostsystem.FromID(client, hsID)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	return hs.ConfigManager().AccountManager(ctx)
+}
+
+// hostLocalAccountDescriptions returns the local accounts of a host, mapped to
+// their descriptions.
+//
+// The HostLocalAccountManager cannot list accounts when the host is managed by
+// vCenter Server, so the accounts are read through esxcli.
+func hostLocalAccountDescriptions(client *govmomi.Client, hs *object.HostSystem) (map[string]string, error) {
+	res, err := runHostEsxcli(client, hs, "system", "account", "list")
+	if err != nil {
+		return nil, fmt.Errorf("error listing local accounts: %s", err)
+	}
+	accounts := make(map[string]string)
+	for _, v := range res.Values {
+		accounts[esxcliValue(v, "UserID")] = esxcliValue(v, "Description")
+	}
+	return accounts, nil
+}
+
+// hostPermission is a permission on the root folder of a host, as reported by
+// esxcli.
+type hostPermission struct {
+	Principal string
+	IsGroup   bool
+	Role      string
+}
+
+// hostPermissions returns the permissions defined on the root folder of a
+// host.
+func hostPermissions(client *govmomi.Client, hs *object.HostSystem) ([]hostPermission, error) {
+	res, err := runHostEsxcli(client, hs, "system", "permission", "list")
+	if err != nil {
+		return nil, fmt.Errorf("error listing host permissions: %s", err)
+	}
+	var permissions []hostPermission
+	for _, v := range res.Values {
+		isGroup, _ := strconv.ParseBool(esxcliValue(v, "IsGroup"))
+		permissions = append(permissions, hostPermission{
+			Principal: esxcliValue(v, "Principal"),
+			IsGroup:   isGroup,
+			Role:      esxcliValue(v, "Role"),
+		})
+	}
+	return permissions, nil
+}
+
+// setHostPermission grants a role to a user or group on the root folder of a
+// host.
+func setHostPermission(client *govmomi.Client, hs *object.HostSystem, p hostPermission) error {
+	args := []string{"system", "permission", "set", "--id=" + p.Principal, "--role=" + p.Role}
+	if p.IsGroup {
+		args = append(args, "--group=true")
+	}
+	if _, err := runHostEsxcli(client, hs, args...); err != nil {
+		return fmt.Errorf("error setting permission for %s: %s", p.Principal, err)
+	}
+	return nil
+}
+
+// unsetHostPermission removes the permission of a user or group from the root
+// folder of a host.
+func unsetHostPermission(client *govmomi.Client, hs *object.HostSystem, p hostPermission) error {
+	args := []string{"system", "permission", "unset", "--id=" + p.Principal}
+	if p.IsGroup {
+		args = append(args, "--group=true")
+	}
+	if _, err := runHostEsxcli(client, hs, args...); err != nil {
+		return fmt.Errorf("error removing permission for %s: %s", p.Principal, err)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"log"
+
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
+)
+
+// hostCertificateManagerFromHostSystem locates the HostCertificateManager of a
+// specified HostSystem.
+func hostCertificateManagerFromHostSystem(hs *object.HostSystem) (*object.HostCertificateManager, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	return hs.ConfigManager().CertificateManager(ctx)
+}
+
+// hostCertificateManagerFromHostSystemID locates the HostCertificateManager of
+// a specified HostSystem managed object ID.
+func hostCertificateManagerFromHostSystemID(client *govmomi.Client, hsID string) (*object.HostCertificateManager, error) {
+	hs, err := hostsystem.FromID(client, hsID)
+	if err != nil {
+		return nil, err
+	}
+	return hostCertificateManagerFromHostSystem(hs)
+}
+
+// parseCertificatePEM parses the first PEM encoded X.509 certificate in s.
+func parseCertificatePEM(s string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(s))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("no PEM encoded certificate found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// certificateMatchesCSR returns true if the PEM encoded certificate in cert
+// was signed from the PEM encoded certificate signing request in csr, that is,
+// if both have the same public key.
+func certificateMatchesCSR(cert string, csr string) (bool, error) {
+	c, err := parseCertificatePEM(cert)
+	if err != nil {
+		return false, err
+	}
+	block, _ := pem.Decode([]byte(csr))
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return false, fmt.Errorf("no PEM encoded certificate signing request found")
+	}
+	req, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return false, err
+	}
+	pub, ok := c.PublicKey.(interface{ Equal(crypto.PublicKey) bool })
+	if !ok {
+		return false, fmt.Errorf("unsupported public key type %T", c.PublicKey)
+	}
+	return pub.Equal(req.PublicKey), nil
+}
+
+// validateCertificatePEM is a SchemaValidateFunc for PEM encoded X.509
+// certificates.
+func validateCertificatePEM(v interface{}, k string) ([]string, []error) {
+	if _, err := parseCertificatePEM(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%s: %s", k, err)}
+	}
+	return nil, nil
+}
+
+// certificatePEMListContains returns true if a list of PEM encoded
+// certificates contains the same certificate as s, regardless of the
+// formatting of the PEM blocks.
+func certificatePEMListContains(list []string, s string) bool {
+	cert, err := parseCertificatePEM(s)
+	if err != nil {
+		return false
+	}
+	for _, p := range list {
+		if c, err := parseCertificatePEM(p); err == nil && bytes.Equal(c.Raw, cert.Raw) {
+			return true
+		}
+	}
+	return false
+}
+
+// addHostCACertificates adds the supplied CA certificates to the trusted CA
+// certificates of a host. The CA certificates and revocation lists already
+// trusted by the host are kept.
+func addHostCACertificates(cm *object.HostCertificateManager, caCerts []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	current, err := cm.ListCACertificates(ctx)
+	if err != nil {
+		return fmt.Errorf("error listing CA certificates: %s", err)
+	}
+	crls, err := cm.ListCACertificateRevocationLists(ctx)
+	if err != nil {
+		return fmt.Errorf("error listing CA certificate revocation lists: %s", err)
+	}
+
+	certs := current
+	for _, c := range caCerts {
+		if !certificatePEMListContains(certs, c) {
+			certs = append(certs, c)
+		}
+	}
+	if len(certs) == len(current) {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Adding %d CA certificates to host %q", len(certs)-len(current), cm.Host.Reference().Value)
+	if err := cm.ReplaceCACertificatesAndCRLs(ctx, certs, crls); err != nil {
+		return fmt.Errorf("error updating CA certificates: %s", err)
+	}
+	return nil
+}
+
+// reconnectHostWithThumbprint reconnects a host to vCenter Server when the
+// SSL thumbprint that vCenter Server trusts for the host differs from the
+// supplied one, such as after a new certificate has been installed.
+func reconnectHostWithThumbprint(client *govmomi.Client, hs *object.HostSystem, thumbprint string) error {
+	if !client.IsVC() {
+		return nil
+	}
+	props, err := hostsystem.Properties(hs)
+	if err != nil {
+		return err
+	}
+	if props.Summary.Config.SslThumbprint == thumbprint {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Reconnecting host %q with SSL thumbprint %s", hs.Reference().Value, thumbprint)
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	task, err := hs.Reconnect(ctx, &types.HostConnectSpec{SslThumbprint: thumbprint}, nil)
+	if err != nil {
+		return fmt.Errorf("error reconnecting host: %s", err)
+	}
+	if err := task.Wait(ctx); err != nil {
+		return fmt.Errorf("error reconnecting host: %s", err)
+	}
+	return nil
+}
+
+// certificateThumbprintSHA1 returns the SHA-1 thumbprint of a PEM encoded
+// certificate, in the format used by vSphere.
+func certificateThumbprintSHA1(s string) (string, error) {
+	cert, err := parseCertificatePEM(s)
+	if err != nil {
+		return "", err
+	}
+	return soap.ThumbprintSHA1(cert), nil
+}
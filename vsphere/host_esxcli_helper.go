@@ -0,0 +1,35 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/cli/esx"
+	"github.com/vmware/govmomi/object"
+)
+
+// runHostEsxcli runs an esxcli command on a host, for settings that are not
+// part of the vSphere API. The arguments are the namespace, the command and
+// its options, such as "system", "syslog", "reload".
+func runHostEsxcli(client *govmomi.Client, hs *object.HostSystem, args ...string) (*esx.Response, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	e, err := esx.NewExecutor(ctx, client.Client, hs.Reference())
+	if err != nil {
+		return nil, fmt.Errorf("error creating esxcli executor: %s", err)
+	}
+	return e.Run(ctx, args)
+}
+
+// esxcliValue returns the first value of a field in an esxcli response row.
+func esxcliValue(v esx.Values, key string) string {
+	if len(v[key]) == 0 {
+		return ""
+	}
+	return v[key][0]
+}
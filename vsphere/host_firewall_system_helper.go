@@ -0,0 +1,170 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/types"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/structure"
+)
+
+const hostFirewallRulesetIDPrefix = "tf-HostFirewallRuleset"
+
+// hostFirewallSystemFromHostSystemID locates a HostFirewallSystem from a
+// specified HostSystem managed object ID.
+func hostFirewallSystemFromHostSystemID(client *govmomi.Client, hsID string) (*object.HostFirewallSystem, error) {
+	hs, err := hostsystem.FromID(client, hsID)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	return hs.ConfigManager().FirewallSystem(ctx)
+}
+
+// hostFirewallRulesets returns all rulesets known to the supplied
+// HostFirewallSystem.
+func hostFirewallRulesets(fs *object.HostFirewallSystem) ([]types.HostFirewallRuleset, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	info, err := fs.Info(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching host firewall information: %s", err)
+	}
+	if info == nil {
+		return nil, nil
+	}
+	return info.Ruleset, nil
+}
+
+// hostFirewallRulesetFromKey locates a ruleset on the supplied
+// HostFirewallSystem by its key.
+func hostFirewallRulesetFromKey(fs *object.HostFirewallSystem, key string) (*types.HostFirewallRuleset, error) {
+	rulesets, err := hostFirewallRulesets(fs)
+	if err != nil {
+		return nil, err
+	}
+	for _, rs := range rulesets {
+		if rs.Key == key {
+			return &rs, nil
+		}
+	}
+	return nil, fmt.Errorf("could not find firewall ruleset %s", key)
+}
+
+// updateHostFirewallRulesetAllowedHosts updates the list of hosts allowed to
+// connect to the services of a ruleset.
+func updateHostFirewallRulesetAllowedHosts(fs *object.HostFirewallSystem, key string, allowed types.HostFirewallRulesetIpList) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	req := types.UpdateRuleset{
+		This: fs.Reference(),
+		Id:   key,
+		Spec: types.HostFirewallRulesetRulesetSpec{
+			AllowedHosts: allowed,
+		},
+	}
+	_, err := methods.UpdateRuleset(ctx, fs.Client(), &req)
+	return err
+}
+
+// validateHostFirewallRulesetNetwork is a SchemaValidateFunc for networks in
+// CIDR notation. The network must be in its canonical form, without host bits
+// set, as the host returns networks in that form and any other form would
+// show a difference on every plan.
+func validateHostFirewallRulesetNetwork(v interface{}, k string) ([]string, []error) {
+	s := v.(string)
+	_, ipNet, err := net.ParseCIDR(s)
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s: expected a network in CIDR notation, got %q", k, s)}
+	}
+	if ipNet.String() != s {
+		return nil, []error{fmt.Errorf("%s: expected %q to be in canonical form, such as %q", k, s, ipNet.String())}
+	}
+	return nil, nil
+}
+
+// expandHostFirewallRulesetIPList reads the allowed hosts of a ruleset from
+// the resource data.
+func expandHostFirewallRulesetIPList(d *schema.ResourceData) (types.HostFirewallRulesetIpList, error) {
+	obj := types.HostFirewallRulesetIpList{
+		AllIp:     d.Get("allowed_all_ip").(bool),
+		IpAddress: structure.SliceInterfacesToStrings(d.Get("allowed_ip_addresses").(*schema.Set).List()),
+	}
+	for _, v := range d.Get("allowed_ip_networks").(*schema.Set).List() {
+		_, ipNet, err := net.ParseCIDR(v.(string))
+		if err != nil {
+			return obj, fmt.Errorf("error parsing network %q: %s", v, err)
+		}
+		prefix, _ := ipNet.Mask.Size()
+		obj.IpNetwork = append(obj.IpNetwork, types.HostFirewallRulesetIpNetwork{
+			Network:      ipNet.IP.String(),
+			PrefixLength: int32(prefix),
+		})
+	}
+	return obj, nil
+}
+
+// flattenHostFirewallRulesetIPList returns the allowed hosts of a ruleset as
+// a map suitable for the resource data.
+func flattenHostFirewallRulesetIPList(obj *types.HostFirewallRulesetIpList) map[string]interface{} {
+	if obj == nil {
+		return map[string]interface{}{
+			"allowed_all_ip":       true,
+			"allowed_ip_addresses": []interface{}{},
+			"allowed_ip_networks":  []interface{}{},
+		}
+	}
+	var networks []interface{}
+	for _, n := range obj.IpNetwork {
+		networks = append(networks, fmt.Sprintf("%s/%d", n.Network, n.PrefixLength))
+	}
+	return map[string]interface{}{
+		"allowed_all_ip":       obj.AllIp,
+		"allowed_ip_addresses": structure.SliceStringsToInterfaces(obj.IpAddress),
+		"allowed_ip_networks":  networks,
+	}
+}
+
+// flattenHostFirewallRules returns the port definitions of a ruleset as a
+// list suitable for the resource data.
+func flattenHostFirewallRules(rules []types.HostFirewallRule) []interface{} {
+	var result []interface{}
+	for _, r := range rules {
+		result = append(result, map[string]interface{}{
+			"port":      int(r.Port),
+			"end_port":  int(r.EndPort),
+			"direction": string(r.Direction),
+			"port_type": string(r.PortType),
+			"protocol":  r.Protocol,
+		})
+	}
+	return result
+}
+
+// saveHostFirewallRulesetID sets a special ID for a host firewall ruleset,
+// composed of the MOID for the concerned HostSystem and the ruleset's key.
+func saveHostFirewallRulesetID(d *schema.ResourceData, hsID, key string) {
+	d.SetId(fmt.Sprintf("%s:%s:%s", hostFirewallRulesetIDPrefix, hsID, key))
+}
+
+// splitHostFirewallRulesetID splits a vsphere_host_firewall_ruleset resource
+// ID into its counterparts: the HostSystem ID and the ruleset key.
+func splitHostFirewallRulesetID(raw string) (string, string, error) {
+	s := strings.SplitN(raw, ":", 3)
+	if len(s) != 3 || s[0] != hostFirewallRulesetIDPrefix || s[1] == "" || s[2] == "" {
+		return "", "", fmt.Errorf("corrupt ID: %s", raw)
+	}
+	return s[1], s[2], nil
+}
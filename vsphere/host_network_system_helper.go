@@ -7,6 +7,7 @@ package vsphere
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/vmware/govmomi"
 	"github.com/vmware/govmomi/object"
@@ -76,3 +77,77 @@ func hostPortGroupFromName(client *govmomi.Client, ns *object.HostNetworkSystem,
 
 	return nil, fmt.Errorf("could not find port group %s", name)
 }
+
+// hostNetworkInfo returns the DNS, routing and TCP/IP stack configuration of
+// the supplied HostNetworkSystem.
+func hostNetworkInfo(client *govmomi.Client, ns *object.HostNetworkSystem) (*types.HostNetworkInfo, error) {
+	var mns mo.HostNetworkSystem
+	pc := client.PropertyCollector()
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	props := []string{
+		"networkInfo.dnsConfig",
+		"networkInfo.ipRouteConfig",
+		"networkInfo.routeTableInfo",
+		"networkInfo.netStackInstance",
+	}
+	if err := pc.RetrieveOne(ctx, ns.Reference(), props, &mns); err != nil {
+		return nil, fmt.Errorf("error fetching host network properties: %s", err)
+	}
+	if mns.NetworkInfo == nil {
+		return &types.HostNetworkInfo{}, nil
+	}
+	return mns.NetworkInfo, nil
+}
+
+// hostNetStackInstanceFromKey locates a TCP/IP stack instance in the supplied
+// network information by its key. nil is returned if the instance does not
+// exist on the host.
+func hostNetStackInstanceFromKey(info *types.HostNetworkInfo, key string) *types.HostNetStackInstance {
+	for i := range info.NetStackInstance {
+		if info.NetStackInstance[i].Key == key {
+			return &info.NetStackInstance[i]
+		}
+	}
+	return nil
+}
+
+// addHostNetStackInstance creates a TCP/IP stack instance on a host.
+//
+// The vSphere API can only edit existing instances, so the instance is created
+// through esxcli.
+func addHostNetStackInstance(client *govmomi.Client, hs *object.HostSystem, key string) error {
+	return runHostNetStackCommand(client, hs, "add", key)
+}
+
+// removeHostNetStackInstance removes a TCP/IP stack instance from a host. The
+// stack must not be in use by any virtual NIC.
+func removeHostNetStackInstance(client *govmomi.Client, hs *object.HostSystem, key string) error {
+	return runHostNetStackCommand(client, hs, "remove", key)
+}
+
+func runHostNetStackCommand(client *govmomi.Client, hs *object.HostSystem, op, key string) error {
+	if _, err := runHostEsxcli(client, hs, "network", "ip", "netstack", op, "--netstack="+key); err != nil {
+		return fmt.Errorf("error running netstack %s for %s: %s", op, key, err)
+	}
+	return nil
+}
+
+// removeHostNetStackDefaultGateway removes the default route through the
+// supplied gateway from a TCP/IP stack instance on a host.
+func removeHostNetStackDefaultGateway(client *govmomi.Client, hs *object.HostSystem, key, gateway string) error {
+	family, network := "ipv4", "default"
+	if isIPv6Route(gateway) {
+		family, network = "ipv6", "::/0"
+	}
+	if _, err := runHostEsxcli(client, hs, "network", "ip", "route", family, "remove", "--network="+network, "--gateway="+gateway, "--netstack="+key); err != nil {
+		return fmt.Errorf("error removing default gateway %s from %s: %s", gateway, key, err)
+	}
+	return nil
+}
+
+// isIPv6Route returns true if the network of a static route is an IPv6
+// network.
+func isIPv6Route(network string) bool {
+	return strings.Contains(network, ":")
+}
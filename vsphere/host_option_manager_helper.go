@@ -0,0 +1,176 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
+)
+
+// hostOptionManagerFromHostSystemID locates the OptionManager holding the
+// advanced settings of a HostSystem by the host's managed object ID.
+func hostOptionManagerFromHostSystemID(client *govmomi.Client, hsID string) (*object.OptionManager, error) {
+	hs, err := hostsystem.FromID(client, hsID)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	return hs.ConfigManager().OptionManager(ctx)
+}
+
+// hostOptionDefinitions returns the option definitions supported by the
+// supplied OptionManager, keyed by option name.
+func hostOptionDefinitions(client *govmomi.Client, om *object.OptionManager) (map[string]types.OptionDef, error) {
+	var mom mo.OptionManager
+	pc := client.PropertyCollector()
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	if err := pc.RetrieveOne(ctx, om.Reference(), []string{"supportedOption"}, &mom); err != nil {
+		return nil, fmt.Errorf("error fetching supported options: %s", err)
+	}
+
+	defs := make(map[string]types.OptionDef)
+	for _, def := range mom.SupportedOption {
+		defs[def.Key] = def
+	}
+	return defs, nil
+}
+
+// hostOptionValueFromString converts the string representation of an option
+// value into the type expected by the option definition.
+func hostOptionValueFromString(def types.OptionDef, value string) (interface{}, error) {
+	if def.OptionType == nil {
+		return value, nil
+	}
+	if ro := def.OptionType.GetOptionType().ValueIsReadonly; ro != nil && *ro {
+		return nil, fmt.Errorf("option %q is read-only", def.Key)
+	}
+
+	switch t := def.OptionType.(type) {
+	case *types.BoolOption:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("option %q expects a boolean value, got %q", def.Key, value)
+		}
+		return v, nil
+	case *types.IntOption:
+		v, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("option %q expects an integer value, got %q", def.Key, value)
+		}
+		if t.Max > t.Min && (int32(v) < t.Min || int32(v) > t.Max) {
+			return nil, fmt.Errorf("option %q must be between %d and %d, got %d", def.Key, t.Min, t.Max, v)
+		}
+		return int32(v), nil
+	case *types.LongOption:
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("option %q expects an integer value, got %q", def.Key, value)
+		}
+		if t.Max > t.Min && (v < t.Min || v > t.Max) {
+			return nil, fmt.Errorf("option %q must be between %d and %d, got %d", def.Key, t.Min, t.Max, v)
+		}
+		return v, nil
+	case *types.FloatOption:
+		v, err := strconv.ParseFloat(value, 32)
+		if err != nil {
+			return nil, fmt.Errorf("option %q expects a floating point value, got %q", def.Key, value)
+		}
+		return float32(v), nil
+	case *types.ChoiceOption:
+		for _, c := range t.ChoiceInfo {
+			if c.GetElementDescription().Key == value {
+				return value, nil
+			}
+		}
+		return nil, fmt.Errorf("option %q does not accept the value %q", def.Key, value)
+	}
+	return value, nil
+}
+
+// hostOptionValueToString returns the string representation of an option
+// value as returned by the OptionManager.
+func hostOptionValueToString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case int32:
+		return strconv.FormatInt(int64(v), 10)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// hostOptionDefaultValue returns the default value of an option definition.
+func hostOptionDefaultValue(def types.OptionDef) (interface{}, error) {
+	switch t := def.OptionType.(type) {
+	case *types.BoolOption:
+		return t.DefaultValue, nil
+	case *types.IntOption:
+		return t.DefaultValue, nil
+	case *types.LongOption:
+		return t.DefaultValue, nil
+	case *types.FloatOption:
+		return t.DefaultValue, nil
+	case *types.StringOption:
+		return t.DefaultValue, nil
+	case *types.ChoiceOption:
+		if int(t.DefaultIndex) < len(t.ChoiceInfo) {
+			return t.ChoiceInfo[t.DefaultIndex].GetElementDescription().Key, nil
+		}
+	}
+	return nil, fmt.Errorf("option %q has no known default value", def.Key)
+}
+
+// expandHostOptionValues converts a map of option names and string values into
+// a list of typed option values, using the supplied option definitions.
+func expandHostOptionValues(defs map[string]types.OptionDef, settings map[string]interface{}) ([]types.BaseOptionValue, error) {
+	var values []types.BaseOptionValue
+	for k, v := range settings {
+		def, ok := defs[k]
+		if !ok {
+			return nil, fmt.Errorf("option %q is not supported by the host", k)
+		}
+		value, err := hostOptionValueFromString(def, v.(string))
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, &types.OptionValue{Key: k, Value: value})
+	}
+	return values, nil
+}
+
+// hostOptionDefaultValues returns a list of option values that reset the
+// supplied option names to their defaults.
+func hostOptionDefaultValues(defs map[string]types.OptionDef, keys []string) ([]types.BaseOptionValue, error) {
+	var values []types.BaseOptionValue
+	for _, k := range keys {
+		def, ok := defs[k]
+		if !ok {
+			continue
+		}
+		value, err := hostOptionDefaultValue(def)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, &types.OptionValue{Key: k, Value: value})
+	}
+	return values, nil
+}
@@ -0,0 +1,88 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
+)
+
+// hostPciPassthruSystemFromHostSystem returns a reference to the
+// HostPciPassthruSystem of a specified HostSystem.
+func hostPciPassthruSystemFromHostSystem(hs *object.HostSystem) (types.ManagedObjectReference, error) {
+	props, err := hostsystem.Properties(hs)
+	if err != nil {
+		return types.ManagedObjectReference{}, err
+	}
+	if props.ConfigManager.PciPassthruSystem == nil {
+		return types.ManagedObjectReference{}, fmt.Errorf("host %s does not support PCI passthrough", hs.Reference().Value)
+	}
+	return *props.ConfigManager.PciPassthruSystem, nil
+}
+
+// hostPciPassthruInfoFromID returns the passthrough information of a PCI
+// device of a host. For devices that support SR-IOV, the information is a
+// HostSriovInfo.
+func hostPciPassthruInfoFromID(client *govmomi.Client, hs *object.HostSystem, id string) (types.BaseHostPciPassthruInfo, error) {
+	ref, err := hostPciPassthruSystemFromHostSystem(hs)
+	if err != nil {
+		return nil, err
+	}
+	var mps mo.HostPciPassthruSystem
+	pc := client.PropertyCollector()
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	if err := pc.RetrieveOne(ctx, ref, []string{"pciPassthruInfo"}, &mps); err != nil {
+		return nil, fmt.Errorf("error fetching PCI passthrough properties: %s", err)
+	}
+	for _, info := range mps.PciPassthruInfo {
+		if info.GetHostPciPassthruInfo().Id == id {
+			return info, nil
+		}
+	}
+	return nil, fmt.Errorf("could not find PCI device %s", id)
+}
+
+// updateHostPciPassthruConfig applies the passthrough configuration of a PCI
+// device to a host, and refreshes the passthrough information of the host.
+func updateHostPciPassthruConfig(hs *object.HostSystem, config types.BaseHostPciPassthruConfig) error {
+	ref, err := hostPciPassthruSystemFromHostSystem(hs)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	req := types.UpdatePassthruConfig{
+		This:   ref,
+		Config: []types.BaseHostPciPassthruConfig{config},
+	}
+	if _, err := methods.UpdatePassthruConfig(ctx, hs.Client(), &req); err != nil {
+		return fmt.Errorf("error updating passthrough configuration of PCI device %s: %s", config.GetHostPciPassthruConfig().Id, err)
+	}
+	if _, err := methods.Refresh(ctx, hs.Client(), &types.Refresh{This: ref}); err != nil {
+		return fmt.Errorf("error refreshing PCI passthrough information: %s", err)
+	}
+	return nil
+}
+
+// hostPciPassthruRebootRequired returns true if the passthrough configuration
+// of a PCI device only takes effect after the host is rebooted.
+func hostPciPassthruRebootRequired(info types.BaseHostPciPassthruInfo) bool {
+	p := info.GetHostPciPassthruInfo()
+	if p.PassthruEnabled != p.PassthruActive {
+		return true
+	}
+	if sriov, ok := info.(*types.HostSriovInfo); ok {
+		return sriov.SriovEnabled != sriov.SriovActive || sriov.NumVirtualFunctionRequested != sriov.NumVirtualFunction
+	}
+	return false
+}
@@ -6,9 +6,14 @@ package vsphere
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 
 	"github.com/vmware/govmomi"
 	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
 )
 
@@ -23,3 +28,150 @@ func hostStorageSystemFromHostSystemID(client *govmomi.Client, hsID string) (*ob
 	defer cancel()
 	return hs.ConfigManager().StorageSystem(ctx)
 }
+
+// hostStorageDeviceInfo returns the storage device information of the
+// supplied HostStorageSystem.
+func hostStorageDeviceInfo(client *govmomi.Client, ss *object.HostStorageSystem) (*types.HostStorageDeviceInfo, error) {
+	var mss mo.HostStorageSystem
+	pc := client.PropertyCollector()
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	if err := pc.RetrieveOne(ctx, ss.Reference(), []string{"storageDeviceInfo"}, &mss); err != nil {
+		return nil, fmt.Errorf("error fetching host storage properties: %s", err)
+	}
+	if mss.StorageDeviceInfo == nil {
+		return nil, fmt.Errorf("storage device information is not available on host")
+	}
+	return mss.StorageDeviceInfo, nil
+}
+
+// hostSoftwareInternetScsiHba locates the software iSCSI adapter on the
+// supplied HostStorageSystem. A nil adapter is returned if the software iSCSI
+// initiator is not enabled.
+func hostSoftwareInternetScsiHba(client *govmomi.Client, ss *object.HostStorageSystem) (*types.HostInternetScsiHba, error) {
+	info, err := hostStorageDeviceInfo(client, ss)
+	if err != nil {
+		return nil, err
+	}
+	if !info.SoftwareInternetScsiEnabled {
+		return nil, nil
+	}
+	for _, hba := range info.HostBusAdapter {
+		if iscsi, ok := hba.(*types.HostInternetScsiHba); ok && iscsi.IsSoftwareBased {
+			return iscsi, nil
+		}
+	}
+	return nil, nil
+}
+
+// hostInternetScsiHbaFromDevice locates an iSCSI adapter on the supplied
+// HostStorageSystem by its device name, such as vmhba65.
+func hostInternetScsiHbaFromDevice(client *govmomi.Client, ss *object.HostStorageSystem, device string) (*types.HostInternetScsiHba, error) {
+	info, err := hostStorageDeviceInfo(client, ss)
+	if err != nil {
+		return nil, err
+	}
+	for _, hba := range info.HostBusAdapter {
+		if iscsi, ok := hba.(*types.HostInternetScsiHba); ok && iscsi.Device == device {
+			return iscsi, nil
+		}
+	}
+	return nil, fmt.Errorf("could not find iSCSI adapter %s", device)
+}
+
+// rescanHostBusAdapter rescans the supplied host bus adapter for new storage
+// devices and then rescans the host for new VMFS volumes.
+func rescanHostBusAdapter(ss *object.HostStorageSystem, device string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	req := types.RescanHba{
+		This:      ss.Reference(),
+		HbaDevice: device,
+	}
+	if _, err := methods.RescanHba(ctx, ss.Client(), &req); err != nil {
+		return fmt.Errorf("error rescanning adapter %s: %s", device, err)
+	}
+	if err := ss.RescanVmfs(ctx); err != nil {
+		return fmt.Errorf("error rescanning VMFS volumes: %s", err)
+	}
+	return nil
+}
+
+// hostMultipathLogicalUnitFromCanonicalName locates the multipath information
+// of a SCSI LUN by the LUN's canonical name, such as naa.600a098038303053.
+func hostMultipathLogicalUnitFromCanonicalName(info *types.HostStorageDeviceInfo, name string) (*types.HostMultipathInfoLogicalUnit, error) {
+	if info.MultipathInfo == nil {
+		return nil, fmt.Errorf("multipath information is not available on host")
+	}
+	var key string
+	for _, lun := range info.ScsiLun {
+		if l := lun.GetScsiLun(); l.CanonicalName == name {
+			key = l.Key
+			break
+		}
+	}
+	if key == "" {
+		return nil, fmt.Errorf("could not find SCSI LUN %s", name)
+	}
+	for _, lu := range info.MultipathInfo.Lun {
+		if lu.Lun == key {
+			return &lu, nil
+		}
+	}
+	return nil, fmt.Errorf("could not find multipath information for SCSI LUN %s", name)
+}
+
+// hostScsiLunCanonicalNames returns the canonical names of the SCSI LUNs of a
+// host, keyed by LUN key.
+func hostScsiLunCanonicalNames(info *types.HostStorageDeviceInfo) map[string]string {
+	names := make(map[string]string)
+	for _, lun := range info.ScsiLun {
+		l := lun.GetScsiLun()
+		names[l.Key] = l.CanonicalName
+	}
+	return names
+}
+
+// hostBusAdapterDevices returns the device names of the host bus adapters of
+// a host, keyed by adapter key.
+func hostBusAdapterDevices(info *types.HostStorageDeviceInfo) map[string]string {
+	devices := make(map[string]string)
+	for _, hba := range info.HostBusAdapter {
+		h := hba.GetHostHostBusAdapter()
+		devices[h.Key] = h.Device
+	}
+	return devices
+}
+
+// hostRoundRobinIopsLimit returns the number of I/O operations after which
+// the round robin path selection policy switches paths for a device. The
+// limit is 0 if the device does not switch paths based on I/O operations.
+//
+// The limit is not part of the vSphere API and is read through esxcli.
+func hostRoundRobinIopsLimit(client *govmomi.Client, hs *object.HostSystem, device string) (int, error) {
+	res, err := runHostEsxcli(client, hs, "storage", "nmp", "psp", "roundrobin", "deviceconfig", "get", "--device="+device)
+	if err != nil {
+		return 0, fmt.Errorf("error reading round robin configuration of device %s: %s", device, err)
+	}
+	for _, v := range res.Values {
+		if esxcliValue(v, "LimitType") != "Iops" || esxcliValue(v, "IOOperationLimit") == "" {
+			continue
+		}
+		limit, err := strconv.Atoi(esxcliValue(v, "IOOperationLimit"))
+		if err != nil {
+			return 0, fmt.Errorf("error parsing I/O operation limit of device %s: %s", device, err)
+		}
+		return limit, nil
+	}
+	return 0, nil
+}
+
+// setHostRoundRobinIopsLimit sets the number of I/O operations after which the
+// round robin path selection policy switches paths for a device.
+func setHostRoundRobinIopsLimit(client *govmomi.Client, hs *object.HostSystem, device string, iops int) error {
+	args := []string{"storage", "nmp", "psp", "roundrobin", "deviceconfig", "set", "--device=" + device, "--type=iops", "--iops=" + strconv.Itoa(iops)}
+	if _, err := runHostEsxcli(client, hs, args...); err != nil {
+		return fmt.Errorf("error setting round robin I/O operation limit of device %s: %s", device, err)
+	}
+	return nil
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/folder"
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/provider"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/viapi"
 )
 
 // FromID locates a cluster by its managed object reference ID.
@@ -121,13 +122,15 @@ func Create(f *object.Folder, name string, spec types.ClusterConfigSpecEx) (*obj
 // Rename renames a ClusterComputeResource.
 func Rename(cluster *object.ClusterComputeResource, name string) error {
 	log.Printf("[DEBUG] Renaming compute cluster %q to %s", cluster.InventoryPath, name)
-	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
-	defer cancel()
-	task, err := cluster.Rename(ctx, name)
-	if err != nil {
-		return err
-	}
-	return task.WaitEx(ctx)
+	return viapi.Retry(cluster.Client(), "clustercomputeresource.Rename", func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+		defer cancel()
+		task, err := cluster.Rename(ctx, name)
+		if err != nil {
+			return err
+		}
+		return task.WaitEx(ctx)
+	})
 }
 
 // MoveToFolder is a complex method that moves a ClusterComputeResource to a given relative
@@ -157,16 +160,25 @@ func Reconfigure(cluster *object.ClusterComputeResource, spec *types.ClusterConf
 	return computeresource.Reconfigure(cluster, spec)
 }
 
-// Delete destroys a ClusterComputeResource.
-func Delete(cluster *object.ClusterComputeResource) error {
+// ReconfigureWithTimeout is Reconfigure with a timeout for the reconfigure
+// task.
+func ReconfigureWithTimeout(cluster *object.ClusterComputeResource, spec *types.ClusterConfigSpecEx, timeout time.Duration) error {
+	return computeresource.ReconfigureWithTimeout(cluster, spec, timeout)
+}
+
+// Delete destroys a ClusterComputeResource, waiting up to timeout for the
+// destroy task to complete.
+func Delete(cluster *object.ClusterComputeResource, timeout time.Duration) error {
 	log.Printf("[DEBUG] Deleting compute cluster %q", cluster.InventoryPath)
-	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
-	defer cancel()
-	task, err := cluster.Destroy(ctx)
-	if err != nil {
-		return err
-	}
-	return task.WaitEx(ctx)
+	return viapi.Retry(cluster.Client(), "clustercomputeresource.Delete", func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		task, err := cluster.Destroy(ctx)
+		if err != nil {
+			return err
+		}
+		return task.WaitEx(ctx)
+	})
 }
 
 func Hosts(cluster *object.ClusterComputeResource) ([]*object.HostSystem, error) {
@@ -230,15 +242,17 @@ func MoveHostsInto(client *govmomi.Client, cluster *object.ClusterComputeResourc
 		Host: hsRefs,
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
-	defer cancel()
-	resp, err := methods.MoveInto_Task(ctx, cluster.Client(), &req)
-	if err != nil {
-		return err
-	}
+	return viapi.Retry(cluster.Client(), "clustercomputeresource.MoveHostsInto", func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+		defer cancel()
+		resp, err := methods.MoveInto_Task(ctx, cluster.Client(), &req)
+		if err != nil {
+			return err
+		}
 
-	task := object.NewTask(cluster.Client(), resp.Returnval)
-	return task.WaitEx(ctx)
+		task := object.NewTask(cluster.Client(), resp.Returnval)
+		return task.WaitEx(ctx)
+	})
 }
 
 // MoveHostsOutOf moves a supplied list of hosts out of the specified cluster.
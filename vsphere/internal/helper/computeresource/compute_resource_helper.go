@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/vmware/govmomi"
 	"github.com/vmware/govmomi/find"
@@ -18,6 +19,7 @@ import (
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/envbrowse"
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/provider"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/viapi"
 )
 
 // BaseComputeResource is an interface that ComputeResource and any derivative
@@ -210,6 +212,12 @@ func EnvironmentBrowserFromReference(client *govmomi.Client, ref types.ManagedOb
 // BaseComputeResourceConfigSpec as configuration (example: standalone hosts,
 // or clusters). Modify is always set.
 func Reconfigure(obj BaseComputeResource, spec types.BaseComputeResourceConfigSpec) error {
+	return ReconfigureWithTimeout(obj, spec, provider.DefaultAPITimeout)
+}
+
+// ReconfigureWithTimeout is Reconfigure with a timeout for the reconfigure
+// task.
+func ReconfigureWithTimeout(obj BaseComputeResource, spec types.BaseComputeResourceConfigSpec, timeout time.Duration) error {
 	var c *object.ComputeResource
 	switch t := obj.(type) {
 	case *object.ComputeResource:
@@ -222,13 +230,15 @@ func Reconfigure(obj BaseComputeResource, spec types.BaseComputeResourceConfigSp
 		return fmt.Errorf("unsupported type for reconfigure: %T", t)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
-	defer cancel()
-	task, err := c.Reconfigure(ctx, spec, true)
-	if err != nil {
-		return err
-	}
-	return task.WaitEx(ctx)
+	return viapi.Retry(c.Client(), "computeresource.Reconfigure", func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		task, err := c.Reconfigure(ctx, spec, true)
+		if err != nil {
+			return err
+		}
+		return task.WaitEx(ctx)
+	})
 }
 
 // HasChildren checks to see if a compute resource has any child items (hosts
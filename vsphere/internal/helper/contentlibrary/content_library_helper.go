@@ -7,7 +7,13 @@ package contentlibrary
 import (
 	"archive/tar"
 	"context"
+	"crypto/md5"  //nolint
+	"crypto/sha1" //nolint
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
 	"fmt"
+	"hash"
 	"io"
 	"log"
 	"net/http"
@@ -24,6 +30,7 @@ import (
 	"github.com/vmware/govmomi/vapi/rest"
 	"github.com/vmware/govmomi/vapi/vcenter"
 	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/datastore"
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/ovfdeploy"
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/provider"
@@ -83,17 +90,12 @@ func CreateLibrary(d *schema.ResourceData, restclient *rest.Client, backings []l
 			UserName:             publication["username"].(string),
 			Password:             publication["password"].(string),
 		}
+		if lib.Publication.Password == "" {
+			lib.Publication.Password = structure.GetWriteOnlyString(d, "publication_password_wo")
+		}
 	}
 	if len(d.Get("subscription").([]interface{})) > 0 {
-		subscription := d.Get("subscription").([]interface{})[0].(map[string]interface{})
-		lib.Subscription = &library.Subscription{
-			AutomaticSyncEnabled: structure.BoolPtr(subscription["automatic_sync"].(bool)),
-			OnDemand:             structure.BoolPtr(subscription["on_demand"].(bool)),
-			AuthenticationMethod: subscription["authentication_method"].(string),
-			UserName:             subscription["username"].(string),
-			Password:             subscription["password"].(string),
-			SubscriptionURL:      subscription["subscription_url"].(string),
-		}
+		lib.Subscription = ExpandSubscription(d)
 		lib.Type = "SUBSCRIBED"
 	}
 	id, err := clm.CreateLibrary(ctx, lib)
@@ -104,6 +106,45 @@ func CreateLibrary(d *schema.ResourceData, restclient *rest.Client, backings []l
 	return id, nil
 }
 
+// ExpandSubscription reads the subscription of a Content Library from
+// ResourceData, with the password taken from subscription_password_wo if it
+// is not set in the subscription block.
+func ExpandSubscription(d *schema.ResourceData) *library.Subscription {
+	subscription := d.Get("subscription").([]interface{})[0].(map[string]interface{})
+	obj := &library.Subscription{
+		AutomaticSyncEnabled: structure.BoolPtr(subscription["automatic_sync"].(bool)),
+		OnDemand:             structure.BoolPtr(subscription["on_demand"].(bool)),
+		AuthenticationMethod: subscription["authentication_method"].(string),
+		UserName:             subscription["username"].(string),
+		Password:             subscription["password"].(string),
+		SubscriptionURL:      subscription["subscription_url"].(string),
+	}
+	if obj.Password == "" {
+		obj.Password = structure.GetWriteOnlyString(d, "subscription_password_wo")
+	}
+	return obj
+}
+
+// UpdateSubscription updates the subscription of a subscribed Content Library,
+// such as to change the password used to authenticate with the publisher.
+func UpdateSubscription(c *rest.Client, id string, subscription *library.Subscription) error {
+	log.Printf("[DEBUG] contentlibrary.UpdateSubscription: Updating subscription of content library %s", id)
+	ctx := context.TODO()
+	spec := struct {
+		Library library.Library `json:"update_spec"`
+	}{
+		Library: library.Library{
+			Subscription: subscription,
+		},
+	}
+	r := c.Resource("/com/vmware/content/subscribed-library").WithID(id)
+	if err := c.Do(ctx, r.Request(http.MethodPatch, spec), nil); err != nil {
+		return provider.Error(id, "UpdateSubscription", err)
+	}
+	log.Printf("[DEBUG] contentlibrary.UpdateSubscription: Successfully updated subscription of content library %s", id)
+	return nil
+}
+
 // DeleteLibrary deletes a Content Library.
 func DeleteLibrary(c *rest.Client, lib *library.Library) error {
 	log.Printf("[DEBUG] contentlibrary.DeleteLibrary: Deleting library %s", lib.Name)
@@ -161,44 +202,195 @@ func IsContentLibraryItem(c *rest.Client, id string) bool {
 	return item != nil
 }
 
-// CreateLibraryItem creates an item in a Content Library.
-func CreateLibraryItem(c *rest.Client, l *library.Library, name string, desc string, t string, file string, moid string) (*string, error) {
+// CreateLibraryItem creates an item in a Content Library. The upload or export
+// of the item content is aborted if it does not complete within timeout. A
+// timeout of 0 waits indefinitely.
+func CreateLibraryItem(c *rest.Client, httpConfig *ovfdeploy.HTTPConfig, l *library.Library, name string, desc string, t string, file string, checksum string, publish *PublishSpec, timeout time.Duration) (*string, error) {
 	log.Printf("[DEBUG] contentlibrary.CreateLibraryItem: Creating content library item %s.", name)
 	clm := library.NewManager(c)
-	ctx := context.TODO()
+	ctx, cancel := provider.WithTimeout(timeout)
+	defer cancel()
 	item := library.Item{
 		Description: &desc,
 		LibraryID:   l.ID,
 		Name:        name,
 		Type:        t,
 	}
+	if publish != nil {
+		uploadSession := libraryUploadSession{
+			Context:               ctx,
+			ContentLibraryManager: clm,
+			RestClient:            c,
+			LibraryID:             l.ID,
+		}
+		id, err := uploadSession.cloneTemplate(publish, name, desc, t, "")
+		if err != nil {
+			return nil, provider.Error(name, "CreateLibraryItem", err)
+		}
+		return id, nil
+	}
+
+	id, err := clm.CreateLibraryItem(ctx, item)
+	if err != nil {
+		return nil, provider.Error(name, "CreateLibraryItem", err)
+	}
+	if err := uploadLibraryItemContent(ctx, c, httpConfig, id, file, checksum); err != nil {
+		return &id, provider.Error(name, "CreateLibraryItem", err)
+	}
+
+	log.Printf("[DEBUG] contentlibrary.CreateLibraryItem: Successfully created content library item %s.", name)
+	return &id, nil
+}
+
+// UpdateLibraryItem updates the name and description of an existing Content
+// Library item.
+func UpdateLibraryItem(c *rest.Client, item *library.Item, name string, desc string) error {
+	log.Printf("[DEBUG] contentlibrary.UpdateLibraryItem: Updating content library item %s.", item.ID)
+	clm := library.NewManager(c)
+	ctx := context.TODO()
+	update := library.Item{
+		ID:          item.ID,
+		Name:        name,
+		Description: &desc,
+	}
+	if err := clm.UpdateLibraryItem(ctx, &update); err != nil {
+		return provider.Error(item.ID, "UpdateLibraryItem", err)
+	}
+	log.Printf("[DEBUG] contentlibrary.UpdateLibraryItem: Successfully updated content library item %s.", item.ID)
+	return nil
+}
+
+// UpdateLibraryItemContent replaces the content of an existing Content Library
+// item with the supplied file. A new update session is opened against the
+// same item, so the item ID is preserved and the item content version is
+// incremented once the session completes. The upload is aborted if it does not
+// complete within timeout. A timeout of 0 waits indefinitely.
+func UpdateLibraryItemContent(c *rest.Client, httpConfig *ovfdeploy.HTTPConfig, item *library.Item, file string, checksum string, timeout time.Duration) error {
+	log.Printf("[DEBUG] contentlibrary.UpdateLibraryItemContent: Updating content of library item %s from %s.", item.ID, file)
+	ctx, cancel := provider.WithTimeout(timeout)
+	defer cancel()
+	if err := uploadLibraryItemContent(ctx, c, httpConfig, item.ID, file, checksum); err != nil {
+		return provider.Error(item.ID, "UpdateLibraryItemContent", err)
+	}
+	log.Printf("[DEBUG] contentlibrary.UpdateLibraryItemContent: Successfully updated content of library item %s.", item.ID)
+	return nil
+}
+
+// RepublishLibraryItem replaces the content of an existing ovf Content Library
+// item with a new export of the source virtual machine. The item ID is
+// preserved. The export is aborted if it does not complete within timeout. A
+// timeout of 0 waits indefinitely.
+func RepublishLibraryItem(c *rest.Client, item *library.Item, desc string, publish *PublishSpec, timeout time.Duration) error {
+	log.Printf("[DEBUG] contentlibrary.RepublishLibraryItem: Republishing library item %s from virtual machine %s.", item.ID, publish.SourceMOID)
+	ctx, cancel := provider.WithTimeout(timeout)
+	defer cancel()
 	uploadSession := libraryUploadSession{
-		ContentLibraryManager: clm,
+		Context:               ctx,
+		ContentLibraryManager: library.NewManager(c),
 		RestClient:            c,
-		LibraryID:             l.ID,
+		LibraryID:             item.LibraryID,
 	}
-	if moid != "" {
-		return uploadSession.cloneTemplate(moid, name, t)
+	if _, err := uploadSession.cloneTemplate(publish, item.Name, desc, item.Type, item.ID); err != nil {
+		return provider.Error(item.ID, "RepublishLibraryItem", err)
 	}
+	log.Printf("[DEBUG] contentlibrary.RepublishLibraryItem: Successfully republished library item %s.", item.ID)
+	return nil
+}
 
-	id, err := clm.CreateLibraryItem(ctx, item)
+// FileChecksum returns the hex encoded SHA-256 checksum of a local file.
+// Remote files are not checksummed and an empty string is returned.
+func FileChecksum(file string) (string, error) {
+	if file == "" || isRemoteFile(file) {
+		return "", nil
+	}
+	f, err := os.Open(filepath.Clean(file))
 	if err != nil {
-		return nil, provider.Error(name, "CreateLibraryItem", err)
+		return "", err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// FileStat returns a fingerprint of the size and modification time of a
+// local file, used to tell whether the file changed without reading it.
+// Remote files are not checked and an empty string is returned.
+func FileStat(file string) (string, error) {
+	if file == "" || isRemoteFile(file) {
+		return "", nil
+	}
+	fi, err := os.Stat(filepath.Clean(file))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d:%d", fi.Size(), fi.ModTime().UnixNano()), nil
+}
+
+// verifyFileChecksum checks that the content of a local file matches the
+// supplied SHA-256 checksum. Remote files are verified by vCenter Server and
+// are skipped.
+func verifyFileChecksum(file string, checksum string) error {
+	if checksum == "" || isRemoteFile(file) {
+		return nil
+	}
+	actual, err := FileChecksum(file)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(actual, checksum) {
+		return fmt.Errorf("checksum mismatch for file %s: expected %s, got %s", file, checksum, actual)
+	}
+	return nil
+}
+
+func isRemoteFile(file string) bool {
+	return strings.HasPrefix(file, "http")
+}
+
+// uploadLibraryItemContent opens an update session against the library item
+// and uploads the supplied file into it. Files previously held by the item
+// that are not part of the new content are removed from the item when the
+// session completes. The session is only completed when the upload succeeds,
+// and is failed otherwise so that the item keeps its previous content.
+func uploadLibraryItemContent(ctx context.Context, c *rest.Client, httpConfig *ovfdeploy.HTTPConfig, id string, file string, checksum string) (err error) {
+	if err := verifyFileChecksum(file, checksum); err != nil {
+		return err
 	}
+	clm := library.NewManager(c)
 	session, err := clm.CreateLibraryItemUpdateSession(ctx, library.Session{LibraryItemID: id})
 	if err != nil {
-		return nil, provider.Error(name, "CreateLibraryItem", err)
+		return err
+	}
+	uploadSession := libraryUploadSession{
+		Context:               ctx,
+		ContentLibraryManager: clm,
+		RestClient:            c,
+		HTTPConfig:            httpConfig,
+		UploadSession:         session,
+		Checksum:              checksum,
 	}
-	uploadSession.UploadSession = session
 	defer func() {
-		_ = clm.CompleteLibraryItemUpdateSession(ctx, session)
+		if err != nil {
+			if ferr := clm.FailLibraryItemUpdateSession(ctx, session); ferr != nil {
+				log.Printf("[DEBUG] uploadLibraryItemContent : Failing update session %s: %s", session, ferr)
+			}
+			return
+		}
+		if err = clm.CompleteLibraryItemUpdateSession(ctx, session); err != nil {
+			err = fmt.Errorf("error completing update session for library item %s: %s", id, err)
+		}
 	}()
 
 	isOva := false
 	isLocal := true
 	isIso := false
 
-	if strings.HasPrefix(file, "http") {
+	if isRemoteFile(file) {
 		isLocal = false
 	}
 	if strings.HasSuffix(file, ".ova") {
@@ -208,31 +400,70 @@ func CreateLibraryItem(c *rest.Client, l *library.Library, name string, desc str
 		isIso = true
 	}
 
-	ovfDescriptor, err := ovfdeploy.GetOvfDescriptor(file, isOva, isLocal, true)
+	ovfDescriptor, err := ovfdeploy.GetOvfDescriptor(file, isOva, isLocal, ovfdeploy.NewHTTPClient(httpConfig, true))
 	if err != nil {
-		return nil, provider.Error(name, "CreateLibraryItem", err)
+		return err
 	}
 
 	switch {
 	case isLocal && isOva:
-		return &id, uploadSession.deployLocalOva(file, ovfDescriptor)
+		err = uploadSession.deployLocalOva(file, ovfDescriptor)
 	case isLocal && !isOva && !isIso:
-		return &id, uploadSession.deployLocalOvf(file, ovfDescriptor)
+		err = uploadSession.deployLocalOvf(file, ovfDescriptor)
 	case isLocal && isIso:
-		return &id, uploadSession.deployLocalIso(file)
+		err = uploadSession.deployLocalIso(file)
 	case !isLocal && isOva:
-		return &id, uploadSession.deployRemoteOva(file, ovfDescriptor)
+		err = uploadSession.deployRemoteOva(file, ovfDescriptor)
 	case !isLocal && !isOva:
-		return &id, uploadSession.deployRemoteOvf(file)
+		// Files referenced by a remote OVF are pulled by vCenter itself, so the
+		// previous files held by the item are left in place.
+		return uploadSession.deployRemoteOvf(file)
 	}
+	if err != nil {
+		return err
+	}
+	return uploadSession.removeStaleFiles(id)
+}
 
-	log.Printf("[DEBUG] contentlibrary.CreateLibraryItem: Successfully created content library item %s.", name)
-	return &id, nil
+// removeStaleFiles marks any file currently held by the library item that was
+// not added to the update session for removal.
+func (uploadSession *libraryUploadSession) removeStaleFiles(id string) error {
+	ctx := uploadSession.Context
+	clm := uploadSession.ContentLibraryManager
+	existing, err := clm.ListLibraryItemFiles(ctx, id)
+	if err != nil {
+		return err
+	}
+	if len(existing) == 0 {
+		return nil
+	}
+	added, err := clm.ListLibraryItemUpdateSessionFile(ctx, uploadSession.UploadSession)
+	if err != nil {
+		return err
+	}
+	keep := make(map[string]bool)
+	for _, f := range added {
+		keep[f.Name] = true
+	}
+	for _, f := range existing {
+		if keep[f.Name] {
+			continue
+		}
+		log.Printf("[DEBUG] contentlibrary.removeStaleFiles: Removing file %s from library item %s", f.Name, id)
+		if err := clm.RemoveLibraryItemUpdateSessionFile(ctx, uploadSession.UploadSession, f.Name); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (uploadSession *libraryUploadSession) deployRemoteOvf(file string) error {
-	ctx := context.TODO()
-	_, err := uploadSession.ContentLibraryManager.AddLibraryItemFileFromURI(ctx, uploadSession.UploadSession, filepath.Base(file), file)
+	ctx := uploadSession.Context
+	var checksum []library.Checksum
+	if uploadSession.Checksum != "" {
+		checksum = append(checksum, library.Checksum{Algorithm: "SHA256", Checksum: uploadSession.Checksum})
+	}
+	_, err := uploadSession.ContentLibraryManager.AddLibraryItemFileFromURI(ctx, uploadSession.UploadSession, filepath.Base(file), file, checksum...)
 	if err != nil {
 		return err
 	}
@@ -293,24 +524,48 @@ func (uploadSession *libraryUploadSession) deployLocalIso(file string) error {
 }
 
 type libraryUploadSession struct {
+	// The context of all calls made during the upload session.
+	Context               context.Context
 	ContentLibraryManager *library.Manager
 	RestClient            *rest.Client
 	UploadSession         string
 	LibraryID             string
+	Checksum              string
+	// The TLS and proxy configuration used to fetch remote files.
+	HTTPConfig *ovfdeploy.HTTPConfig
 }
 
-func (uploadSession libraryUploadSession) cloneTemplate(moid string, name string, templateType string) (*string, error) {
-	ctx := context.TODO()
-	if templateType == "ovf" {
+// PublishSpec describes how an existing virtual machine is published into a
+// Content Library item.
+type PublishSpec struct {
+	// The managed object ID of the source virtual machine.
+	SourceMOID string
+	// Placement of the VM template. Only used for vm-template items.
+	DatastoreID     string
+	FolderID        string
+	HostSystemID    string
+	ResourcePoolID  string
+	StoragePolicyID string
+	// Flags controlling the OVF export. Only used for ovf items.
+	OvfFlags []string
+}
+
+func (uploadSession libraryUploadSession) cloneTemplate(publish *PublishSpec, name string, desc string, templateType string, itemID string) (*string, error) {
+	ctx := uploadSession.Context
+	switch templateType {
+	case library.ItemTypeOVF:
 		ovfItem := vcenter.OVF{
 			Spec: vcenter.CreateSpec{
-				Name: name,
+				Name:        name,
+				Description: desc,
+				Flags:       publish.OvfFlags,
 			},
 			Source: vcenter.ResourceID{
-				Value: moid,
+				Value: publish.SourceMOID,
 			},
 			Target: vcenter.LibraryTarget{
-				LibraryID: uploadSession.LibraryID,
+				LibraryID:     uploadSession.LibraryID,
+				LibraryItemID: itemID,
 			},
 		}
 		id, err := vcenter.NewManager(uploadSession.RestClient).CreateOVF(ctx, ovfItem)
@@ -318,8 +573,39 @@ func (uploadSession libraryUploadSession) cloneTemplate(moid string, name string
 			return nil, err
 		}
 		return &id, nil
+	case library.ItemTypeVMTX:
+		if itemID != "" {
+			return nil, fmt.Errorf("an existing vm-template item cannot be republished")
+		}
+		storage := &vcenter.DiskStorage{
+			Datastore: publish.DatastoreID,
+		}
+		if publish.StoragePolicyID != "" {
+			storage.StoragePolicy = &vcenter.StoragePolicy{
+				Policy: publish.StoragePolicyID,
+				Type:   "USE_SPECIFIED_POLICY",
+			}
+		}
+		template := vcenter.Template{
+			Name:          name,
+			Description:   desc,
+			Library:       uploadSession.LibraryID,
+			SourceVM:      publish.SourceMOID,
+			DiskStorage:   storage,
+			VMHomeStorage: storage,
+			Placement: &vcenter.Placement{
+				Folder:       publish.FolderID,
+				ResourcePool: publish.ResourcePoolID,
+				Host:         publish.HostSystemID,
+			},
+		}
+		id, err := vcenter.NewManager(uploadSession.RestClient).CreateTemplate(ctx, template)
+		if err != nil {
+			return nil, err
+		}
+		return &id, nil
 	}
-	return nil, fmt.Errorf("unsupported template type. Only ovf can be used when cloning from vCenter")
+	return nil, fmt.Errorf("unsupported template type %q. Only ovf and vm-template can be used when cloning from vCenter", templateType)
 }
 
 func (uploadSession libraryUploadSession) uploadString(data string, name string) error {
@@ -370,8 +656,8 @@ func (uploadSession libraryUploadSession) uploadOvaDisksFromLocal(ovaFilePath st
 }
 
 func (uploadSession libraryUploadSession) uploadOvaDisksFromURL(ovfFilePath string, diskName string, size int64) error {
-	client := &http.Client{}
-	req, err := http.NewRequest("GET", ovfFilePath, nil)
+	client := ovfdeploy.NewHTTPClient(uploadSession.HTTPConfig, false)
+	req, err := http.NewRequestWithContext(uploadSession.Context, "GET", ovfFilePath, nil)
 	if err != nil {
 		return fmt.Errorf("error creating request for %s: %w", ovfFilePath, err)
 	}
@@ -427,7 +713,7 @@ func readEnvelope(data string) (*ovf.Envelope, error) {
 }
 
 func (uploadSession libraryUploadSession) upload(name string, file *io.Reader, size int64) error {
-	ctx := context.TODO()
+	ctx := uploadSession.Context
 
 	info := library.UpdateFile{
 		Name:       name,
@@ -535,3 +821,335 @@ func MapNetworkDevices(d *schema.ResourceData) []vcenter.NetworkMapping {
 	}
 	return nm
 }
+
+// DownloadedFile describes a file written to local storage by
+// DownloadLibraryItem.
+type DownloadedFile struct {
+	Name              string
+	Path              string
+	Size              int64
+	ChecksumAlgorithm string
+	Checksum          string
+}
+
+// DownloadLibraryItem opens a download session against a Content Library item
+// and writes all of its files to a subdirectory of the supplied local
+// directory named after the item ID, so that files of different items with
+// the same name do not overwrite each other. Each file is verified against the
+// checksum reported by vCenter, when one is available. The download is aborted
+// if it does not complete within timeout. A timeout of 0 waits indefinitely.
+//
+// The files are downloaded to a staging directory and only moved to the item
+// directory once all of them are downloaded and verified, so that a failed
+// download does not leave some of the files of the item behind.
+func DownloadLibraryItem(c *rest.Client, item *library.Item, dir string, timeout time.Duration) ([]DownloadedFile, error) {
+	parent := filepath.Clean(dir)
+	dir = filepath.Join(parent, item.ID)
+	log.Printf("[DEBUG] contentlibrary.DownloadLibraryItem: Downloading content library item %s to %s.", item.ID, dir)
+	clm := library.NewManager(c)
+	ctx, cancel := provider.WithTimeout(timeout)
+	defer cancel()
+	if err := os.MkdirAll(parent, 0750); err != nil {
+		return nil, provider.Error(item.ID, "DownloadLibraryItem", err)
+	}
+	staging, err := os.MkdirTemp(parent, "."+item.ID+".*.tmp")
+	if err != nil {
+		return nil, provider.Error(item.ID, "DownloadLibraryItem", err)
+	}
+	defer func() {
+		_ = os.RemoveAll(staging)
+	}()
+	files, err := clm.ListLibraryItemFiles(ctx, item.ID)
+	if err != nil {
+		return nil, provider.Error(item.ID, "DownloadLibraryItem", err)
+	}
+	session, err := clm.CreateLibraryItemDownloadSession(ctx, library.Session{LibraryItemID: item.ID})
+	if err != nil {
+		return nil, provider.Error(item.ID, "DownloadLibraryItem", err)
+	}
+	defer func() {
+		_ = clm.DeleteLibraryItemDownloadSession(context.Background(), session)
+	}()
+
+	var downloaded []DownloadedFile
+	for _, file := range files {
+		info, err := prepareDownloadFile(ctx, clm, session, file.Name)
+		if err != nil {
+			return nil, provider.Error(item.ID, "DownloadLibraryItem", err)
+		}
+		checksum := file.Checksum
+		if checksum == nil {
+			checksum = info.Checksum
+		}
+		df, err := downloadFile(ctx, c, info, filepath.Join(staging, filepath.Base(file.Name)), checksum)
+		if err != nil {
+			return nil, provider.Error(item.ID, "DownloadLibraryItem", err)
+		}
+		downloaded = append(downloaded, *df)
+		if err := clm.KeepAliveLibraryItemDownloadSession(ctx, session); err != nil {
+			return nil, provider.Error(item.ID, "DownloadLibraryItem", err)
+		}
+	}
+	if err := moveDownloadedFiles(downloaded, dir); err != nil {
+		return nil, provider.Error(item.ID, "DownloadLibraryItem", err)
+	}
+	log.Printf("[DEBUG] contentlibrary.DownloadLibraryItem: Successfully downloaded %d file(s) from content library item %s.", len(downloaded), item.ID)
+	return downloaded, nil
+}
+
+// moveDownloadedFiles moves downloaded files from the staging directory to dir
+// and updates their paths. If a file cannot be moved, the files already moved
+// are removed again.
+func moveDownloadedFiles(files []DownloadedFile, dir string) error {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return err
+	}
+	for i := range files {
+		path := filepath.Join(dir, filepath.Base(files[i].Path))
+		if err := os.Rename(files[i].Path, path); err != nil {
+			for _, moved := range files[:i] {
+				_ = os.Remove(moved.Path)
+			}
+			return err
+		}
+		files[i].Path = path
+	}
+	return nil
+}
+
+// prepareDownloadFile requests that a file in a download session is prepared
+// and waits for it to become available for download.
+func prepareDownloadFile(ctx context.Context, clm *library.Manager, session string, name string) (*library.DownloadFile, error) {
+	if _, err := clm.PrepareLibraryItemDownloadSessionFile(ctx, session, name); err != nil {
+		return nil, err
+	}
+	for {
+		info, err := clm.GetLibraryItemDownloadSessionFile(ctx, session, name)
+		if err != nil {
+			return nil, err
+		}
+		switch info.Status {
+		case "PREPARED":
+			return info, nil
+		case "ERROR":
+			return nil, fmt.Errorf("file %s could not be prepared for download", name)
+		}
+		log.Printf("[DEBUG] contentlibrary.prepareDownloadFile: Waiting for file %s to be prepared (status: %s)", name, info.Status)
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timeout waiting for file %s to be prepared for download", name)
+		case <-time.After(time.Second * 5):
+		}
+	}
+}
+
+// downloadFile writes a prepared download session file to the supplied path
+// and verifies its checksum. The file is written to a temporary file in the
+// same directory first, and only renamed to path once it is complete and
+// verified, so that a failed download does not leave a partial file behind.
+func downloadFile(ctx context.Context, c *rest.Client, info *library.DownloadFile, path string, checksum *library.Checksum) (*DownloadedFile, error) {
+	if info.DownloadEndpoint == nil {
+		return nil, fmt.Errorf("no download endpoint returned for file %s", info.Name)
+	}
+	u, err := url.Parse(info.DownloadEndpoint.URI)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[DEBUG] contentlibrary.downloadFile: Downloading %s to %s", info.Name, path)
+	body, _, err := c.Download(ctx, u, &soap.DefaultDownload)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = body.Close()
+	}()
+
+	out, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return nil, err
+	}
+	tmp := out.Name()
+	defer func() {
+		_ = out.Close()
+		_ = os.Remove(tmp)
+	}()
+
+	df := &DownloadedFile{
+		Name: info.Name,
+		Path: path,
+	}
+	var h hash.Hash
+	if checksum != nil {
+		if h, err = checksumHash(checksum.Algorithm); err != nil {
+			return nil, err
+		}
+	}
+	w := io.Writer(out)
+	if h != nil {
+		w = io.MultiWriter(out, h)
+	}
+	if df.Size, err = io.Copy(w, body); err != nil {
+		return nil, err
+	}
+	if h != nil {
+		df.ChecksumAlgorithm = checksum.Algorithm
+		df.Checksum = hex.EncodeToString(h.Sum(nil))
+		if !strings.EqualFold(df.Checksum, checksum.Checksum) {
+			return nil, fmt.Errorf("checksum mismatch for file %s: expected %s, got %s", info.Name, checksum.Checksum, df.Checksum)
+		}
+	}
+	if err := out.Close(); err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(tmp, 0640); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmp, filepath.Clean(path)); err != nil {
+		return nil, err
+	}
+	return df, nil
+}
+
+// checksumHash returns a hash for a Content Library checksum algorithm.
+func checksumHash(algorithm string) (hash.Hash, error) {
+	switch strings.ToUpper(algorithm) {
+	case "SHA1", "":
+		return sha1.New(), nil //nolint
+	case "MD5":
+		return md5.New(), nil //nolint
+	case "SHA256":
+		return sha256.New(), nil
+	case "SHA512":
+		return sha512.New(), nil
+	}
+	return nil, fmt.Errorf("unsupported checksum algorithm %q", algorithm)
+}
+
+// SyncLibrary synchronizes a subscribed Content Library with its publisher
+// and waits until the library reports a new last sync time.
+func SyncLibrary(c *rest.Client, lib *library.Library, timeout time.Duration) error {
+	log.Printf("[DEBUG] contentlibrary.SyncLibrary: Synchronizing subscribed library %s.", lib.ID)
+	clm := library.NewManager(c)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	previous := lib.LastSyncTime
+	if err := clm.SyncLibrary(ctx, lib); err != nil {
+		return provider.Error(lib.ID, "SyncLibrary", err)
+	}
+	for {
+		current, err := clm.GetLibraryByID(ctx, lib.ID)
+		if err != nil {
+			return provider.Error(lib.ID, "SyncLibrary", err)
+		}
+		if syncTimeAdvanced(previous, current.LastSyncTime) {
+			break
+		}
+		log.Printf("[DEBUG] contentlibrary.SyncLibrary: Waiting for subscribed library %s to synchronize", lib.ID)
+		select {
+		case <-ctx.Done():
+			return provider.Error(lib.ID, "SyncLibrary", fmt.Errorf("timeout waiting for library synchronization"))
+		case <-time.After(time.Second * 10):
+		}
+	}
+	log.Printf("[DEBUG] contentlibrary.SyncLibrary: Successfully synchronized subscribed library %s.", lib.ID)
+	return nil
+}
+
+// SyncLibraryItem synchronizes an item in a subscribed Content Library and
+// waits until the item reports a new last sync time. When force is set, the
+// item content is downloaded and the wait also covers the item becoming
+// cached.
+func SyncLibraryItem(c *rest.Client, item *library.Item, force bool, timeout time.Duration) error {
+	log.Printf("[DEBUG] contentlibrary.SyncLibraryItem: Synchronizing library item %s.", item.ID)
+	clm := library.NewManager(c)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	previous := item.LastSyncTime
+	if err := clm.SyncLibraryItem(ctx, item, force); err != nil {
+		return provider.Error(item.ID, "SyncLibraryItem", err)
+	}
+	for {
+		current, err := clm.GetLibraryItem(ctx, item.ID)
+		if err != nil {
+			return provider.Error(item.ID, "SyncLibraryItem", err)
+		}
+		if syncTimeAdvanced(previous, current.LastSyncTime) && (!force || current.Cached) {
+			break
+		}
+		log.Printf("[DEBUG] contentlibrary.SyncLibraryItem: Waiting for library item %s to synchronize", item.ID)
+		select {
+		case <-ctx.Done():
+			return provider.Error(item.ID, "SyncLibraryItem", fmt.Errorf("timeout waiting for library item synchronization"))
+		case <-time.After(time.Second * 10):
+		}
+	}
+	log.Printf("[DEBUG] contentlibrary.SyncLibraryItem: Successfully synchronized library item %s.", item.ID)
+	return nil
+}
+
+func syncTimeAdvanced(previous, current *time.Time) bool {
+	if current == nil {
+		return false
+	}
+	return previous == nil || current.After(*previous)
+}
+
+// ItemChange describes an entry in the version history of a Content Library
+// item.
+type ItemChange struct {
+	Time         *time.Time `json:"time,omitempty"`
+	Version      string     `json:"version,omitempty"`
+	User         string     `json:"user,omitempty"`
+	ShortMessage string     `json:"short_message,omitempty"`
+}
+
+// CheckOutTemplate checks out a VM template contained in a Content Library
+// item into a virtual machine, returning the managed object ID of the virtual
+// machine.
+func CheckOutTemplate(c *rest.Client, itemID string, name string, folderID string, resourcePoolID string, hostID string, poweredOn bool) (string, error) {
+	log.Printf("[DEBUG] contentlibrary.CheckOutTemplate: Checking out library item %s to virtual machine %s.", itemID, name)
+	ctx := context.TODO()
+	spec := vcenter.CheckOut{
+		Name: name,
+		Placement: &vcenter.Placement{
+			Folder:       folderID,
+			ResourcePool: resourcePoolID,
+			Host:         hostID,
+		},
+		PoweredOn: poweredOn,
+	}
+	ref, err := vcenter.NewManager(c).CheckOut(ctx, itemID, &spec)
+	if err != nil {
+		return "", provider.Error(itemID, "CheckOutTemplate", err)
+	}
+	log.Printf("[DEBUG] contentlibrary.CheckOutTemplate: Successfully checked out library item %s to virtual machine %s.", itemID, ref.Value)
+	return ref.Value, nil
+}
+
+// CheckInTemplate checks a virtual machine previously checked out from a
+// Content Library item back in as a new version of the VM template, returning
+// the new version.
+func CheckInTemplate(c *rest.Client, itemID string, vmID string, message string) (string, error) {
+	log.Printf("[DEBUG] contentlibrary.CheckInTemplate: Checking in virtual machine %s to library item %s.", vmID, itemID)
+	ctx := context.TODO()
+	ref := types.ManagedObjectReference{Type: "VirtualMachine", Value: vmID}
+	version, err := vcenter.NewManager(c).CheckIn(ctx, itemID, ref, &vcenter.CheckIn{Message: message})
+	if err != nil {
+		return "", provider.Error(itemID, "CheckInTemplate", err)
+	}
+	log.Printf("[DEBUG] contentlibrary.CheckInTemplate: Successfully checked in virtual machine %s to library item %s as version %s.", vmID, itemID, version)
+	return version, nil
+}
+
+// ItemChanges returns the version history of a Content Library item, most
+// recent first.
+func ItemChanges(c *rest.Client, itemID string) ([]ItemChange, error) {
+	log.Printf("[DEBUG] contentlibrary.ItemChanges: Retrieving version history of library item %s.", itemID)
+	ctx := context.TODO()
+	var changes []ItemChange
+	r := c.Resource(fmt.Sprintf("/api/content/library/item/%s/changes", itemID))
+	if err := c.Do(ctx, r.Request(http.MethodGet), &changes); err != nil {
+		return nil, provider.Error(itemID, "ItemChanges", err)
+	}
+	return changes, nil
+}
@@ -10,11 +10,13 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/govmomi"
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/vim25/mo"
 	"github.com/vmware/govmomi/vim25/types"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/structure"
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/viapi"
 )
 
@@ -37,15 +39,82 @@ const ConfigKey = "custom_attributes"
 //
 // The key should be set to the ConfigKey constant and should be a
 // map of custom attribute ids to values.
+//
+// The attribute is computed, as the planned value is the configuration merged
+// with the provider defaults. Resources that use it must call DiffWithDefaults
+// during diff customization.
 func ConfigSchema() *schema.Schema {
 	return &schema.Schema{
 		Type:        schema.TypeMap,
 		Description: "A list of custom attributes to set on this resource.",
 		Optional:    true,
+		Computed:    true,
 		Elem:        &schema.Schema{Type: schema.TypeString},
 	}
 }
 
+// ConfigSchemaWithoutDefaults returns the schema for custom attribute
+// configuration of resources that the provider defaults do not apply to.
+// Unlike ConfigSchema, the attribute is not computed, so the planned value is
+// the configuration.
+func ConfigSchemaWithoutDefaults() *schema.Schema {
+	s := ConfigSchema()
+	s.Computed = false
+	return s
+}
+
+// MergeDefaults returns the custom attributes of a resource merged with the
+// provider defaults. A value in attrs replaces the default value of the same
+// custom attribute, and an empty value removes the custom attribute.
+func MergeDefaults(attrs map[string]interface{}, defaults map[string]string) map[string]interface{} {
+	merged := make(map[string]interface{})
+	for k, v := range defaults {
+		merged[k] = v
+	}
+	for k, v := range attrs {
+		merged[k] = v
+	}
+	for k, v := range merged {
+		if v.(string) == "" {
+			delete(merged, k)
+		}
+	}
+	return merged
+}
+
+// DiffWithDefaults plans the custom attributes of a resource as the custom
+// attributes in its configuration merged with the provider defaults. The
+// custom attributes are marked as computed if the configuration is not known
+// yet.
+func DiffWithDefaults(d *schema.ResourceDiff, defaults map[string]string) error {
+	attrs, ok := configuredAttributes(d)
+	if !ok {
+		return d.SetNewComputed(ConfigKey)
+	}
+	return d.SetNew(ConfigKey, MergeDefaults(attrs, defaults))
+}
+
+// configuredAttributes returns the custom attributes in the configuration of
+// a resource, and false if they are not known yet.
+func configuredAttributes(d structure.RawConfigReader) (map[string]interface{}, bool) {
+	attrs := make(map[string]interface{})
+	v, diags := d.GetRawConfigAt(cty.GetAttrPath(ConfigKey))
+	if diags.HasError() || v.IsNull() {
+		return attrs, true
+	}
+	if !v.IsWhollyKnown() {
+		return nil, false
+	}
+	for it := v.ElementIterator(); it.Next(); {
+		k, e := it.Element()
+		if e.IsNull() {
+			continue
+		}
+		attrs[k.AsString()] = e.AsString()
+	}
+	return attrs, true
+}
+
 func VerifySupport(client *govmomi.Client) error {
 	if err := viapi.ValidateVirtualCenter(client); err != nil {
 		return errors.New("custom attributes are only supported on vCenter")
@@ -126,11 +195,19 @@ func (p *DiffProcessor) ProcessDiff(subject object.Reference) error {
 	return nil
 }
 
-func GetDiffProcessorIfAttributesDefined(client *govmomi.Client, d *schema.ResourceData) (*DiffProcessor, error) {
+// GetDiffProcessorIfAttributesDefined returns a DiffProcessor for the changes
+// to the custom attributes of a resource, with the provider defaults merged
+// into the new custom attributes. nil is returned if there are no changes.
+func GetDiffProcessorIfAttributesDefined(client *govmomi.Client, d *schema.ResourceData, defaults map[string]string) (*DiffProcessor, error) {
 	if !d.HasChange(ConfigKey) {
 		return nil, nil
 	}
 	old, newValue := d.GetChange(ConfigKey)
+	// The planned value is not known if the configuration was not known at
+	// plan time, in which case the defaults are merged here.
+	if attrs, ok := configuredAttributes(d); ok {
+		newValue = MergeDefaults(attrs, defaults)
+	}
 	if len(old.(map[string]interface{})) > 0 || len(newValue.(map[string]interface{})) > 0 {
 		if err := VerifySupport(client); err != nil {
 			return nil, err
@@ -0,0 +1,57 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package customattribute
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeDefaults(t *testing.T) {
+	cases := []struct {
+		name     string
+		attrs    map[string]interface{}
+		defaults map[string]string
+		expected map[string]interface{}
+	}{
+		{
+			name:     "no defaults",
+			attrs:    map[string]interface{}{"101": "foo"},
+			expected: map[string]interface{}{"101": "foo"},
+		},
+		{
+			name:     "defaults only",
+			defaults: map[string]string{"102": "cc-100"},
+			expected: map[string]interface{}{"102": "cc-100"},
+		},
+		{
+			name:     "merged",
+			attrs:    map[string]interface{}{"101": "foo"},
+			defaults: map[string]string{"102": "cc-100"},
+			expected: map[string]interface{}{"101": "foo", "102": "cc-100"},
+		},
+		{
+			name:     "overridden",
+			attrs:    map[string]interface{}{"102": "cc-200"},
+			defaults: map[string]string{"102": "cc-100"},
+			expected: map[string]interface{}{"102": "cc-200"},
+		},
+		{
+			name:     "removed",
+			attrs:    map[string]interface{}{"101": "foo", "102": ""},
+			defaults: map[string]string{"102": "cc-100"},
+			expected: map[string]interface{}{"101": "foo"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := MergeDefaults(tc.attrs, tc.defaults)
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("expected %#v, got %#v", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -160,10 +160,26 @@ func SpecSchema(isVM bool) map[string]*schema.Schema {
 					Description: "Specifies how many times the VM should auto-logon the Administrator account when auto_logon is true.",
 				},
 				"admin_password": {
-					Type:        schema.TypeString,
-					Optional:    true,
-					Sensitive:   true,
-					Description: "The new administrator password for this virtual machine.",
+					Type:          schema.TypeString,
+					Optional:      true,
+					Sensitive:     true,
+					Description:   "The new administrator password for this virtual machine.",
+					ConflictsWith: []string{prefix + "windows_options.0.admin_password_wo"},
+				},
+				"admin_password_wo": {
+					Type:          schema.TypeString,
+					Optional:      true,
+					Sensitive:     true,
+					WriteOnly:     true,
+					Description:   "The new administrator password for this virtual machine, as a write-only value that is not stored in state.",
+					ConflictsWith: []string{prefix + "windows_options.0.admin_password"},
+					RequiredWith:  []string{prefix + "windows_options.0.admin_password_wo_version"},
+				},
+				"admin_password_wo_version": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Description:  "The version of admin_password_wo. Changing the version applies the current value of admin_password_wo.",
+					RequiredWith: []string{prefix + "windows_options.0.admin_password_wo"},
 				},
 				"time_zone": {
 					Type:        schema.TypeInt,
@@ -178,7 +194,7 @@ func SpecSchema(isVM bool) map[string]*schema.Schema {
 					Optional:      true,
 					ConflictsWith: []string{prefix + "windows_options.0.workgroup"},
 					Description:   "The domain that the virtual machine should join.",
-					RequiredWith:  []string{prefix + "windows_options.0.domain_admin_user", prefix + "windows_options.0.domain_admin_password"},
+					RequiredWith:  []string{prefix + "windows_options.0.domain_admin_user"},
 				},
 				"domain_ou": {
 					Type:          schema.TypeString,
@@ -198,10 +214,25 @@ func SpecSchema(isVM bool) map[string]*schema.Schema {
 					Type:          schema.TypeString,
 					Optional:      true,
 					Sensitive:     true,
-					ConflictsWith: []string{prefix + "windows_options.0.workgroup"},
+					ConflictsWith: []string{prefix + "windows_options.0.workgroup", prefix + "windows_options.0.domain_admin_password_wo"},
 					Description:   "The password of the domain administrator used to join this virtual machine to the domain.",
 					RequiredWith:  []string{prefix + "windows_options.0.join_domain"},
 				},
+				"domain_admin_password_wo": {
+					Type:          schema.TypeString,
+					Optional:      true,
+					Sensitive:     true,
+					WriteOnly:     true,
+					ConflictsWith: []string{prefix + "windows_options.0.workgroup", prefix + "windows_options.0.domain_admin_password"},
+					Description:   "The password of the domain administrator used to join this virtual machine to the domain, as a write-only value that is not stored in state.",
+					RequiredWith:  []string{prefix + "windows_options.0.join_domain", prefix + "windows_options.0.domain_admin_password_wo_version"},
+				},
+				"domain_admin_password_wo_version": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Description:  "The version of domain_admin_password_wo. Changing the version applies the current value of domain_admin_password_wo.",
+					RequiredWith: []string{prefix + "windows_options.0.domain_admin_password_wo"},
+				},
 				"workgroup": {
 					Type:          schema.TypeString,
 					Optional:      true,
@@ -335,7 +366,7 @@ func FlattenGuestOsCustomizationSpec(d *schema.ResourceData, specItem *types.Cus
 		} else {
 			specItemWinOptions := specItem.Spec.Identity.(*types.CustomizationSysprep)
 			version := viapi.ParseVersionFromClient(client)
-			windowsOptions, err := flattenWindowsOptions(specItemWinOptions, version)
+			windowsOptions, err := flattenWindowsOptions(d, specItemWinOptions, version)
 			if err != nil {
 				return err
 			}
@@ -425,22 +456,56 @@ func ValidateCustomizationSpec(d *schema.ResourceDiff, family string, isVM bool)
 	case family == string(types.VirtualMachineGuestOsFamilyWindowsGuest) && !windowsExists && !sysprepExists:
 		return errors.New("one of windows_options or windows_sysprep_text must exist in VM customization options for Windows operating systems")
 	}
+	return ValidateWindowsOptions(d, isVM)
+}
+
+// windowsOptionsReader is the subset of ResourceDiff used by
+// ValidateWindowsOptions.
+type windowsOptionsReader interface {
+	structure.NewValueKnownReader
+	structure.RawConfigReader
+	Get(string) interface{}
+}
+
+// ValidateWindowsOptions checks that a domain administrator password is
+// supplied, through either domain_admin_password or domain_admin_password_wo,
+// when join_domain is set. It should be called during diff customization to
+// veto invalid configs.
+func ValidateWindowsOptions(d windowsOptionsReader, isVM bool) error {
+	prefix := getSchemaPrefix(isVM) + "windows_options.0."
+	if !structure.ValuesAvailable(prefix, []string{"join_domain", "domain_admin_password"}, d) {
+		return nil
+	}
+	if d.Get(prefix+"join_domain").(string) == "" || d.Get(prefix+"domain_admin_password").(string) != "" {
+		return nil
+	}
+	if !structure.WriteOnlyValueSet(d, prefix+"domain_admin_password_wo") {
+		return errors.New("one of domain_admin_password or domain_admin_password_wo must be set when join_domain is set")
+	}
 	return nil
 }
 
-func flattenWindowsOptions(customizationPrep *types.CustomizationSysprep, version viapi.VSphereVersion) ([]map[string]interface{}, error) {
+func flattenWindowsOptions(d *schema.ResourceData, customizationPrep *types.CustomizationSysprep, version viapi.VSphereVersion) ([]map[string]interface{}, error) {
+	prefix := schemaPrefixGOSC + "windows_options.0."
 	winOptionsData := make(map[string]interface{})
 	if customizationPrep.GuiRunOnce != nil {
 		winOptionsData["run_once_command_list"] = customizationPrep.GuiRunOnce.CommandList
 	}
 	winOptionsData["auto_logon"] = customizationPrep.GuiUnattended.AutoLogon
 	winOptionsData["auto_logon_count"] = customizationPrep.GuiUnattended.AutoLogonCount
-	if customizationPrep.GuiUnattended.Password != nil {
+	// Passwords supplied through the write-only attributes are not saved to
+	// state. Only their versions are carried over, as vCenter does not track
+	// them.
+	if v, ok := d.GetOk(prefix + "admin_password_wo_version"); ok {
+		winOptionsData["admin_password_wo_version"] = v
+	} else if customizationPrep.GuiUnattended.Password != nil {
 		winOptionsData["admin_password"] = customizationPrep.GuiUnattended.Password.Value
 	}
 	winOptionsData["time_zone"] = customizationPrep.GuiUnattended.TimeZone
 	winOptionsData["domain_admin_user"] = customizationPrep.Identification.DomainAdmin
-	if customizationPrep.Identification.DomainAdminPassword != nil {
+	if v, ok := d.GetOk(prefix + "domain_admin_password_wo_version"); ok {
+		winOptionsData["domain_admin_password_wo_version"] = v
+	} else if customizationPrep.Identification.DomainAdminPassword != nil {
 		winOptionsData["domain_admin_password"] = customizationPrep.Identification.DomainAdminPassword.Value
 	}
 	winOptionsData["join_domain"] = customizationPrep.Identification.JoinDomain
@@ -610,6 +675,11 @@ func expandCustomizationGuiUnattended(d *schema.ResourceData, prefix string) typ
 			Value:     v.(string),
 			PlainText: true,
 		}
+	} else if v := structure.GetWriteOnlyString(d, prefix+"admin_password_wo"); v != "" {
+		obj.Password = &types.CustomizationPassword{
+			Value:     v,
+			PlainText: true,
+		}
 	}
 
 	return obj
@@ -634,6 +704,11 @@ func expandCustomizationIdentification(d *schema.ResourceData, prefix string, ve
 			Value:     v.(string),
 			PlainText: true,
 		}
+	} else if v := structure.GetWriteOnlyString(d, prefix+"domain_admin_password_wo"); v != "" {
+		obj.DomainAdminPassword = &types.CustomizationPassword{
+			Value:     v,
+			PlainText: true,
+		}
 	}
 	return obj
 }
@@ -0,0 +1,156 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package guestoscustomizations
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// testWindowsOptionsDiff is a windowsOptionsReader over fixed planned values
+// and a fixed raw configuration.
+type testWindowsOptionsDiff struct {
+	values  map[string]interface{}
+	unknown map[string]bool
+	config  cty.Value
+}
+
+func (d testWindowsOptionsDiff) Get(key string) interface{} {
+	if v, ok := d.values[key]; ok {
+		return v
+	}
+	return ""
+}
+
+func (d testWindowsOptionsDiff) NewValueKnown(key string) bool {
+	return !d.unknown[key]
+}
+
+func (d testWindowsOptionsDiff) GetRawConfigAt(p cty.Path) (cty.Value, diag.Diagnostics) {
+	v, err := p.Apply(d.config)
+	if err != nil {
+		return cty.DynamicVal, diag.FromErr(err)
+	}
+	return v, nil
+}
+
+// testWindowsOptionsConfig returns a raw configuration with
+// domain_admin_password_wo set to password, under the spec or clone block.
+func testWindowsOptionsConfig(isVM bool, password cty.Value) cty.Value {
+	options := cty.ListVal([]cty.Value{
+		cty.ObjectVal(map[string]cty.Value{
+			"domain_admin_password_wo": password,
+		}),
+	})
+	if isVM {
+		return cty.ObjectVal(map[string]cty.Value{
+			"clone": cty.ListVal([]cty.Value{
+				cty.ObjectVal(map[string]cty.Value{
+					"customize": cty.ListVal([]cty.Value{
+						cty.ObjectVal(map[string]cty.Value{
+							"windows_options": options,
+						}),
+					}),
+				}),
+			}),
+		})
+	}
+	return cty.ObjectVal(map[string]cty.Value{
+		"spec": cty.ListVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{
+				"windows_options": options,
+			}),
+		}),
+	})
+}
+
+func TestValidateWindowsOptions(t *testing.T) {
+	cases := []struct {
+		name        string
+		isVM        bool
+		joinDomain  string
+		password    string
+		passwordWO  cty.Value
+		unknown     []string
+		expectError bool
+	}{
+		{
+			name:       "no domain",
+			passwordWO: cty.NullVal(cty.String),
+		},
+		{
+			name:       "domain with password",
+			joinDomain: "example.com",
+			password:   "secret",
+			passwordWO: cty.NullVal(cty.String),
+		},
+		{
+			name:       "domain with write-only password",
+			joinDomain: "example.com",
+			passwordWO: cty.StringVal("secret"),
+		},
+		{
+			name:       "domain with unknown write-only password",
+			joinDomain: "example.com",
+			passwordWO: cty.UnknownVal(cty.String),
+		},
+		{
+			name:        "domain without password",
+			joinDomain:  "example.com",
+			passwordWO:  cty.NullVal(cty.String),
+			expectError: true,
+		},
+		{
+			name:       "unknown domain",
+			passwordWO: cty.NullVal(cty.String),
+			unknown:    []string{"join_domain"},
+		},
+		{
+			name:       "unknown password",
+			joinDomain: "example.com",
+			passwordWO: cty.NullVal(cty.String),
+			unknown:    []string{"domain_admin_password"},
+		},
+		{
+			name:        "virtual machine domain without password",
+			isVM:        true,
+			joinDomain:  "example.com",
+			passwordWO:  cty.NullVal(cty.String),
+			expectError: true,
+		},
+		{
+			name:       "virtual machine domain with write-only password",
+			isVM:       true,
+			joinDomain: "example.com",
+			passwordWO: cty.StringVal("secret"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			prefix := getSchemaPrefix(tc.isVM) + "windows_options.0."
+			d := testWindowsOptionsDiff{
+				values: map[string]interface{}{
+					prefix + "join_domain":           tc.joinDomain,
+					prefix + "domain_admin_password": tc.password,
+				},
+				unknown: make(map[string]bool),
+				config:  testWindowsOptionsConfig(tc.isVM, tc.passwordWO),
+			}
+			for _, k := range tc.unknown {
+				d.unknown[prefix+k] = true
+			}
+			err := ValidateWindowsOptions(d, tc.isVM)
+			if tc.expectError && err == nil {
+				t.Fatal("expected an error, got none")
+			}
+			if !tc.expectError && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
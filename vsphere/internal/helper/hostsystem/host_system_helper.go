@@ -13,6 +13,7 @@ import (
 	"github.com/vmware/govmomi"
 	"github.com/vmware/govmomi/find"
 	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/methods"
 	"github.com/vmware/govmomi/vim25/mo"
 	"github.com/vmware/govmomi/vim25/types"
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/provider"
@@ -135,12 +136,15 @@ func EnterMaintenanceMode(host *object.HostSystem, timeout time.Duration, evacua
 
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
-	task, err := host.EnterMaintenanceMode(ctx, int32(timeout.Seconds()), evacuate, nil)
-	if err != nil {
-		return err
-	}
-
-	err = task.WaitEx(ctx)
+	var task *object.Task
+	err = viapi.RetryWithPolicy(ctx, viapi.RetryPolicyFor(host.Client()), "hostsystem.EnterMaintenanceMode", func() error {
+		var err error
+		task, err = host.EnterMaintenanceMode(ctx, int32(timeout.Seconds()), evacuate, nil)
+		if err != nil {
+			return err
+		}
+		return task.WaitEx(ctx)
+	})
 	if err != nil {
 		return err
 	}
@@ -172,12 +176,15 @@ func ExitMaintenanceMode(host *object.HostSystem, timeout time.Duration) error {
 
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
-	task, err := host.ExitMaintenanceMode(ctx, int32(timeout.Seconds()))
-	if err != nil {
-		return err
-	}
-
-	err = task.WaitEx(ctx)
+	var task *object.Task
+	err = viapi.RetryWithPolicy(ctx, viapi.RetryPolicyFor(host.Client()), "hostsystem.ExitMaintenanceMode", func() error {
+		var err error
+		task, err = host.ExitMaintenanceMode(ctx, int32(timeout.Seconds()))
+		if err != nil {
+			return err
+		}
+		return task.WaitEx(ctx)
+	})
 	if err != nil {
 		return err
 	}
@@ -194,6 +201,63 @@ func ExitMaintenanceMode(host *object.HostSystem, timeout time.Duration) error {
 	return nil
 }
 
+// rebootPollInterval is the interval at which the state of a host is checked
+// while waiting for it to come back from a reboot.
+var rebootPollInterval = 10 * time.Second
+
+// Reboot reboots a host and waits up to timeout for it to reconnect. The host
+// must be in maintenance mode.
+//
+// Only vCenter connections are supported, as a direct connection to the host
+// is lost while it reboots.
+func Reboot(host *object.HostSystem, timeout time.Duration) error {
+	if err := viapi.VimValidateVirtualCenter(host.Client()); err != nil {
+		return fmt.Errorf("cannot reboot host(%s): %s", host.Reference(), err)
+	}
+	props, err := Properties(host)
+	if err != nil {
+		return err
+	}
+	bootTime := props.Runtime.BootTime
+
+	log.Printf("[DEBUG] Host %q is rebooting", host.Name())
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	req := types.RebootHost_Task{
+		This:  host.Reference(),
+		Force: false,
+	}
+	res, err := methods.RebootHost_Task(ctx, host.Client(), &req)
+	if err != nil {
+		return fmt.Errorf("error while rebooting host(%s): %s", host.Reference(), err)
+	}
+	if err := object.NewTask(host.Client(), res.Returnval).WaitEx(ctx); err != nil {
+		return fmt.Errorf("error while rebooting host(%s): %s", host.Reference(), err)
+	}
+
+	// The host is only back once it is connected again with a boot time later
+	// than the one before the reboot.
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timeout waiting for host(%s) to come back from reboot", host.Reference())
+		case <-time.After(rebootPollInterval):
+		}
+		props, err := Properties(host)
+		if err != nil {
+			log.Printf("[DEBUG] Waiting for host %q to come back from reboot: %s", host.Name(), err)
+			continue
+		}
+		if props.Runtime.ConnectionState != types.HostSystemConnectionStateConnected || props.Runtime.BootTime == nil {
+			continue
+		}
+		if bootTime == nil || props.Runtime.BootTime.After(*bootTime) {
+			return nil
+		}
+	}
+}
+
 // GetConnectionState returns the host's connection state (see vim.HostSystem.ConnectionState)
 func GetConnectionState(host *object.HostSystem) (types.HostSystemConnectionState, error) {
 	hostProps, err := Properties(host)
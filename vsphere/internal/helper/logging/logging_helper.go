@@ -0,0 +1,95 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package logging provides structured logging for vSphere API traffic and
+// long-running tasks, through terraform-plugin-log subsystems.
+package logging
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// The subsystems of the provider logger. The level of each subsystem can be
+// set with the TF_LOG_PROVIDER_VSPHERE_<SUBSYSTEM> environment variable, for
+// example TF_LOG_PROVIDER_VSPHERE_SOAP=TRACE.
+const (
+	SubsystemSOAP = "soap"
+	SubsystemREST = "rest"
+	SubsystemVSAN = "vsan"
+	SubsystemPBM  = "pbm"
+	SubsystemOVF  = "ovf"
+)
+
+var subsystems = []string{
+	SubsystemSOAP,
+	SubsystemREST,
+	SubsystemVSAN,
+	SubsystemPBM,
+	SubsystemOVF,
+}
+
+// sensitiveFieldKeys are the keys of structured log fields whose values are
+// always masked.
+var sensitiveFieldKeys = []string{
+	"password",
+	"admin_password",
+	"domain_admin_password",
+	"token",
+	"saml_token",
+	"oidc_token",
+	"session_id",
+	"private_key",
+}
+
+var (
+	loggingCtxMu sync.RWMutex
+	loggingCtx   = context.Background()
+)
+
+// Configure sets up the subsystem loggers from the logger carried by ctx,
+// which is the context the provider is configured with.
+//
+// Most vSphere API calls are not made with a context that is passed down
+// from Terraform, so the loggers are kept for use with Context.
+func Configure(ctx context.Context) {
+	for _, s := range subsystems {
+		ctx = tflog.NewSubsystem(ctx, s, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_VSPHERE", s))
+		ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, s, sensitiveFieldKeys...)
+	}
+	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, sensitiveFieldKeys...)
+
+	loggingCtxMu.Lock()
+	defer loggingCtxMu.Unlock()
+	loggingCtx = ctx
+}
+
+// Context returns a context that logs to the loggers set up by Configure,
+// unless ctx carries loggers of its own. The deadline and cancellation of ctx
+// are preserved.
+func Context(ctx context.Context) context.Context {
+	loggingCtxMu.RLock()
+	defer loggingCtxMu.RUnlock()
+	return &loggerContext{
+		Context: ctx,
+		loggers: loggingCtx,
+	}
+}
+
+// loggerContext is a context that falls back to the values of another context
+// for the values, such as loggers, that it does not carry.
+type loggerContext struct {
+	context.Context
+	loggers context.Context
+}
+
+// Value implements context.Context for loggerContext.
+func (c *loggerContext) Value(key interface{}) interface{} {
+	if v := c.Context.Value(key); v != nil {
+		return v
+	}
+	return c.loggers.Value(key)
+}
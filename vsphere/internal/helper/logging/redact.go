@@ -0,0 +1,147 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package logging
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+
+	"github.com/vmware/govmomi/vim25/debug"
+)
+
+const redacted = "***"
+
+// sensitiveElements are the SOAP elements whose content is redacted. This
+// covers login credentials, host connection passwords, guest customization
+// secrets, SAML tokens and session cookies.
+var sensitiveElements = []string{
+	"password",
+	"newPassword",
+	"oldPassword",
+	"adminPassword",
+	"domainAdminPassword",
+	"privateKey",
+	"token",
+	"Assertion",
+	"BinarySecurityToken",
+	"vcSessionCookie",
+}
+
+// sensitiveHeaders are the HTTP headers whose values are redacted.
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"vmware-api-session-id",
+}
+
+// sensitiveJSONKeys are the keys of JSON objects whose string values are
+// redacted, matched case-insensitively.
+var sensitiveJSONKeys = []string{
+	"password",
+	"token",
+	"secret",
+	"session_id",
+	"sessionId",
+}
+
+type redaction struct {
+	re   *regexp.Regexp
+	repl string
+}
+
+var redactions = buildRedactions()
+
+func buildRedactions() []redaction {
+	var r []redaction
+	for _, e := range sensitiveElements {
+		r = append(r, redaction{
+			re:   regexp.MustCompile(fmt.Sprintf(`(?s)(<(?:\w+:)?%[1]s(?:\s[^>]*)?>).*?(</(?:\w+:)?%[1]s>)`, e)),
+			repl: "${1}" + redacted + "${2}",
+		})
+	}
+	for _, h := range sensitiveHeaders {
+		r = append(r, redaction{
+			re:   regexp.MustCompile(fmt.Sprintf(`(?im)^(%s:)[ \t]*[^\r\n]*`, regexp.QuoteMeta(h))),
+			repl: "${1} " + redacted,
+		})
+	}
+	for _, k := range sensitiveJSONKeys {
+		r = append(r, redaction{
+			re:   regexp.MustCompile(fmt.Sprintf(`(?i)("%s"\s*:\s*)"(?:[^"\\]|\\.)*"`, regexp.QuoteMeta(k))),
+			repl: `${1}"` + redacted + `"`,
+		})
+	}
+	return r
+}
+
+// Redact returns b with the credentials, tokens and customization secrets in
+// SOAP and JSON payloads and HTTP headers replaced by "***".
+func Redact(b []byte) []byte {
+	for _, r := range redactions {
+		b = r.re.ReplaceAll(b, []byte(r.repl))
+	}
+	return b
+}
+
+// RedactURL returns rawURL with the password in its user information, if any,
+// replaced by "xxxxx". A URL that cannot be parsed is redacted entirely.
+func RedactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return redacted
+	}
+	return u.Redacted()
+}
+
+// NewRedactingDebugProvider returns a govmomi debug provider that redacts the
+// traces written to p with Redact.
+func NewRedactingDebugProvider(p debug.Provider) debug.Provider {
+	return &redactingDebugProvider{p: p}
+}
+
+type redactingDebugProvider struct {
+	p debug.Provider
+}
+
+// NewFile implements debug.Provider for redactingDebugProvider. A trace is
+// buffered until it is closed, as a secret may span several writes.
+func (r *redactingDebugProvider) NewFile(s string) io.WriteCloser {
+	return &redactingWriter{
+		open: func() io.WriteCloser { return r.p.NewFile(s) },
+	}
+}
+
+// Flush implements debug.Provider for redactingDebugProvider.
+func (r *redactingDebugProvider) Flush() {
+	r.p.Flush()
+}
+
+type redactingWriter struct {
+	open   func() io.WriteCloser
+	buf    bytes.Buffer
+	closed bool
+}
+
+func (w *redactingWriter) Write(p []byte) (int, error) {
+	return w.buf.Write(p)
+}
+
+func (w *redactingWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	f := w.open()
+	if _, err := f.Write(Redact(w.buf.Bytes())); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
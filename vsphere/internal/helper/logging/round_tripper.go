@@ -0,0 +1,125 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package logging
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// NewSOAPRoundTripper wraps a SOAP round tripper to log each API call to the
+// subsystem, with the method, the managed object it is invoked on, the task
+// it starts, if any, and its duration. Request and response bodies are not
+// logged; client_debug can be used to trace them.
+func NewSOAPRoundTripper(rt soap.RoundTripper, subsystem string) soap.RoundTripper {
+	return &soapRoundTripper{
+		rt:        rt,
+		subsystem: subsystem,
+	}
+}
+
+type soapRoundTripper struct {
+	rt        soap.RoundTripper
+	subsystem string
+}
+
+// RoundTrip implements soap.RoundTripper for soapRoundTripper.
+func (t *soapRoundTripper) RoundTrip(ctx context.Context, req, res soap.HasFault) error {
+	start := time.Now()
+	err := t.rt.RoundTrip(ctx, req, res)
+
+	fields := map[string]interface{}{
+		"method":   soapMethod(req),
+		"duration": time.Since(start).String(),
+	}
+	if ref := soapReference(req, "Req", "This"); ref != nil {
+		fields["moid"] = ref.Value
+	}
+	if ref := soapReference(res, "Res", "Returnval"); ref != nil && ref.Type == "Task" {
+		fields["task"] = ref.Value
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.SubsystemDebug(Context(ctx), t.subsystem, "vSphere API call failed", fields)
+		return err
+	}
+	tflog.SubsystemDebug(Context(ctx), t.subsystem, "vSphere API call", fields)
+	return nil
+}
+
+// soapMethod returns the name of the method of a request body, such as
+// RetrievePropertiesEx for *methods.RetrievePropertiesExBody.
+func soapMethod(body soap.HasFault) string {
+	t := reflect.TypeOf(body)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return strings.TrimSuffix(t.Name(), "Body")
+}
+
+// soapReference returns the managed object reference in the field of the
+// request or response carried by a method body, or nil if there is none.
+func soapReference(body soap.HasFault, message, field string) *types.ManagedObjectReference {
+	v := reflect.Indirect(reflect.ValueOf(body))
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	m := v.FieldByName(message)
+	if !m.IsValid() || m.Kind() != reflect.Ptr || m.IsNil() {
+		return nil
+	}
+	m = m.Elem()
+	if m.Kind() != reflect.Struct {
+		return nil
+	}
+	f := m.FieldByName(field)
+	if !f.IsValid() {
+		return nil
+	}
+	switch ref := f.Interface().(type) {
+	case types.ManagedObjectReference:
+		return &ref
+	case *types.ManagedObjectReference:
+		return ref
+	}
+	return nil
+}
+
+// NewRESTRoundTripper wraps an HTTP round tripper to log each REST API call
+// to the rest subsystem, with the method, path, status and duration.
+func NewRESTRoundTripper(rt http.RoundTripper) http.RoundTripper {
+	return &restRoundTripper{rt: rt}
+}
+
+type restRoundTripper struct {
+	rt http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper for restRoundTripper.
+func (t *restRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	res, err := t.rt.RoundTrip(req)
+
+	fields := map[string]interface{}{
+		"method":   req.Method,
+		"path":     req.URL.Path,
+		"duration": time.Since(start).String(),
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.SubsystemDebug(Context(req.Context()), SubsystemREST, "vSphere REST API call failed", fields)
+		return res, err
+	}
+	fields["status"] = res.StatusCode
+	tflog.SubsystemDebug(Context(req.Context()), SubsystemREST, "vSphere REST API call", fields)
+	return res, nil
+}
@@ -0,0 +1,77 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package logging
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/progress"
+)
+
+// TaskProgress returns a progress.Sinker for waiting on task, which logs
+// progress events with the task key as the task runs, and the duration and
+// outcome of the task once it completes. name describes the operation, such
+// as the resource the task runs for.
+func TaskProgress(ctx context.Context, task *object.Task, name string) progress.Sinker {
+	return &taskSinker{
+		ctx: Context(ctx),
+		fields: map[string]interface{}{
+			"task":      task.Reference().Value,
+			"operation": name,
+		},
+	}
+}
+
+type taskSinker struct {
+	ctx    context.Context
+	fields map[string]interface{}
+}
+
+// Sink implements progress.Sinker for taskSinker.
+func (s *taskSinker) Sink() chan<- progress.Report {
+	ch := make(chan progress.Report)
+	go s.log(ch)
+	return ch
+}
+
+func (s *taskSinker) log(ch <-chan progress.Report) {
+	start := time.Now()
+	last := float32(-1)
+	var err error
+	for r := range ch {
+		if r.Error() != nil {
+			err = r.Error()
+			continue
+		}
+		if r.Percentage() == last {
+			continue
+		}
+		last = r.Percentage()
+		tflog.Info(s.ctx, "Task progress", s.with(map[string]interface{}{
+			"progress": last,
+			"detail":   r.Detail(),
+		}))
+	}
+
+	fields := map[string]interface{}{
+		"duration": time.Since(start).Round(time.Second).String(),
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Info(s.ctx, "Task failed", s.with(fields))
+		return
+	}
+	tflog.Info(s.ctx, "Task complete", s.with(fields))
+}
+
+func (s *taskSinker) with(fields map[string]interface{}) map[string]interface{} {
+	for k, v := range s.fields {
+		fields[k] = v
+	}
+	return fields
+}
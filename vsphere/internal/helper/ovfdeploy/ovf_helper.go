@@ -11,14 +11,15 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync/atomic"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/vmware/govmomi"
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/ovf"
@@ -27,7 +28,9 @@ import (
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/datastore"
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/folder"
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/logging"
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/network"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/provider"
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/resourcepool"
 )
 
@@ -50,46 +53,64 @@ func (pr *ProgressReader) Read(p []byte) (n int, err error) {
 	return
 }
 
+// DeployOvfAndGetResult imports the OVF or OVA described by the import spec,
+// uploading its disks. The import is aborted if it does not complete within
+// timeout. A timeout of 0 waits indefinitely.
 func DeployOvfAndGetResult(client *govmomi.Client, ovfCreateImportSpecResult *types.OvfCreateImportSpecResult, resourcePoolObj *object.ResourcePool,
-	folder *object.Folder, host *object.HostSystem, filePath string, deployOva bool, fromLocal bool, allowUnverifiedSSL bool) error {
+	folder *object.Folder, host *object.HostSystem, filePath string, deployOva bool, fromLocal bool, httpClient *http.Client, timeout time.Duration) error {
 
 	var currBytesRead int64
 	var totalBytes int64
 
-	nfcLease, err := resourcePoolObj.ImportVApp(context.Background(), ovfCreateImportSpecResult.ImportSpec, folder, host)
+	ctx, cancel := provider.WithTimeout(timeout)
+	defer cancel()
+
+	nfcLease, err := resourcePoolObj.ImportVApp(ctx, ovfCreateImportSpecResult.ImportSpec, folder, host)
 	if err != nil {
 		return err
 	}
 
-	leaseInfo, err := nfcLease.Wait(context.Background(), ovfCreateImportSpecResult.FileItem)
+	leaseInfo, err := nfcLease.Wait(ctx, ovfCreateImportSpecResult.FileItem)
 	if err != nil {
 		return err
 	}
 
-	u := nfcLease.StartUpdater(context.Background(), leaseInfo)
+	u := nfcLease.StartUpdater(ctx, leaseInfo)
 	defer u.Done()
 
 	for _, ovfFileItem := range ovfCreateImportSpecResult.FileItem {
 		totalBytes += ovfFileItem.Size
 	}
-	log.Printf("Total size of files to upload is %v bytes", totalBytes)
+	lctx := logging.Context(ctx)
+	tflog.SubsystemDebug(lctx, logging.SubsystemOVF, "Uploading OVF files", map[string]interface{}{
+		"lease":       nfcLease.Reference().Value,
+		"total_bytes": totalBytes,
+	})
 
-	statusChannel := make(chan bool)
-	// Create a go routine to update progress regularly
+	// Update the lease progress regularly until the upload completes.
+	done := make(chan struct{})
+	defer close(done)
 	go func() {
+		ticker := time.NewTicker(10 * time.Second)
+		defer ticker.Stop()
 		for {
+			if totalBytes == 0 {
+				_ = nfcLease.Progress(ctx, 100)
+				return
+			}
+			uploaded := getTotalBytesRead(&currBytesRead)
+			progress := int32(uploaded * 100 / totalBytes)
+			tflog.SubsystemInfo(lctx, logging.SubsystemOVF, "OVF upload progress", map[string]interface{}{
+				"lease":          nfcLease.Reference().Value,
+				"uploaded_bytes": uploaded,
+				"total_bytes":    totalBytes,
+				"progress":       progress,
+			})
+			_ = nfcLease.Progress(ctx, progress)
 			select {
-			case <-statusChannel:
-				break
-			default:
-				if totalBytes == 0 {
-					_ = nfcLease.Progress(context.Background(), 100)
-					return
-				}
-				log.Printf("Uploaded %v of %v Bytes", getTotalBytesRead(&currBytesRead), totalBytes)
-				progress := (getTotalBytesRead(&currBytesRead) / totalBytes) * 100
-				_ = nfcLease.Progress(context.Background(), int32(progress))
-				time.Sleep(10 * time.Second)
+			case <-done:
+				return
+			case <-ticker.C:
 			}
 		}
 	}()
@@ -101,29 +122,31 @@ func DeployOvfAndGetResult(client *govmomi.Client, ovfCreateImportSpecResult *ty
 			}
 			if !deployOva {
 				if fromLocal {
-					err = uploadDisksFromLocal(client, filePath, ovfFileItem, deviceObj, &currBytesRead)
+					err = uploadDisksFromLocal(ctx, client, filePath, ovfFileItem, deviceObj, &currBytesRead)
 				} else {
-					err = uploadDisksFromURL(client, filePath, ovfFileItem, deviceObj, &currBytesRead, allowUnverifiedSSL)
+					err = uploadDisksFromURL(ctx, client, filePath, ovfFileItem, deviceObj, &currBytesRead, httpClient)
 				}
 			} else {
 				if fromLocal {
-					err = uploadOvaDisksFromLocal(client, filePath, ovfFileItem, deviceObj, &currBytesRead)
+					err = uploadOvaDisksFromLocal(ctx, client, filePath, ovfFileItem, deviceObj, &currBytesRead)
 				} else {
-					err = uploadOvaDisksFromURL(client, filePath, ovfFileItem, deviceObj, &currBytesRead, allowUnverifiedSSL)
+					err = uploadOvaDisksFromURL(ctx, client, filePath, ovfFileItem, deviceObj, &currBytesRead, httpClient)
 				}
 			}
 			if err != nil {
 				return fmt.Errorf("error while uploading the disk %s %s", ovfFileItem.Path, err)
 			}
-			log.Print(" DEBUG : Completed uploading the vmdk file", ovfFileItem.Path)
+			tflog.SubsystemDebug(lctx, logging.SubsystemOVF, "Completed uploading OVF file", map[string]interface{}{
+				"lease": nfcLease.Reference().Value,
+				"file":  ovfFileItem.Path,
+			})
 		}
 	}
-	statusChannel <- true
-	err = nfcLease.Progress(context.Background(), 100)
+	err = nfcLease.Progress(ctx, 100)
 	if err != nil {
 		return err
 	}
-	return nfcLease.Complete(context.Background())
+	return nfcLease.Complete(ctx)
 }
 
 func upload(ctx context.Context, client *govmomi.Client, item types.OvfFileItem, f io.Reader, rawURL string, size int64, totalBytesRead *int64) error {
@@ -187,50 +210,56 @@ func upload(ctx context.Context, client *govmomi.Client, item types.OvfFileItem,
 	return err
 }
 
-func uploadDisksFromLocal(client *govmomi.Client, filePath string, ovfFileItem types.OvfFileItem, deviceObj types.HttpNfcLeaseDeviceUrl, currBytesRead *int64) error {
+func uploadDisksFromLocal(ctx context.Context, client *govmomi.Client, filePath string, ovfFileItem types.OvfFileItem, deviceObj types.HttpNfcLeaseDeviceUrl, currBytesRead *int64) error {
 	var absoluteFilePath string
 	if strings.Contains(filePath, string(os.PathSeparator)) {
 		absoluteFilePath = filePath[:strings.LastIndex(filePath, string(os.PathSeparator))+1]
 	}
 	vmdkFilePath := absoluteFilePath + ovfFileItem.Path
-	log.Print(" [DEBUG] Absolute vmdk path: " + vmdkFilePath)
+	tflog.SubsystemDebug(logging.Context(ctx), logging.SubsystemOVF, "Uploading disk from local file", map[string]interface{}{
+		"path": vmdkFilePath,
+	})
 	vmdkFilePath = filepath.Clean(vmdkFilePath)
 	file, err := os.Open(vmdkFilePath)
 	if err != nil {
 		return err
 	}
-	err = upload(context.Background(), client, ovfFileItem, file, deviceObj.Url, ovfFileItem.Size, currBytesRead)
+	err = upload(ctx, client, ovfFileItem, file, deviceObj.Url, ovfFileItem.Size, currBytesRead)
 	if err != nil {
 		return fmt.Errorf("error while uploading the file %s %s", vmdkFilePath, err)
 	}
 	err = file.Close()
 	if err != nil {
-		log.Printf("error while closing the file %s", vmdkFilePath)
+		tflog.SubsystemWarn(logging.Context(ctx), logging.SubsystemOVF, "Error closing disk file", map[string]interface{}{
+			"path":  vmdkFilePath,
+			"error": err.Error(),
+		})
 	}
 	return nil
 }
 
-func uploadDisksFromURL(client *govmomi.Client, filePath string, ovfFileItem types.OvfFileItem, deviceObj types.HttpNfcLeaseDeviceUrl, currBytesRead *int64,
-	allowUnverifiedSSL bool) error {
+func uploadDisksFromURL(ctx context.Context, client *govmomi.Client, filePath string, ovfFileItem types.OvfFileItem, deviceObj types.HttpNfcLeaseDeviceUrl, currBytesRead *int64,
+	httpClient *http.Client) error {
 	var absoluteFilePath string
 	if strings.Contains(filePath, "/") {
 		absoluteFilePath = filePath[:strings.LastIndex(filePath, "/")+1]
 	}
 	vmdkFilePath := absoluteFilePath + ovfFileItem.Path
-	httpClient := getClient(allowUnverifiedSSL)
+	tflog.SubsystemDebug(logging.Context(ctx), logging.SubsystemOVF, "Uploading disk from URL", map[string]interface{}{
+		"url": logging.RedactURL(vmdkFilePath),
+	})
 	resp, err := httpClient.Get(vmdkFilePath)
-	log.Print(" [DEBUG] Absolute vmdk path: " + vmdkFilePath)
 	if err != nil {
 		return err
 	}
 	defer func(Body io.ReadCloser) {
 		_ = Body.Close()
 	}(resp.Body)
-	err = upload(context.Background(), client, ovfFileItem, resp.Body, deviceObj.Url, ovfFileItem.Size, currBytesRead)
+	err = upload(ctx, client, ovfFileItem, resp.Body, deviceObj.Url, ovfFileItem.Size, currBytesRead)
 	return err
 }
 
-func uploadOvaDisksFromLocal(client *govmomi.Client, filePath string, ovfFileItem types.OvfFileItem, deviceObj types.HttpNfcLeaseDeviceUrl, currBytesRead *int64) error {
+func uploadOvaDisksFromLocal(ctx context.Context, client *govmomi.Client, filePath string, ovfFileItem types.OvfFileItem, deviceObj types.HttpNfcLeaseDeviceUrl, currBytesRead *int64) error {
 	diskName := ovfFileItem.Path
 	ovaFile, err := os.Open(filePath)
 	if err != nil {
@@ -240,14 +269,13 @@ func uploadOvaDisksFromLocal(client *govmomi.Client, filePath string, ovfFileIte
 		_ = ovaFile.Close()
 	}(ovaFile)
 
-	err = findAndUploadDiskFromOva(client, ovaFile, diskName, ovfFileItem, deviceObj, currBytesRead)
+	err = findAndUploadDiskFromOva(ctx, client, ovaFile, diskName, ovfFileItem, deviceObj, currBytesRead)
 	return err
 }
 
-func uploadOvaDisksFromURL(client *govmomi.Client, filePath string, ovfFileItem types.OvfFileItem, deviceObj types.HttpNfcLeaseDeviceUrl, currBytesRead *int64,
-	allowUnverifiedSSL bool) error {
+func uploadOvaDisksFromURL(ctx context.Context, client *govmomi.Client, filePath string, ovfFileItem types.OvfFileItem, deviceObj types.HttpNfcLeaseDeviceUrl, currBytesRead *int64,
+	httpClient *http.Client) error {
 	diskName := ovfFileItem.Path
-	httpClient := getClient(allowUnverifiedSSL)
 	resp, err := httpClient.Get(filePath)
 	if err != nil {
 		return err
@@ -256,7 +284,7 @@ func uploadOvaDisksFromURL(client *govmomi.Client, filePath string, ovfFileItem
 		_ = Body.Close()
 	}(resp.Body)
 	if resp.StatusCode == http.StatusOK {
-		err = findAndUploadDiskFromOva(client, resp.Body, diskName, ovfFileItem, deviceObj, currBytesRead)
+		err = findAndUploadDiskFromOva(ctx, client, resp.Body, diskName, ovfFileItem, deviceObj, currBytesRead)
 		if err != nil {
 			return err
 		}
@@ -266,7 +294,7 @@ func uploadOvaDisksFromURL(client *govmomi.Client, filePath string, ovfFileItem
 	return nil
 }
 
-func GetOvfDescriptor(filePath string, deployOva bool, fromLocal bool, allowUnverifiedSSL bool) (string, error) {
+func GetOvfDescriptor(filePath string, deployOva bool, fromLocal bool, httpClient *http.Client) (string, error) {
 	ovfDescriptor := ""
 	if !deployOva {
 		if fromLocal {
@@ -277,8 +305,7 @@ func GetOvfDescriptor(filePath string, deployOva bool, fromLocal bool, allowUnve
 			}
 			ovfDescriptor = string(fileBuffer)
 		} else {
-			client := getClient(allowUnverifiedSSL)
-			resp, err := client.Get(filePath)
+			resp, err := httpClient.Get(filePath)
 			if err != nil {
 				return "", err
 			}
@@ -309,8 +336,7 @@ func GetOvfDescriptor(filePath string, deployOva bool, fromLocal bool, allowUnve
 				return "", err
 			}
 		} else {
-			client := getClient(allowUnverifiedSSL)
-			resp, err := client.Get(filePath)
+			resp, err := httpClient.Get(filePath)
 			if err != nil {
 				return "", err
 			}
@@ -350,7 +376,7 @@ func getOvfDescriptorFromOva(ovaFile io.Reader) (string, error) {
 	return "", fmt.Errorf("ovf file not found inside the ova")
 }
 
-func findAndUploadDiskFromOva(client *govmomi.Client, ovaFile io.Reader, diskName string, ovfFileItem types.OvfFileItem, deviceObj types.HttpNfcLeaseDeviceUrl, currBytesRead *int64) error {
+func findAndUploadDiskFromOva(ctx context.Context, client *govmomi.Client, ovaFile io.Reader, diskName string, ovfFileItem types.OvfFileItem, deviceObj types.HttpNfcLeaseDeviceUrl, currBytesRead *int64) error {
 	ovaReader := tar.NewReader(ovaFile)
 	for {
 		fileHdr, err := ovaReader.Next()
@@ -361,7 +387,7 @@ func findAndUploadDiskFromOva(client *govmomi.Client, ovaFile io.Reader, diskNam
 			return err
 		}
 		if fileHdr.Name == diskName {
-			err = upload(context.Background(), client, ovfFileItem, ovaReader, deviceObj.Url, ovfFileItem.Size, currBytesRead)
+			err = upload(ctx, client, ovfFileItem, ovaReader, deviceObj.Url, ovfFileItem.Size, currBytesRead)
 			if err != nil {
 				return fmt.Errorf("error while uploading the file %s %s", diskName, err)
 			}
@@ -384,14 +410,40 @@ func GetNetworkMapping(client *govmomi.Client, m map[string]interface{}) ([]type
 	return ovfNetworkMappings, nil
 }
 
-func getClient(allowUnverifiedSSL bool) *http.Client {
+// HTTPConfig is the TLS and proxy configuration of a provider instance, used
+// for requests to remote OVF and OVA files. It is carried with each request
+// instead of being shared by the package, so that several configurations of
+// the provider do not use each other's settings.
+type HTTPConfig struct {
+	// The TLS configuration of the provider. The default configuration is used
+	// when nil.
+	TLSClientConfig *tls.Config
+	// The function returning the proxy server for a request URL. No proxy is
+	// used when nil.
+	Proxy func(*url.URL) (*url.URL, error)
+}
+
+// NewHTTPClient returns an HTTP client for requests to remote OVF and OVA
+// files, using the TLS and proxy configuration in config, which may be nil.
+// Server certificates are not verified if allowUnverifiedSSL is set.
+func NewHTTPClient(config *HTTPConfig, allowUnverifiedSSL bool) *http.Client {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if config != nil && config.TLSClientConfig != nil {
+		tlsConfig = config.TLSClientConfig.Clone()
+	}
 	if allowUnverifiedSSL {
-		tr := &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint (gosec G402)
+		tlsConfig.InsecureSkipVerify = true //nolint (gosec G402)
+		tlsConfig.VerifyConnection = nil
+	}
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.TLSClientConfig = tlsConfig
+	if config != nil && config.Proxy != nil {
+		proxy := config.Proxy
+		tr.Proxy = func(req *http.Request) (*url.URL, error) {
+			return proxy(req.URL)
 		}
-		return &http.Client{Transport: tr}
 	}
-	return &http.Client{}
+	return &http.Client{Transport: tr}
 }
 
 func CheckDeploymentOption(client *govmomi.Client, deploymentOption, ovfDescriptor string) error {
@@ -415,6 +467,7 @@ func CheckDeploymentOption(client *govmomi.Client, deploymentOption, ovfDescript
 
 type OvfHelper struct {
 	AllowUnverifiedSSL bool
+	HTTPConfig         *HTTPConfig
 	Datastore          *object.Datastore
 	DeploymentOption   string
 	DeployOva          bool
@@ -432,6 +485,7 @@ type OvfHelper struct {
 
 type OvfHelperParams struct {
 	AllowUnverifiedSSL bool
+	HTTPConfig         *HTTPConfig
 	DatastoreID        string
 	DeploymentOption   string
 	DiskProvisioning   string
@@ -449,6 +503,7 @@ type OvfHelperParams struct {
 func NewOvfHelper(client *govmomi.Client, o *OvfHelperParams) (*OvfHelper, error) {
 	ovfParams := &OvfHelper{
 		AllowUnverifiedSSL: o.AllowUnverifiedSSL,
+		HTTPConfig:         o.HTTPConfig,
 		DeploymentOption:   o.DeploymentOption,
 		DiskProvisioning:   o.DiskProvisioning,
 		IPAllocationPolicy: o.IPAllocationPolicy,
@@ -528,7 +583,7 @@ func (o *OvfHelper) GetImportSpec(client *govmomi.Client) (*types.OvfCreateImpor
 		DiskProvisioning:   o.DiskProvisioning,
 	}
 
-	ovfDescriptor, err := GetOvfDescriptor(o.FilePath, o.DeployOva, o.IsLocal, o.AllowUnverifiedSSL)
+	ovfDescriptor, err := GetOvfDescriptor(o.FilePath, o.DeployOva, o.IsLocal, NewHTTPClient(o.HTTPConfig, o.AllowUnverifiedSSL))
 	if err != nil {
 		return nil, fmt.Errorf("error while reading the ovf file %s, %s ", o.FilePath, err)
 	}
@@ -564,7 +619,7 @@ func (o *OvfHelper) GetImportSpec(client *govmomi.Client) (*types.OvfCreateImpor
 	return is, nil
 }
 
-func (o *OvfHelper) DeployOvf(client *govmomi.Client, spec *types.OvfCreateImportSpecResult) error {
+func (o *OvfHelper) DeployOvf(client *govmomi.Client, spec *types.OvfCreateImportSpecResult, timeout time.Duration) error {
 	return DeployOvfAndGetResult(client, spec, o.ResourcePool, o.Folder, o.HostSystem,
-		o.FilePath, o.DeployOva, o.IsLocal, o.AllowUnverifiedSSL)
+		o.FilePath, o.DeployOva, o.IsLocal, NewHTTPClient(o.HTTPConfig, o.AllowUnverifiedSSL), timeout)
 }
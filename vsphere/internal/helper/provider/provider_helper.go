@@ -5,6 +5,7 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -13,6 +14,16 @@ import (
 // requiring contexts, and other various waiters.
 const DefaultAPITimeout = time.Minute * 5
 
+// WithTimeout returns a context that is cancelled after timeout. A timeout of 0
+// returns a context without a deadline, for operations that are not limited
+// unless a resource timeout is configured.
+func WithTimeout(timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
+
 func Error(id string, function string, err error) error {
 	return fmt.Errorf("%s: RESOURCE (%s), ACTION (%s)", err, id, function)
 }
@@ -7,13 +7,14 @@ package spbm
 import (
 	"context"
 	"fmt"
-	"log"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/vmware/govmomi"
 	"github.com/vmware/govmomi/pbm"
 	"github.com/vmware/govmomi/pbm/methods"
 	pbmtypes "github.com/vmware/govmomi/pbm/types"
 	"github.com/vmware/govmomi/vim25/types"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/logging"
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/provider"
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/viapi"
 )
@@ -34,7 +35,11 @@ func pbmClientFromGovmomiClient(ctx context.Context, client *govmomi.Client) (*p
 	}
 
 	pc, err := pbm.NewClient(ctx, client.Client)
-	return pc, err
+	if err != nil {
+		return nil, err
+	}
+	pc.RoundTripper = logging.NewSOAPRoundTripper(pc.RoundTripper, logging.SubsystemPBM)
+	return pc, nil
 }
 
 // PolicyIDByName finds a SPBM storage policy by name and returns its ID.
@@ -58,7 +63,9 @@ func PolicyNameByID(client *govmomi.Client, id string) (string, error) {
 		return "", provider.Error(id, "policyNameByID", err)
 	}
 
-	log.Printf("[DEBUG] Retrieving contents of storage profiles by id: %s.", id)
+	tflog.SubsystemDebug(logging.Context(ctx), logging.SubsystemPBM, "Retrieving contents of storage profile", map[string]interface{}{
+		"profile_id": id,
+	})
 	profileID := []pbmtypes.PbmProfileId{
 		{
 			UniqueId: id,
@@ -137,7 +144,10 @@ func PolicyIDByVirtualMachine(client *govmomi.Client, vmMOID string) (string, er
 
 // queryAssociatedProfile returns the PbmProfileId of the storage policy associated with entity.
 func queryAssociatedProfile(ctx context.Context, pc *pbm.Client, ref pbmtypes.PbmServerObjectRef) ([]pbmtypes.PbmProfileId, error) {
-	log.Printf("[DEBUG] queryAssociatedProfile: Retrieving storage policy of server object of type [%s] and key [%s].", ref.ObjectType, ref.Key)
+	tflog.SubsystemDebug(logging.Context(ctx), logging.SubsystemPBM, "Retrieving storage policy of server object", map[string]interface{}{
+		"object_type": ref.ObjectType,
+		"key":         ref.Key,
+	})
 	req := pbmtypes.PbmQueryAssociatedProfile{
 		This:   pc.ServiceContent.ProfileManager,
 		Entity: ref,
@@ -336,7 +336,7 @@ func RelocateVM(
 	client *govmomi.Client,
 	vm *object.VirtualMachine,
 	spec types.VirtualMachineRelocateSpec,
-	timeout int,
+	timeout time.Duration,
 	pod *object.StoragePod,
 ) error {
 	sdrsEnabled, err := StorageDRSEnabled(pod)
@@ -362,7 +362,7 @@ func RelocateVM(
 		Type:         string(types.StoragePlacementSpecPlacementTypeRelocate),
 	}
 
-	_, err = recommendAndApplySDRS(client, sps, time.Minute*time.Duration(timeout))
+	_, err = recommendAndApplySDRS(client, sps, timeout)
 	return err
 }
 
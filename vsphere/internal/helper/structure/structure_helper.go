@@ -9,7 +9,10 @@ import (
 	"math"
 	"reflect"
 	"strconv"
+	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/govmomi/vim25/types"
 )
@@ -550,7 +553,7 @@ func (s MoRefSorter) Swap(i, j int) {
 // the value for each key is available at CustomizeDiff time. This function
 // will return false if any of they values are based on computed values from
 // other new or updated resources.
-func ValuesAvailable(base string, keys []string, d *schema.ResourceDiff) bool {
+func ValuesAvailable(base string, keys []string, d NewValueKnownReader) bool {
 	for _, k := range keys {
 		if !d.NewValueKnown(fmt.Sprintf("%s%s", base, k)) {
 			return false
@@ -558,3 +561,55 @@ func ValuesAvailable(base string, keys []string, d *schema.ResourceDiff) bool {
 	}
 	return true
 }
+
+// NewValueKnownReader is a small interface that can be used to supply
+// ResourceDiff to ValuesAvailable.
+type NewValueKnownReader interface {
+	NewValueKnown(string) bool
+}
+
+// RawConfigReader is a small interface that can be used to supply
+// ResourceData and ResourceDiff to functions that need to read values from
+// the raw configuration, namely write-only attributes.
+type RawConfigReader interface {
+	GetRawConfigAt(cty.Path) (cty.Value, diag.Diagnostics)
+}
+
+// GetWriteOnlyString returns the value of a write-only string attribute, with
+// key in the dotted notation used by ResourceData.Get, such as
+// "spec.0.windows_options.0.admin_password_wo". Write-only values are never
+// persisted to state and are only available from the configuration during
+// plan, create and update, so an empty string is returned if the value is not
+// set or not available.
+func GetWriteOnlyString(d RawConfigReader, key string) string {
+	v := rawConfigValue(d, key)
+	if !v.IsKnown() || v.IsNull() || !v.Type().Equals(cty.String) {
+		return ""
+	}
+	return v.AsString()
+}
+
+// WriteOnlyValueSet returns true if a write-only attribute is set in the
+// configuration. Unlike GetWriteOnlyString, values that are not known yet at
+// plan time count as set.
+func WriteOnlyValueSet(d RawConfigReader, key string) bool {
+	return !rawConfigValue(d, key).IsNull()
+}
+
+// rawConfigValue returns the value at key in the raw configuration, or null
+// if the configuration or the value is not available.
+func rawConfigValue(d RawConfigReader, key string) cty.Value {
+	var p cty.Path
+	for _, s := range strings.Split(key, ".") {
+		if i, err := strconv.Atoi(s); err == nil {
+			p = p.IndexInt(i)
+			continue
+		}
+		p = p.GetAttr(s)
+	}
+	v, diags := d.GetRawConfigAt(p)
+	if diags.HasError() {
+		return cty.NullVal(cty.DynamicPseudoType)
+	}
+	return v
+}
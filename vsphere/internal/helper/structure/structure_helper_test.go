@@ -0,0 +1,150 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package structure
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// testRawConfig is a RawConfigReader over a fixed configuration value, which
+// resolves paths the same way as ResourceData.
+type testRawConfig struct {
+	config cty.Value
+}
+
+func (c testRawConfig) GetRawConfigAt(p cty.Path) (cty.Value, diag.Diagnostics) {
+	v, err := p.Apply(c.config)
+	if err != nil {
+		return cty.DynamicVal, diag.FromErr(err)
+	}
+	return v, nil
+}
+
+func testRawConfigWithPassword(password cty.Value) testRawConfig {
+	return testRawConfig{
+		config: cty.ObjectVal(map[string]cty.Value{
+			"spec": cty.ListVal([]cty.Value{
+				cty.ObjectVal(map[string]cty.Value{
+					"password_wo": password,
+				}),
+			}),
+		}),
+	}
+}
+
+func TestWriteOnlyValues(t *testing.T) {
+	cases := []struct {
+		name           string
+		config         testRawConfig
+		key            string
+		expectedString string
+		expectedSet    bool
+	}{
+		{
+			name:           "set",
+			config:         testRawConfigWithPassword(cty.StringVal("secret")),
+			key:            "spec.0.password_wo",
+			expectedString: "secret",
+			expectedSet:    true,
+		},
+		{
+			name:           "null",
+			config:         testRawConfigWithPassword(cty.NullVal(cty.String)),
+			key:            "spec.0.password_wo",
+			expectedString: "",
+			expectedSet:    false,
+		},
+		{
+			name:           "unknown",
+			config:         testRawConfigWithPassword(cty.UnknownVal(cty.String)),
+			key:            "spec.0.password_wo",
+			expectedString: "",
+			expectedSet:    true,
+		},
+		{
+			name:           "empty string",
+			config:         testRawConfigWithPassword(cty.StringVal("")),
+			key:            "spec.0.password_wo",
+			expectedString: "",
+			expectedSet:    true,
+		},
+		{
+			name:           "missing attribute",
+			config:         testRawConfigWithPassword(cty.StringVal("secret")),
+			key:            "spec.0.other_wo",
+			expectedString: "",
+			expectedSet:    false,
+		},
+		{
+			name:           "missing block",
+			config:         testRawConfigWithPassword(cty.StringVal("secret")),
+			key:            "spec.1.password_wo",
+			expectedString: "",
+			expectedSet:    false,
+		},
+		{
+			name:           "null configuration",
+			config:         testRawConfig{config: cty.NullVal(cty.DynamicPseudoType)},
+			key:            "spec.0.password_wo",
+			expectedString: "",
+			expectedSet:    false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := GetWriteOnlyString(tc.config, tc.key); actual != tc.expectedString {
+				t.Fatalf("GetWriteOnlyString: expected %q, got %q", tc.expectedString, actual)
+			}
+			if actual := WriteOnlyValueSet(tc.config, tc.key); actual != tc.expectedSet {
+				t.Fatalf("WriteOnlyValueSet: expected %t, got %t", tc.expectedSet, actual)
+			}
+		})
+	}
+}
+
+func TestRawConfigValue(t *testing.T) {
+	config := testRawConfigWithPassword(cty.StringVal("secret"))
+	cases := []struct {
+		name     string
+		key      string
+		expected cty.Value
+	}{
+		{
+			name:     "attribute in list block",
+			key:      "spec.0.password_wo",
+			expected: cty.StringVal("secret"),
+		},
+		{
+			name: "list block",
+			key:  "spec.0",
+			expected: cty.ObjectVal(map[string]cty.Value{
+				"password_wo": cty.StringVal("secret"),
+			}),
+		},
+		{
+			name:     "index out of range",
+			key:      "spec.2.password_wo",
+			expected: cty.NullVal(cty.DynamicPseudoType),
+		},
+		{
+			name:     "index on attribute",
+			key:      "spec.0.password_wo.0",
+			expected: cty.NullVal(cty.DynamicPseudoType),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := rawConfigValue(config, tc.key)
+			if !actual.RawEquals(tc.expected) {
+				t.Fatalf("expected %#v, got %#v", tc.expected, actual)
+			}
+		})
+	}
+}
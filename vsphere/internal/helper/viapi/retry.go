@@ -0,0 +1,194 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package viapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/vmware/govmomi/task"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// maxRetryBackoff caps the wait between two attempts of a retried operation.
+const maxRetryBackoff = time.Minute
+
+// RetryPolicy controls how operations failing with transient faults are
+// retried.
+type RetryPolicy struct {
+	// The maximum number of attempts, including the first one. A value of 1 or
+	// less disables retries.
+	Attempts int
+
+	// The wait before the first retry. The wait doubles with every subsequent
+	// retry, up to one minute.
+	Backoff time.Duration
+}
+
+// DefaultRetryPolicy is the retry policy used for clients that no policy was
+// set for.
+var DefaultRetryPolicy = RetryPolicy{Attempts: 3, Backoff: 2 * time.Second}
+
+// retryPolicies holds the retry policy of each client, keyed by
+// *vim25.Client, so that provider instances with different settings do not
+// share a policy.
+var retryPolicies sync.Map
+
+// SetRetryPolicy sets the retry policy used by Retry for operations made
+// through the supplied client. This is set from the configuration of the
+// provider instance that owns the client.
+func SetRetryPolicy(c *vim25.Client, p RetryPolicy) {
+	retryPolicies.Store(c, p)
+}
+
+// RetryPolicyFor returns the retry policy used by Retry for operations made
+// through the supplied client.
+func RetryPolicyFor(c *vim25.Client) RetryPolicy {
+	if p, ok := retryPolicies.Load(c); ok {
+		return p.(RetryPolicy)
+	}
+	return DefaultRetryPolicy
+}
+
+// Retry runs f, running it again according to the retry policy of the client
+// if it fails with a transient fault, as determined by IsRetryableError. Any
+// other error is returned immediately, as f may have already made changes that
+// should not be repeated.
+//
+// f should contain both the submission of an operation and the wait for its
+// task, so that a failed task is submitted again.
+func Retry(c *vim25.Client, name string, f func() error) error {
+	return RetryWithPolicy(context.Background(), RetryPolicyFor(c), name, f)
+}
+
+// RetryWithPolicy is Retry with an explicit policy. Waits between attempts are
+// cancelled with ctx.
+func RetryWithPolicy(ctx context.Context, p RetryPolicy, name string, f func() error) error {
+	backoff := p.Backoff
+	for attempt := 1; ; attempt++ {
+		err := f()
+		if err == nil || attempt >= p.Attempts || !IsRetryableError(err) {
+			return err
+		}
+		log.Printf("[DEBUG] %s: attempt %d of %d failed with transient fault, retrying in %s: %s", name, attempt, p.Attempts, backoff, err)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return err
+		}
+		backoff = nextRetryBackoff(backoff)
+	}
+}
+
+func nextRetryBackoff(backoff time.Duration) time.Duration {
+	backoff *= 2
+	if backoff > maxRetryBackoff {
+		return maxRetryBackoff
+	}
+	return backoff
+}
+
+// restRetryRoundTripper retries REST API requests that fail with 503 Service
+// Unavailable, which is returned while the vCenter services are starting or
+// overloaded and before the request is processed.
+type restRetryRoundTripper struct {
+	rt     http.RoundTripper
+	policy RetryPolicy
+}
+
+// NewRESTRetryRoundTripper returns a round tripper that sends requests
+// through rt and retries those answered with 503 Service Unavailable according
+// to the supplied policy. Requests with a body that cannot be replayed are not
+// retried.
+func NewRESTRetryRoundTripper(rt http.RoundTripper, p RetryPolicy) http.RoundTripper {
+	return &restRetryRoundTripper{rt: rt, policy: p}
+}
+
+func (r *restRetryRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	backoff := r.policy.Backoff
+	for attempt := 1; ; attempt++ {
+		res, err := r.rt.RoundTrip(req)
+		if err != nil || res.StatusCode != http.StatusServiceUnavailable || attempt >= r.policy.Attempts {
+			return res, err
+		}
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return res, nil
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				return res, nil
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+
+		log.Printf("[DEBUG] %s %s: attempt %d of %d failed with %s, retrying in %s", req.Method, req.URL.Path, attempt, r.policy.Attempts, res.Status, backoff)
+		select {
+		case <-time.After(backoff):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		backoff = nextRetryBackoff(backoff)
+	}
+}
+
+// IsRetryableError checks an error to see if it's a transient fault that is
+// safe to retry. These are faults returned when an operation could not be
+// started or was aborted before making changes:
+//
+// * TaskInProgress and its subtypes, when the object is busy with another
+// task.
+// * ConcurrentAccess, when the object was modified by another operation.
+// * HostCommunication and its subtypes, when the host could not be reached.
+//
+// Both faults returned by SOAP API calls and faults of failed tasks are
+// checked. REST API requests are retried by the round tripper returned by
+// NewRESTRetryRoundTripper instead.
+func IsRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if f, ok := vimFault(err); ok {
+		return isRetryableFault(f)
+	}
+	return false
+}
+
+// vimFault extracts the VIM fault from a SOAP fault or a task error.
+func vimFault(err error) (interface{}, bool) {
+	if f, ok := vimSoapFault(err); ok && f != nil {
+		return f, true
+	}
+	var te task.Error
+	if errors.As(err, &te) && te.LocalizedMethodFault != nil && te.Fault() != nil {
+		return te.Fault(), true
+	}
+	return nil, false
+}
+
+func isRetryableFault(f interface{}) bool {
+	// SOAP faults are decoded to values, while task faults are pointers. The
+	// fault type interfaces are implemented on pointers only.
+	if v := reflect.ValueOf(f); v.Kind() == reflect.Struct {
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		f = p.Interface()
+	}
+	switch f.(type) {
+	case types.BaseTaskInProgress, types.BaseHostCommunication, *types.ConcurrentAccess:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package viapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/vmware/govmomi/task"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func testSoapFaultError(fault types.AnyType) error {
+	f := &soap.Fault{}
+	f.Detail.Fault = fault
+	return soap.WrapSoapFault(f)
+}
+
+func testTaskError(fault types.BaseMethodFault) error {
+	return task.Error{LocalizedMethodFault: &types.LocalizedMethodFault{Fault: fault}}
+}
+
+func TestIsRetryableError(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"nil", nil, false},
+		{"generic error", errors.New("foo"), false},
+		{"soap task in progress", testSoapFaultError(types.TaskInProgress{}), true},
+		{"soap concurrent access", testSoapFaultError(types.ConcurrentAccess{}), true},
+		{"soap host not connected", testSoapFaultError(types.HostNotConnected{}), true},
+		{"soap not found", testSoapFaultError(types.ManagedObjectNotFound{}), false},
+		{"task vapp task in progress", testTaskError(&types.VAppTaskInProgress{}), true},
+		{"task host communication", testTaskError(&types.HostCommunication{}), true},
+		{"task invalid state", testTaskError(&types.InvalidState{}), false},
+		{"wrapped task concurrent access", fmt.Errorf("reconfigure: %w", testTaskError(&types.ConcurrentAccess{})), true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := IsRetryableError(tc.err); actual != tc.expected {
+				t.Fatalf("expected %t, got %t", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRetryWithPolicy(t *testing.T) {
+	p := RetryPolicy{Attempts: 3}
+	cases := []struct {
+		name     string
+		errs     []error
+		expected int
+		wantErr  bool
+	}{
+		{"success", []error{nil}, 1, false},
+		{"transient then success", []error{testTaskError(&types.TaskInProgress{}), nil}, 2, false},
+		{"transient until exhausted", []error{testTaskError(&types.TaskInProgress{})}, 3, true},
+		{"not retryable", []error{testTaskError(&types.InvalidState{})}, 1, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls int
+			err := RetryWithPolicy(context.Background(), p, "test", func() error {
+				err := tc.errs[min(calls, len(tc.errs)-1)]
+				calls++
+				return err
+			})
+			if calls != tc.expected {
+				t.Fatalf("expected %d calls, got %d", tc.expected, calls)
+			}
+			if tc.wantErr != (err != nil) {
+				t.Fatalf("expected error: %t, got: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRESTRetryRoundTripper(t *testing.T) {
+	cases := []struct {
+		name     string
+		statuses []int
+		expected int
+		status   int
+	}{
+		{"success", []int{http.StatusOK}, 1, http.StatusOK},
+		{"unavailable then success", []int{http.StatusServiceUnavailable, http.StatusOK}, 2, http.StatusOK},
+		{"unavailable until exhausted", []int{http.StatusServiceUnavailable}, 3, http.StatusServiceUnavailable},
+		{"not retryable", []int{http.StatusInternalServerError}, 1, http.StatusInternalServerError},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if string(body) != "payload" {
+					t.Errorf("expected body to be replayed, got %q", body)
+				}
+				w.WriteHeader(tc.statuses[min(calls, len(tc.statuses)-1)])
+				calls++
+			}))
+			defer srv.Close()
+
+			c := &http.Client{Transport: NewRESTRetryRoundTripper(http.DefaultTransport, RetryPolicy{Attempts: 3})}
+			res, err := c.Post(srv.URL, "text/plain", strings.NewReader("payload"))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			_ = res.Body.Close()
+			if calls != tc.expected {
+				t.Fatalf("expected %d calls, got %d", tc.expected, calls)
+			}
+			if res.StatusCode != tc.status {
+				t.Fatalf("expected status %d, got %d", tc.status, res.StatusCode)
+			}
+		})
+	}
+}
@@ -95,6 +95,17 @@ func IsResourceInUseError(err error) bool {
 	return false
 }
 
+// IsInvalidNameError checks an error to see if it's of the
+// InvalidName type.
+func IsInvalidNameError(err error) bool {
+	if f, ok := vimSoapFault(err); ok {
+		if _, ok := f.(types.InvalidName); ok {
+			return true
+		}
+	}
+	return false
+}
+
 // RenameObject renames a MO and tracks the task to make sure it completes.
 func RenameObject(client *govmomi.Client, ref types.ManagedObjectReference, newObjectName string) error {
 	req := types.Rename_Task{
@@ -26,6 +26,7 @@ import (
 	"github.com/vmware/govmomi/vim25/mo"
 	"github.com/vmware/govmomi/vim25/types"
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/folder"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/logging"
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/provider"
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/structure"
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/vappcontainer"
@@ -515,7 +516,7 @@ func Create(c *govmomi.Client, f *object.Folder, s types.VirtualMachineConfigSpe
 	}
 	tctx, tcancel := context.WithTimeout(context.Background(), timeout)
 	defer tcancel()
-	result, err := task.WaitForResultEx(tctx, nil)
+	result, err := task.WaitForResultEx(tctx, logging.TaskProgress(tctx, task, fmt.Sprintf("create virtual machine %s/%s", f.InventoryPath, s.Name)))
 	if err != nil {
 		return nil, err
 	}
@@ -536,7 +537,7 @@ func Clone(c *govmomi.Client, src *object.VirtualMachine, f *object.Folder, name
 		}
 		return nil, err
 	}
-	result, err := task.WaitForResultEx(ctx, nil)
+	result, err := task.WaitForResultEx(ctx, logging.TaskProgress(ctx, task, fmt.Sprintf("clone virtual machine %s/%s", f.InventoryPath, name)))
 	if err != nil {
 		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
 			err = errors.New("timeout waiting for clone to complete")
@@ -656,15 +657,17 @@ func DiskType(d *schema.ResourceData) string {
 // waiting of the task.
 func Customize(vm *object.VirtualMachine, spec types.CustomizationSpec) error {
 	log.Printf("[DEBUG] Sending customization spec to virtual machine %q", vm.InventoryPath)
-	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
-	defer cancel()
-	task, err := vm.Customize(ctx, spec)
-	if err != nil {
-		return err
-	}
-	tctx, tcancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
-	defer tcancel()
-	return task.WaitEx(tctx)
+	return viapi.Retry(vm.Client(), "virtualmachine.Customize", func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+		defer cancel()
+		task, err := vm.Customize(ctx, spec)
+		if err != nil {
+			return err
+		}
+		tctx, tcancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+		defer tcancel()
+		return task.WaitEx(tctx)
+	})
 }
 
 // PowerOn wraps powering on a VM and the waiting for the subsequent task.
@@ -727,17 +730,19 @@ powerLoop:
 }
 
 // PowerOff wraps powering off a VM and the waiting for the subsequent task.
-func PowerOff(vm *object.VirtualMachine) error {
+func PowerOff(vm *object.VirtualMachine, timeout time.Duration) error {
 	log.Printf("[DEBUG] Forcing power off of virtual machine of %q", vm.InventoryPath)
-	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
-	defer cancel()
-	task, err := vm.PowerOff(ctx)
-	if err != nil {
-		return err
-	}
-	tctx, tcancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
-	defer tcancel()
-	return task.WaitEx(tctx)
+	return viapi.Retry(vm.Client(), "virtualmachine.PowerOff", func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		task, err := vm.PowerOff(ctx)
+		if err != nil {
+			return err
+		}
+		tctx, tcancel := context.WithTimeout(context.Background(), timeout)
+		defer tcancel()
+		return task.WaitEx(tctx)
+	})
 }
 
 // ShutdownGuest wraps the graceful shutdown of a guest VM, and then waiting an
@@ -793,7 +798,10 @@ func ShutdownGuest(client *govmomi.Client, vm *object.VirtualMachine, timeout in
 // machines. A graceful shutdown is attempted first if possible (VMware Tools
 // is installed, and the guest state is not suspended), and then, if allowed, a
 // power-off is forced if that fails.
-func GracefulPowerOff(client *govmomi.Client, vm *object.VirtualMachine, timeout int, force bool) error {
+//
+// shutdownTimeout is the time in minutes to wait for the guest shutdown, and
+// timeout is the timeout of the forced power-off task.
+func GracefulPowerOff(client *govmomi.Client, vm *object.VirtualMachine, shutdownTimeout int, timeout time.Duration, force bool) error {
 	vprops, err := Properties(vm)
 	if err != nil {
 		return err
@@ -802,7 +810,7 @@ func GracefulPowerOff(client *govmomi.Client, vm *object.VirtualMachine, timeout
 	// actually powered on (we don't expect that a graceful shutdown would
 	// complete on a suspended VM, so there's really no point in trying).
 	if vprops.Runtime.PowerState == types.VirtualMachinePowerStatePoweredOn && vprops.Guest != nil && vprops.Guest.ToolsRunningStatus == string(types.VirtualMachineToolsRunningStatusGuestToolsRunning) {
-		if err := ShutdownGuest(client, vm, timeout); err != nil {
+		if err := ShutdownGuest(client, vm, shutdownTimeout); err != nil {
 			if errors.Is(err, errGuestShutdownTimeout) && !force {
 				return err
 			}
@@ -813,7 +821,7 @@ func GracefulPowerOff(client *govmomi.Client, vm *object.VirtualMachine, timeout
 	// If the guest shutdown failed (and we were allowed to proceed), or
 	// conditions did not satisfy the criteria for a graceful shutdown, do a full
 	// power-off of the VM.
-	return PowerOff(vm)
+	return PowerOff(vm, timeout)
 }
 
 // MoveToFolder moves a virtual machine to the specified folder.
@@ -830,49 +838,56 @@ func MoveToFolder(client *govmomi.Client, vm *object.VirtualMachine, relative st
 // the task to complete.
 func Reconfigure(vm *object.VirtualMachine, spec types.VirtualMachineConfigSpec, timeout time.Duration) error {
 	log.Printf("[DEBUG] Reconfiguring virtual machine %q", vm.InventoryPath)
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
-	task, err := vm.Reconfigure(ctx, spec)
-	if err != nil {
+	return viapi.Retry(vm.Client(), "virtualmachine.Reconfigure", func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		task, err := vm.Reconfigure(ctx, spec)
+		if err != nil {
+			return err
+		}
+		tctx, tcancel := context.WithTimeout(context.Background(), timeout)
+		defer tcancel()
+		_, err = task.WaitForResultEx(tctx, logging.TaskProgress(tctx, task, fmt.Sprintf("reconfigure virtual machine %s", vm.InventoryPath)))
 		return err
-	}
-	tctx, tcancel := context.WithTimeout(context.Background(), timeout)
-	defer tcancel()
-	return task.WaitEx(tctx)
+	})
 }
 
 // Relocate wraps the Relocate task and the subsequent waiting for the task to
 // complete.
-func Relocate(vm *object.VirtualMachine, spec types.VirtualMachineRelocateSpec, timeout int) error {
-	log.Printf("[DEBUG] Beginning migration of virtual machine %q (timeout %d)", vm.InventoryPath, timeout)
-	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*time.Duration(timeout))
+func Relocate(vm *object.VirtualMachine, spec types.VirtualMachineRelocateSpec, timeout time.Duration) error {
+	log.Printf("[DEBUG] Beginning migration of virtual machine %q (timeout %s)", vm.InventoryPath, timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
-	task, err := vm.Relocate(ctx, spec, "")
-	if err != nil {
-		return err
-	}
-	if err := task.WaitEx(ctx); err != nil {
-		// Provide a friendly error message if we timed out waiting for the migration.
-		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-			return errors.New("timeout waiting for migration to complete")
+	return viapi.RetryWithPolicy(ctx, viapi.RetryPolicyFor(vm.Client()), "virtualmachine.Relocate", func() error {
+		task, err := vm.Relocate(ctx, spec, "")
+		if err != nil {
+			return err
 		}
-	}
-	return nil
+		if _, err := task.WaitForResultEx(ctx, logging.TaskProgress(ctx, task, fmt.Sprintf("migrate virtual machine %s", vm.InventoryPath))); err != nil {
+			// Provide a friendly error message if we timed out waiting for the migration.
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return errors.New("timeout waiting for migration to complete")
+			}
+		}
+		return nil
+	})
 }
 
 // Destroy wraps the Destroy task and the subsequent waiting for the task to
 // complete.
-func Destroy(vm *object.VirtualMachine) error {
+func Destroy(vm *object.VirtualMachine, timeout time.Duration) error {
 	log.Printf("[DEBUG] Deleting virtual machine %q", vm.InventoryPath)
-	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
-	defer cancel()
-	task, err := vm.Destroy(ctx)
-	if err != nil {
-		return err
-	}
-	tctx, tcancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
-	defer tcancel()
-	return task.WaitEx(tctx)
+	return viapi.Retry(vm.Client(), "virtualmachine.Destroy", func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		task, err := vm.Destroy(ctx)
+		if err != nil {
+			return err
+		}
+		tctx, tcancel := context.WithTimeout(context.Background(), timeout)
+		defer tcancel()
+		return task.WaitEx(tctx)
+	})
 }
 
 // MOIDForUUIDResult is a struct that holds a virtual machine UUID -> MOID
@@ -7,6 +7,7 @@ package vsphere
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/vmware/govmomi"
 	"github.com/vmware/govmomi/object"
@@ -37,6 +38,9 @@ type nasDatastoreMountProcessor struct {
 	// with that newly created datastore. If this is missing, unmount operations
 	// will also be skipped.
 	ds *object.Datastore
+
+	// The timeout for each mount operation.
+	timeout time.Duration
 }
 
 // diffOldNew returns any elements of old that were missing in new.
@@ -88,7 +92,7 @@ func (p *nasDatastoreMountProcessor) processMountOperations() (*object.Datastore
 			return p.ds, fmt.Errorf("host %q: %s", hostsystem.NameOrID(p.client, hsID), err)
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
 
 		ds, err := dss.CreateNasDatastore(ctx, *p.volSpec)
 		if err != nil {
@@ -5,11 +5,15 @@
 package vsphere
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/logging"
 )
 
 // defaultAPITimeout is a default timeout value that is passed to functions
@@ -18,22 +22,53 @@ var defaultAPITimeout = time.Minute * 5
 
 // Provider returns a terraform.ResourceProvider.
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"user": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("VSPHERE_USER", nil),
 				Description: "The user name for vSphere API operations.",
 			},
 
 			"password": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("VSPHERE_PASSWORD", nil),
 				Description: "The user password for vSphere API operations.",
 			},
 
+			"saml_token": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				DefaultFunc:   schema.EnvDefaultFunc("VSPHERE_SAML_TOKEN", ""),
+				Description:   "A SAML token issued by the vCenter Server STS to log in with instead of a user name and password.",
+				ConflictsWith: []string{"oidc_token"},
+			},
+			"client_certificate": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("VSPHERE_CLIENT_CERTIFICATE", ""),
+				Description:   "The path to a PEM-encoded solution user certificate used to obtain a holder-of-key SAML token.",
+				RequiredWith:  []string{"client_key"},
+				ConflictsWith: []string{"oidc_token"},
+			},
+			"client_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("VSPHERE_CLIENT_KEY", ""),
+				Description:  "The path to the PEM-encoded private key of the solution user certificate.",
+				RequiredWith: []string{"client_certificate"},
+			},
+			"oidc_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("VSPHERE_OIDC_TOKEN", ""),
+				Description: "An access token issued by the identity provider configured for vCenter Server identity federation, exchanged for a SAML token to log in with.",
+			},
+
 			"vsphere_server": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -46,6 +81,45 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("VSPHERE_ALLOW_UNVERIFIED_SSL", false),
 				Description: "If set, VMware vSphere client will permit unverifiable SSL certificates.",
 			},
+			"ca_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("VSPHERE_CA_FILE", ""),
+				Description:   "The path to a PEM-encoded CA bundle used to verify the certificate of the vSphere server.",
+				ConflictsWith: []string{"ca_bundle"},
+			},
+			"ca_bundle": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("VSPHERE_CA_BUNDLE", ""),
+				Description:   "PEM-encoded CA certificates used to verify the certificate of the vSphere server.",
+				ConflictsWith: []string{"ca_file"},
+			},
+			"vsphere_server_thumbprint": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VSPHERE_SERVER_THUMBPRINT", ""),
+				Description: "The SHA-256 thumbprint of the vSphere server certificate. If set, connections to the vSphere server are only permitted if the certificate matches.",
+			},
+			"proxy_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VSPHERE_PROXY_URL", ""),
+				Description: "The URL of the proxy server for connections made by the provider. If not set, the HTTPS_PROXY and HTTP_PROXY environment variables are used.",
+			},
+			"no_proxy": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VSPHERE_NO_PROXY", ""),
+				Description: "A comma-separated list of hosts, domains and networks that are connected to directly instead of through the proxy server. If not set, the NO_PROXY environment variable is used.",
+			},
+			"tls_min_version": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("VSPHERE_TLS_MIN_VERSION", "1.2"),
+				Description:  "The minimum TLS version for connections to the vSphere server.",
+				ValidateFunc: validation.StringInSlice([]string{"1.2", "1.3"}, false),
+			},
 			"vcenter_server": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -88,6 +162,28 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("VSPHERE_REST_SESSION_PATH", filepath.Join(os.Getenv("HOME"), ".govmomi", "rest_sessions")),
 				Description: "The directory to save vSphere REST API sessions to",
 			},
+			"session_encryption_key": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				DefaultFunc:   schema.EnvDefaultFunc("VSPHERE_SESSION_ENCRYPTION_KEY", ""),
+				ConflictsWith: []string{"session_encryption_key_file"},
+				Description:   "The key to encrypt persisted vSphere sessions with",
+			},
+			"session_encryption_key_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("VSPHERE_SESSION_ENCRYPTION_KEY_FILE", ""),
+				ConflictsWith: []string{"session_encryption_key"},
+				Description:   "The path of a file containing the key to encrypt persisted vSphere sessions with",
+			},
+			"session_max_age": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("VSPHERE_SESSION_MAX_AGE", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The age in minutes after which persisted vSphere sessions are discarded. 0 means no limit",
+			},
 			"vim_keep_alive": {
 				Type:        schema.TypeInt,
 				Optional:    true,
@@ -100,6 +196,67 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("VSPHERE_API_TIMEOUT", 5),
 				Description: "API timeout in minutes (Default: 5)",
 			},
+			"api_retry_attempts": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("VSPHERE_API_RETRY_ATTEMPTS", 3),
+				Description:  "The maximum number of attempts for virtual machine, host and cluster task operations failing with transient SOAP faults, and for REST API calls answered with 503 Service Unavailable, including the first attempt (Default: 3)",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"api_retry_backoff": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("VSPHERE_API_RETRY_BACKOFF", 2),
+				Description:  "The wait in seconds before the first retry of an operation failing with a transient fault, doubled for each subsequent retry (Default: 2)",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"max_concurrent_tasks": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("VSPHERE_MAX_CONCURRENT_TASKS", 0),
+				Description:  "The maximum number of virtual machine tasks the provider runs concurrently. 0 is unlimited (Default: 0)",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"max_concurrent_clone_tasks": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("VSPHERE_MAX_CONCURRENT_CLONE_TASKS", 0),
+				Description:  "The maximum number of virtual machine clone and content library deploy tasks the provider runs concurrently. 0 is unlimited (Default: 0)",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"max_concurrent_relocate_tasks": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("VSPHERE_MAX_CONCURRENT_RELOCATE_TASKS", 0),
+				Description:  "The maximum number of virtual machine relocate tasks the provider runs concurrently. 0 is unlimited (Default: 0)",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"max_concurrent_reconfigure_tasks": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("VSPHERE_MAX_CONCURRENT_RECONFIGURE_TASKS", 0),
+				Description:  "The maximum number of virtual machine reconfigure tasks the provider runs concurrently. 0 is unlimited (Default: 0)",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"max_concurrent_ovf_import_tasks": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("VSPHERE_MAX_CONCURRENT_OVF_IMPORT_TASKS", 0),
+				Description:  "The maximum number of OVF and OVA import tasks the provider runs concurrently. 0 is unlimited (Default: 0)",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"default_tags": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "A list of tag IDs to apply to every resource that supports tags. A tag in the tags of a resource replaces the default tags in the same category",
+			},
+			"default_custom_attributes": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "A map of custom attribute IDs to values to set on every resource that supports custom attributes. A value in the custom_attributes of a resource replaces the default value, and an empty value removes it",
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
@@ -112,6 +269,7 @@ func Provider() *schema.Provider {
 			"vsphere_compute_cluster_vm_host_rule":             resourceVSphereComputeClusterVMHostRule(),
 			"vsphere_content_library":                          resourceVSphereContentLibrary(),
 			"vsphere_content_library_item":                     resourceVSphereContentLibraryItem(),
+			"vsphere_content_library_item_download":            resourceVSphereContentLibraryItemDownload(),
 			"vsphere_custom_attribute":                         resourceVSphereCustomAttribute(),
 			"vsphere_datacenter":                               resourceVSphereDatacenter(),
 			"vsphere_datastore_cluster":                        resourceVSphereDatastoreCluster(),
@@ -127,7 +285,18 @@ func Provider() *schema.Provider {
 			"vsphere_guest_os_customization":                   resourceVSphereGuestOsCustomization(),
 			"vsphere_ha_vm_override":                           resourceVSphereHAVMOverride(),
 			"vsphere_host":                                     resourceVsphereHost(),
+			"vsphere_host_advanced_settings":                   resourceVSphereHostAdvancedSettings(),
+			"vsphere_host_certificate":                         resourceVSphereHostCertificate(),
+			"vsphere_host_firewall_ruleset":                    resourceVSphereHostFirewallRuleset(),
+			"vsphere_host_iscsi_adapter":                       resourceVSphereHostIscsiAdapter(),
+			"vsphere_host_iscsi_target":                        resourceVSphereHostIscsiTarget(),
+			"vsphere_host_logging":                             resourceVSphereHostLogging(),
+			"vsphere_host_multipath_policy":                    resourceVSphereHostMultipathPolicy(),
+			"vsphere_host_network_config":                      resourceVSphereHostNetworkConfig(),
+			"vsphere_host_pci_passthrough":                     resourceVSphereHostPciPassthrough(),
+			"vsphere_host_permission":                          resourceVSphereHostPermission(),
 			"vsphere_host_port_group":                          resourceVSphereHostPortGroup(),
+			"vsphere_host_user":                                resourceVSphereHostUser(),
 			"vsphere_host_virtual_switch":                      resourceVSphereHostVirtualSwitch(),
 			"vsphere_license":                                  resourceVSphereLicense(),
 			"vsphere_nas_datastore":                            resourceVSphereNasDatastore(),
@@ -145,6 +314,7 @@ func Provider() *schema.Provider {
 			"vsphere_virtual_machine_class":                    resourceVsphereVMClass(),
 			"vsphere_virtual_machine_snapshot":                 resourceVSphereVirtualMachineSnapshot(),
 			"vsphere_vm_storage_policy":                        resourceVMStoragePolicy(),
+			"vsphere_vm_template_checkout":                     resourceVSphereVMTemplateCheckout(),
 			"vsphere_vmfs_datastore":                           resourceVSphereVmfsDatastore(),
 			"vsphere_vnic":                                     resourceVsphereNic(),
 		},
@@ -165,6 +335,8 @@ func Provider() *schema.Provider {
 			"vsphere_guest_os_customization":     dataSourceVSphereGuestOSCustomization(),
 			"vsphere_host":                       dataSourceVSphereHost(),
 			"vsphere_host_base_images":           dataSourceVSphereHostBaseImages(),
+			"vsphere_host_firewall_rulesets":     dataSourceVSphereHostFirewallRulesets(),
+			"vsphere_host_multipath_paths":       dataSourceVSphereHostMultipathPaths(),
 			"vsphere_host_pci_device":            dataSourceVSphereHostPciDevice(),
 			"vsphere_host_thumbprint":            dataSourceVSphereHostThumbprint(),
 			"vsphere_host_vgpu_profile":          dataSourceVSphereHostVGpuProfile(),
@@ -181,8 +353,21 @@ func Provider() *schema.Provider {
 			"vsphere_vmfs_disks":                 dataSourceVSphereVmfsDisks(),
 		},
 
-		ConfigureFunc: providerConfigure,
+		ConfigureContextFunc: providerConfigureContext,
+	}
+	logResourceOperations(p.ResourcesMap)
+	return p
+}
+
+// providerConfigureContext sets up the provider loggers from the logger
+// carried by ctx before configuring the provider.
+func providerConfigureContext(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	logging.Configure(ctx)
+	client, err := providerConfigure(d)
+	if err != nil {
+		return nil, diag.FromErr(err)
 	}
+	return client, nil
 }
 
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
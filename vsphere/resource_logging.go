@@ -0,0 +1,101 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// logResourceOperations wraps the create, read, update and delete functions of
+// resources so that each operation is logged with the resource type and ID,
+// its duration and its outcome.
+//
+// Terraform does not send the address of a resource, such as
+// vsphere_virtual_machine.web, to providers, so the resource type and ID
+// identify the resource instead.
+//
+// Functions without a context are wrapped as their WithoutTimeout
+// counterparts, as they do not expect the SDK to enforce the operation
+// timeout.
+func logResourceOperations(resources map[string]*schema.Resource) {
+	for name, r := range resources {
+		switch {
+		case r.Create != nil:
+			r.CreateWithoutTimeout = logResourceOperation(name, "create", withoutContext(r.Create))
+			r.Create = nil
+		case r.CreateContext != nil:
+			r.CreateContext = logResourceOperation(name, "create", r.CreateContext)
+		}
+		switch {
+		case r.Read != nil:
+			r.ReadWithoutTimeout = logResourceOperation(name, "read", withoutContext(r.Read))
+			r.Read = nil
+		case r.ReadContext != nil:
+			r.ReadContext = logResourceOperation(name, "read", r.ReadContext)
+		}
+		switch {
+		case r.Update != nil:
+			r.UpdateWithoutTimeout = logResourceOperation(name, "update", withoutContext(r.Update))
+			r.Update = nil
+		case r.UpdateContext != nil:
+			r.UpdateContext = logResourceOperation(name, "update", r.UpdateContext)
+		}
+		switch {
+		case r.Delete != nil:
+			r.DeleteWithoutTimeout = logResourceOperation(name, "delete", withoutContext(r.Delete))
+			r.Delete = nil
+		case r.DeleteContext != nil:
+			r.DeleteContext = logResourceOperation(name, "delete", r.DeleteContext)
+		}
+	}
+}
+
+func withoutContext(f func(*schema.ResourceData, interface{}) error) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		return diag.FromErr(f(d, meta))
+	}
+}
+
+func logResourceOperation(
+	name string,
+	operation string,
+	f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics,
+) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		ctx = tflog.SetField(ctx, "resource_type", name)
+		ctx = tflog.SetField(ctx, "operation", operation)
+		if d.Id() != "" {
+			ctx = tflog.SetField(ctx, "resource_id", d.Id())
+		}
+		tflog.Debug(ctx, "Resource operation started")
+
+		start := time.Now()
+		diags := f(ctx, d, meta)
+
+		fields := map[string]interface{}{
+			"duration": time.Since(start).String(),
+		}
+		if d.Id() != "" {
+			fields["resource_id"] = d.Id()
+		}
+		if diags.HasError() {
+			for _, diagnostic := range diags {
+				if diagnostic.Severity == diag.Error {
+					fields["error"] = diagnostic.Summary
+					break
+				}
+			}
+			tflog.Info(ctx, "Resource operation failed", fields)
+			return diags
+		}
+		tflog.Debug(ctx, "Resource operation complete", fields)
+		return diags
+	}
+}
@@ -0,0 +1,69 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestLogResourceOperations(t *testing.T) {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+		Create: func(d *schema.ResourceData, _ interface{}) error {
+			d.SetId("created")
+			return nil
+		},
+		Read: func(_ *schema.ResourceData, _ interface{}) error {
+			return errors.New("read failed")
+		},
+		Update: func(_ *schema.ResourceData, _ interface{}) error {
+			return nil
+		},
+		DeleteContext: func(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+			d.SetId("")
+			return nil
+		},
+	}
+	logResourceOperations(map[string]*schema.Resource{"vsphere_test": r})
+
+	if r.Create != nil || r.Read != nil || r.Update != nil {
+		t.Fatal("expected functions without a context to be replaced")
+	}
+	if r.CreateWithoutTimeout == nil || r.ReadWithoutTimeout == nil || r.UpdateWithoutTimeout == nil || r.DeleteContext == nil {
+		t.Fatal("expected all operations to be wrapped")
+	}
+	if err := r.InternalValidate(nil, true); err != nil {
+		t.Fatalf("wrapped resource is invalid: %s", err)
+	}
+
+	ctx := context.Background()
+	d := r.TestResourceData()
+	if diags := r.CreateWithoutTimeout(ctx, d, nil); diags.HasError() {
+		t.Fatalf("unexpected create error: %v", diags)
+	}
+	if d.Id() != "created" {
+		t.Fatalf("expected ID %q, got %q", "created", d.Id())
+	}
+	diags := r.ReadWithoutTimeout(ctx, d, nil)
+	if !diags.HasError() || diags[0].Summary != "read failed" {
+		t.Fatalf("expected read error to be returned, got %v", diags)
+	}
+	if diags := r.DeleteContext(ctx, d, nil); diags.HasError() {
+		t.Fatalf("unexpected delete error: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Fatalf("expected ID to be cleared, got %q", d.Id())
+	}
+}
@@ -0,0 +1,55 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceTimeouts returns the timeouts block of long-running resources,
+// supporting the supplied operation keys.
+//
+// The defaults are left at 0 so that resourceTimeout can tell whether a
+// timeout was configured, as ResourceData.Timeout does not expose it
+// otherwise. Operations without a configured timeout keep using the timeout
+// they used before the timeouts block was supported, usually the provider's
+// api_timeout.
+func resourceTimeouts(keys ...string) *schema.ResourceTimeout {
+	t := &schema.ResourceTimeout{}
+	for _, key := range keys {
+		switch key {
+		case schema.TimeoutCreate:
+			t.Create = schema.DefaultTimeout(time.Duration(0))
+		case schema.TimeoutRead:
+			t.Read = schema.DefaultTimeout(time.Duration(0))
+		case schema.TimeoutUpdate:
+			t.Update = schema.DefaultTimeout(time.Duration(0))
+		case schema.TimeoutDelete:
+			t.Delete = schema.DefaultTimeout(time.Duration(0))
+		}
+	}
+	return t
+}
+
+// resourceTimeout returns the timeout configured for the operation key in the
+// timeouts block of the resource, or fallback if none is configured.
+func resourceTimeout(d *schema.ResourceData, key string, fallback time.Duration) time.Duration {
+	if t := d.Timeout(key); t > 0 {
+		return t
+	}
+	return fallback
+}
+
+// resourceCreateOrUpdateTimeout is resourceTimeout for steps shared by the
+// create and update operations of a resource, returning the timeout of the
+// operation in progress.
+func resourceCreateOrUpdateTimeout(d *schema.ResourceData, fallback time.Duration) time.Duration {
+	if d.IsNewResource() {
+		return resourceTimeout(d, schema.TimeoutCreate, fallback)
+	}
+	return resourceTimeout(d, schema.TimeoutUpdate, fallback)
+}
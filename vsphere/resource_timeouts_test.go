@@ -0,0 +1,35 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceTimeout(t *testing.T) {
+	r := &schema.Resource{
+		Schema:   map[string]*schema.Schema{},
+		Timeouts: resourceTimeouts(schema.TimeoutCreate, schema.TimeoutDelete),
+	}
+	// Simulate a timeouts block that only configures create.
+	r.Timeouts.Create = schema.DefaultTimeout(45 * time.Minute)
+	d := r.Data(nil)
+
+	cases := []struct {
+		key      string
+		expected time.Duration
+	}{
+		{key: schema.TimeoutCreate, expected: 45 * time.Minute},
+		{key: schema.TimeoutDelete, expected: time.Minute},
+	}
+	for _, tc := range cases {
+		if actual := resourceTimeout(d, tc.key, time.Minute); actual != tc.expected {
+			t.Fatalf("%s: expected %s, got %s", tc.key, tc.expected, actual)
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"log"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -134,13 +135,15 @@ var clusterInfraUpdateHaConfigInfoRemediationTypeAllowedValues = []string{
 
 func resourceVSphereComputeCluster() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceVSphereComputeClusterCreate,
-		Read:   resourceVSphereComputeClusterRead,
-		Update: resourceVSphereComputeClusterUpdate,
-		Delete: resourceVSphereComputeClusterDelete,
+		Create:        resourceVSphereComputeClusterCreate,
+		Read:          resourceVSphereComputeClusterRead,
+		Update:        resourceVSphereComputeClusterUpdate,
+		Delete:        resourceVSphereComputeClusterDelete,
+		CustomizeDiff: tagsAndCustomAttributesCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			State: resourceVSphereComputeClusterImport,
 		},
+		Timeouts: resourceTimeouts(schema.TimeoutCreate, schema.TimeoutUpdate, schema.TimeoutDelete),
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -847,7 +850,7 @@ func resourceVSphereComputeClusterDelete(d *schema.ResourceData, meta interface{
 			_ = v
 			log.Printf("[DEBUG] if Admission Control Policy set to Failover Host than turn HA OFF before removing hosts")
 			spec.DasConfig.Enabled = structure.BoolPtr(false)
-			if err := clustercomputeresource.Reconfigure(cluster, spec); err != nil {
+			if err := clustercomputeresource.ReconfigureWithTimeout(cluster, spec, resourceTimeout(d, schema.TimeoutDelete, provider.DefaultAPITimeout)); err != nil {
 				return err
 			}
 		}
@@ -865,7 +868,7 @@ func resourceVSphereComputeClusterDelete(d *schema.ResourceData, meta interface{
 		return err
 	}
 
-	if err := resourceVSphereComputeClusterApplyDelete(d, cluster); err != nil {
+	if err := resourceVSphereComputeClusterApplyDelete(d, cluster, resourceTimeout(d, schema.TimeoutDelete, provider.DefaultAPITimeout)); err != nil {
 		return err
 	}
 
@@ -1018,7 +1021,7 @@ func resourceVSphereComputeClusterProcessHostUpdate(
 				return fmt.Errorf("while fetching properties for host %q: %s", hs.Reference().Value, err)
 			}
 			if hsProps.Runtime.InMaintenanceMode {
-				err := hostsystem.ExitMaintenanceMode(hs, provider.DefaultAPITimeout)
+				err := hostsystem.ExitMaintenanceMode(hs, resourceCreateOrUpdateTimeout(d, provider.DefaultAPITimeout))
 				if err != nil {
 					return fmt.Errorf("while getting host %q out of maintenance mode: %s", hs.Reference().Value, err)
 				}
@@ -1083,7 +1086,7 @@ func resourceVSphereComputeClusterApplyClusterConfiguration(
 
 	// Note that the reconfigure for a cluster is the same as a standalone host,
 	// hence we send this to the computeresource helper's Reconfigure function.
-	return clustercomputeresource.Reconfigure(cluster, spec)
+	return clustercomputeresource.ReconfigureWithTimeout(cluster, spec, resourceCreateOrUpdateTimeout(d, provider.DefaultAPITimeout))
 }
 
 // resourceVSphereComputeClusterApplyTags processes the tags step for both
@@ -1101,7 +1104,7 @@ func resourceVSphereComputeClusterApplyTags(d *schema.ResourceData, meta interfa
 	}
 
 	log.Printf("[DEBUG] %s: Applying any pending tags", resourceVSphereComputeClusterIDString(d))
-	return processTagDiff(tagsClient, d, cluster)
+	return processTagDiff(tagsClient, d, meta, cluster)
 }
 
 // resourceVSphereComputeClusterReadTags reads the tags for
@@ -1127,7 +1130,7 @@ func resourceVSphereComputeClusterApplyCustomAttributes(
 ) error {
 	client := meta.(*Client).vimClient
 	// Verify a proper vCenter before proceeding if custom attributes are defined
-	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d)
+	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d, meta.(*Client).defaultCustomAttributes)
 	if err != nil {
 		return err
 	}
@@ -1527,7 +1530,7 @@ func resourceVSphereComputeClusterDeleteProcessForceRemoveFaultDomain(
 	for _, fd := range spec.VsanHostConfigSpec {
 		fd.FaultDomainInfo.Name = ""
 	}
-	return clustercomputeresource.Reconfigure(cluster, spec)
+	return clustercomputeresource.ReconfigureWithTimeout(cluster, spec, resourceTimeout(d, schema.TimeoutDelete, provider.DefaultAPITimeout))
 }
 
 // resourceVSphereComputeClusterDeleteProcessForceRemoveVsanRemoteDatastore process
@@ -1565,9 +1568,9 @@ func resourceVSphereComputeClusterDeleteProcessForceRemoveVsanRemoteDatastore(
 
 // resourceVSphereComputeClusterApplyDelete process the removal of a
 // cluster.
-func resourceVSphereComputeClusterApplyDelete(d structure.ResourceIDStringer, cluster *object.ClusterComputeResource) error {
+func resourceVSphereComputeClusterApplyDelete(d structure.ResourceIDStringer, cluster *object.ClusterComputeResource, timeout time.Duration) error {
 	log.Printf("[DEBUG] %s: Proceeding with cluster deletion", resourceVSphereComputeClusterIDString(d))
-	return clustercomputeresource.Delete(cluster)
+	return clustercomputeresource.Delete(cluster, timeout)
 }
 
 // resourceVSphereComputeClusterFlattenData saves the configuration attributes
@@ -2032,11 +2035,12 @@ func updateVsanDisks(d *schema.ResourceData, cluster *object.ClusterComputeResou
 		return err
 	}
 
+	timeout := resourceCreateOrUpdateTimeout(d, defaultAPITimeout)
 	for _, host := range hosts {
-		if err = deleteVsanDisks(host, delSet, client); err != nil {
+		if err = deleteVsanDisks(host, delSet, client, timeout); err != nil {
 			return err
 		}
-		if err = addVsanDisks(host, addSet, client); err != nil {
+		if err = addVsanDisks(host, addSet, client, timeout); err != nil {
 			return err
 		}
 	}
@@ -2083,7 +2087,7 @@ func generateDiskMap(client *govmomi.Client, host *object.HostSystem, list []int
 	return &diskMap, nil
 }
 
-func deleteVsanDisks(host *object.HostSystem, list []interface{}, client *govmomi.Client) error {
+func deleteVsanDisks(host *object.HostSystem, list []interface{}, client *govmomi.Client, timeout time.Duration) error {
 	if len(list) == 0 {
 		return nil
 	}
@@ -2098,7 +2102,7 @@ func deleteVsanDisks(host *object.HostSystem, list []interface{}, client *govmom
 	}
 	if diskMap.Ssd.CanonicalName != "" || len(diskMap.NonSsd) > 0 {
 		log.Printf("deleteVsanDisks: Scheduled disks are being removed.")
-		if err = vsansystem.RemoveDiskMapping(client, host, hvs, diskMap, defaultAPITimeout); err != nil {
+		if err = vsansystem.RemoveDiskMapping(client, host, hvs, diskMap, timeout); err != nil {
 			return err
 		}
 		log.Printf("deleteVsanDisks: vSAN disks successfully removed.")
@@ -2108,7 +2112,7 @@ func deleteVsanDisks(host *object.HostSystem, list []interface{}, client *govmom
 	return nil
 }
 
-func addVsanDisks(host *object.HostSystem, list []interface{}, client *govmomi.Client) error {
+func addVsanDisks(host *object.HostSystem, list []interface{}, client *govmomi.Client, timeout time.Duration) error {
 	if len(list) == 0 {
 		return nil
 	}
@@ -2123,7 +2127,7 @@ func addVsanDisks(host *object.HostSystem, list []interface{}, client *govmomi.C
 	}
 	if diskMap.Ssd.CanonicalName != "" {
 		log.Printf("addVsanDisks: Scheduled disks are being initialized.")
-		if err = vsansystem.InitializeDisks(client, host, hvs, diskMap, defaultAPITimeout); err != nil {
+		if err = vsansystem.InitializeDisks(client, host, hvs, diskMap, timeout); err != nil {
 			return err
 		}
 		log.Printf("addVsanDisks: vSAN disks successfully initialized.")
@@ -5,21 +5,30 @@
 package vsphere
 
 import (
+	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/contentlibrary"
 )
 
+// contentLibrarySyncTimeout is the time allowed for a subscribed content
+// library, or an item in it, to synchronize when no timeout is configured for
+// the operation.
+const contentLibrarySyncTimeout = 30 * time.Minute
+
 func resourceVSphereContentLibrary() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceVSphereContentLibraryCreate,
 		Delete: resourceVSphereContentLibraryDelete,
 		Read:   resourceVSphereContentLibraryRead,
+		Update: resourceVSphereContentLibraryUpdate,
 		Importer: &schema.ResourceImporter{
 			State: resourceVSphereContentLibraryImport,
 		},
+		Timeouts: resourceTimeouts(schema.TimeoutCreate, schema.TimeoutUpdate),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
@@ -128,6 +137,55 @@ func resourceVSphereContentLibrary() *schema.Resource {
 				},
 				},
 			},
+			"publication_password_wo": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				WriteOnly:     true,
+				Description:   "The password to publish the content library with, as a write-only value that is not stored in state.",
+				ConflictsWith: []string{"publication.0.password"},
+				RequiredWith:  []string{"publication"},
+			},
+			"publication_password_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Description:  "The version of publication_password_wo. Changing the version recreates the content library with the current value of publication_password_wo.",
+				RequiredWith: []string{"publication_password_wo"},
+			},
+			"subscription_password_wo": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				WriteOnly:     true,
+				Description:   "The password to authenticate with the publisher of a subscribed content library, as a write-only value that is not stored in state.",
+				ConflictsWith: []string{"subscription.0.password"},
+				RequiredWith:  []string{"subscription"},
+			},
+			"subscription_password_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The version of subscription_password_wo. Changing the version updates the subscription with the current value of subscription_password_wo.",
+				RequiredWith: []string{"subscription_password_wo"},
+			},
+			"sync_trigger": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "An arbitrary value that, when changed, synchronizes a subscribed content library with its publisher.",
+				RequiredWith: []string{"subscription"},
+			},
+			"sync_items": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Description:  "The names of items in a subscribed content library whose content is downloaded whenever the library is synchronized.",
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				RequiredWith: []string{"subscription"},
+			},
+			"last_sync_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date and time when a subscribed content library was last synchronized.",
+			},
 		},
 	}
 }
@@ -155,6 +213,9 @@ func resourceVSphereContentLibraryRead(d *schema.ResourceData, meta interface{})
 	}
 	_ = d.Set("name", lib.Name)
 	_ = d.Set("description", lib.Description)
+	if lib.LastSyncTime != nil {
+		_ = d.Set("last_sync_time", lib.LastSyncTime.Format(time.RFC3339))
+	}
 	log.Printf("[DEBUG] resourceVSphereContentLibraryRead : Content Library (%s) read is complete", d.Id())
 	return nil
 }
@@ -172,10 +233,62 @@ func resourceVSphereContentLibraryCreate(d *schema.ResourceData, meta interface{
 		return err
 	}
 	d.SetId(id)
+	_, syncTrigger := d.GetOk("sync_trigger")
+	_, syncItems := d.GetOk("sync_items")
+	if syncTrigger || syncItems {
+		if err := resourceVSphereContentLibrarySync(d, meta); err != nil {
+			return err
+		}
+	}
 	log.Printf("[DEBUG] resourceVSphereContentLibraryCreate : Content Library (%s) creation is complete", d.Get("name").(string))
 	return resourceVSphereContentLibraryRead(d, meta)
 }
 
+func resourceVSphereContentLibraryUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] resourceVSphereContentLibraryUpdate : Updating Content Library (%s)", d.Id())
+	if d.HasChange("subscription_password_wo_version") {
+		c := meta.(*Client).restClient
+		if err := contentlibrary.UpdateSubscription(c, d.Id(), contentlibrary.ExpandSubscription(d)); err != nil {
+			return err
+		}
+	}
+	if d.HasChanges("sync_trigger", "sync_items") {
+		if err := resourceVSphereContentLibrarySync(d, meta); err != nil {
+			return err
+		}
+	}
+	log.Printf("[DEBUG] resourceVSphereContentLibraryUpdate : Content Library (%s) update is complete", d.Id())
+	return resourceVSphereContentLibraryRead(d, meta)
+}
+
+// resourceVSphereContentLibrarySync synchronizes a subscribed content library
+// and downloads the content of each item in sync_items, waiting for both to
+// complete.
+func resourceVSphereContentLibrarySync(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*Client).restClient
+	timeout := resourceCreateOrUpdateTimeout(d, contentLibrarySyncTimeout)
+	lib, err := contentlibrary.FromID(c, d.Id())
+	if err != nil {
+		return err
+	}
+	if lib.Subscription == nil {
+		return fmt.Errorf("content library %s is not a subscribed library and cannot be synchronized", d.Id())
+	}
+	if err := contentlibrary.SyncLibrary(c, lib, timeout); err != nil {
+		return err
+	}
+	for _, name := range d.Get("sync_items").(*schema.Set).List() {
+		item, err := contentlibrary.ItemFromName(c, lib, name.(string))
+		if err != nil {
+			return err
+		}
+		if err := contentlibrary.SyncLibraryItem(c, item, true, timeout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func resourceVSphereContentLibraryDelete(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[DEBUG] resourceVSphereContentLibraryDelete : Deleting Content Library (%s)", d.Id())
 	c := meta.(*Client).restClient
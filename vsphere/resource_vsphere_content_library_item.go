@@ -5,28 +5,43 @@
 package vsphere
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/contentlibrary"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/folder"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/structure"
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/virtualmachine"
 )
 
+// contentLibraryItemSnapshotCloneTimeout is the time allowed to create the
+// linked clone that a snapshot is published from when no timeout is configured
+// for the operation.
+const contentLibraryItemSnapshotCloneTimeout = 30 * time.Minute
+
 func resourceVSphereContentLibraryItem() *schema.Resource {
 	return &schema.Resource{
 		Create:        resourceVSphereContentLibraryItemCreate,
 		Delete:        resourceVSphereContentLibraryItemDelete,
 		Read:          resourceVSphereContentLibraryItemRead,
+		Update:        resourceVSphereContentLibraryItemUpdate,
+		CustomizeDiff: resourceVSphereContentLibraryItemCustomizeDiff,
 		SchemaVersion: 1,
 		Importer: &schema.ResourceImporter{
 			State: resourceVSphereContentLibraryItemImport,
 		},
+		Timeouts: resourceTimeouts(schema.TimeoutCreate, schema.TimeoutUpdate),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 				Description: "The name of the content library item.",
 			},
 			"library_id": {
@@ -38,16 +53,26 @@ func resourceVSphereContentLibraryItem() *schema.Resource {
 			"description": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				ForceNew:    true,
 				Description: "Optional description of the content library item.",
 			},
 			"file_url": {
 				Type:          schema.TypeString,
 				Optional:      true,
-				ForceNew:      true,
-				Description:   "ID of source VM of content library item.",
+				Description:   "File to import as the content library item. Changes upload new content to the existing item.",
 				ConflictsWith: []string{"source_uuid"},
 			},
+			"file_checksum": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				Description:   "The SHA-256 checksum of the content at file_url. Computed for local files and verified before local files are uploaded, and by vCenter for remote OVF files.",
+				ConflictsWith: []string{"source_uuid"},
+			},
+			"file_stat": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The size and modification time of the local file at file_url when its content was last uploaded.",
+			},
 			"type": {
 				Type:        schema.TypeString,
 				Default:     "ovf",
@@ -56,12 +81,103 @@ func resourceVSphereContentLibraryItem() *schema.Resource {
 				Description: "Type of content library item.",
 			},
 			"source_uuid": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The UUID of an existing VM to be published to the content library.",
+				ConflictsWith: []string{"file_url"},
+			},
+			"source_snapshot_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The managed object ID of a snapshot of the VM referenced by source_uuid to publish, instead of the current state of the VM.",
+				RequiredWith:  []string{"source_uuid"},
+				ConflictsWith: []string{"file_url"},
+			},
+			"republish_trigger": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "An arbitrary value that, when changed, republishes the item from the VM referenced by source_uuid.",
+				ConflictsWith: []string{"file_url"},
+			},
+			"ovf_flags": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Description:   "Flags controlling the export of the VM referenced by source_uuid to an ovf item, such as EXTRA_CONFIG or PRESERVE_MAC.",
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				RequiredWith:  []string{"source_uuid"},
+				ConflictsWith: []string{"file_url"},
+			},
+			"target_datastore_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "The managed object ID of the datastore to store a vm-template item on.",
+				ConflictsWith: []string{"file_url"},
+			},
+			"target_folder_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "The managed object ID of the folder to place a vm-template item in.",
+				ConflictsWith: []string{"file_url"},
+			},
+			"target_host_system_id": {
 				Type:          schema.TypeString,
 				Optional:      true,
 				ForceNew:      true,
-				Description:   "The managed object ID of an existing VM to be cloned to the content library.",
+				Description:   "The managed object ID of the host to place a vm-template item on.",
 				ConflictsWith: []string{"file_url"},
 			},
+			"target_resource_pool_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "The managed object ID of the resource pool to place a vm-template item in.",
+				ConflictsWith: []string{"file_url"},
+			},
+			"storage_policy_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "The ID of the storage policy to apply to a vm-template item.",
+				ConflictsWith: []string{"file_url"},
+			},
+			"sync_trigger": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "An arbitrary value that, when changed, synchronizes an item in a subscribed content library with its publisher and downloads its content.",
+				ConflictsWith: []string{"file_url", "source_uuid"},
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The version of the content library item, incremented on any change to the item.",
+			},
+			"content_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The version of the content library item files, incremented when new content is uploaded.",
+			},
+			"cached": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the content of the item is stored locally. Items in a subscribed library may not be cached until synchronized.",
+			},
+			"size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The size, in bytes, of the content library item files stored locally.",
+			},
+			"last_sync_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date and time when an item in a subscribed library was last synchronized.",
+			},
+			"last_modified_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date and time when the content library item was last updated.",
+			},
 		},
 	}
 }
@@ -81,6 +197,16 @@ func resourceVSphereContentLibraryItemRead(d *schema.ResourceData, meta interfac
 	_ = d.Set("description", item.Description)
 	_ = d.Set("type", item.Type)
 	_ = d.Set("library_id", item.LibraryID)
+	_ = d.Set("version", item.Version)
+	_ = d.Set("content_version", item.ContentVersion)
+	if item.LastModifiedTime != nil {
+		_ = d.Set("last_modified_time", item.LastModifiedTime.Format(time.RFC3339))
+	}
+	_ = d.Set("cached", item.Cached)
+	_ = d.Set("size", item.Size)
+	if item.LastSyncTime != nil {
+		_ = d.Set("last_sync_time", item.LastSyncTime.Format(time.RFC3339))
+	}
 	log.Printf("[DEBUG] resourceVSphereContentLibraryItemRead : Content Library item (%s) read is complete", d.Id())
 	return nil
 }
@@ -92,14 +218,19 @@ func resourceVSphereContentLibraryItemCreate(d *schema.ResourceData, meta interf
 	if err != nil {
 		return err
 	}
-	var moid virtualmachine.MOIDForUUIDResult
-	if uuid, ok := d.GetOk("source_uuid"); ok {
-		moid, err = virtualmachine.MOIDForUUID(meta.(*Client).vimClient, uuid.(string))
+	var publish *contentlibrary.PublishSpec
+	if _, ok := d.GetOk("source_uuid"); ok {
+		publish, err = expandContentLibraryItemPublishSpec(d, meta)
+		if err != nil {
+			return err
+		}
+		cleanup, err := resourceVSphereContentLibraryItemCloneSnapshot(d, meta, publish)
 		if err != nil {
 			return err
 		}
+		defer cleanup()
 	}
-	id, err := contentlibrary.CreateLibraryItem(rc, lib, d.Get("name").(string), d.Get("description").(string), d.Get("type").(string), d.Get("file_url").(string), moid.MOID)
+	id, err := contentlibrary.CreateLibraryItem(rc, meta.(*Client).httpConfig, lib, d.Get("name").(string), d.Get("description").(string), d.Get("type").(string), d.Get("file_url").(string), d.Get("file_checksum").(string), publish, resourceTimeout(d, schema.TimeoutCreate, 0))
 	if err != nil {
 		return err
 	}
@@ -108,6 +239,59 @@ func resourceVSphereContentLibraryItemCreate(d *schema.ResourceData, meta interf
 	return resourceVSphereContentLibraryItemRead(d, meta)
 }
 
+func resourceVSphereContentLibraryItemUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] resourceVSphereContentLibraryItemUpdate : Updating Content Library item (%s)", d.Id())
+	rc := meta.(*Client).restClient
+	item, err := contentlibrary.ItemFromID(rc, d.Id())
+	if err != nil {
+		return err
+	}
+	if d.HasChanges("name", "description") {
+		if err := contentlibrary.UpdateLibraryItem(rc, item, d.Get("name").(string), d.Get("description").(string)); err != nil {
+			return err
+		}
+	}
+	if d.HasChanges("file_url", "file_checksum") {
+		file := d.Get("file_url").(string)
+		if file == "" {
+			return fmt.Errorf("file_url cannot be removed from an existing content library item")
+		}
+		if err := contentlibrary.UpdateLibraryItemContent(rc, meta.(*Client).httpConfig, item, file, d.Get("file_checksum").(string), resourceTimeout(d, schema.TimeoutUpdate, 0)); err != nil {
+			return err
+		}
+	}
+	if d.HasChanges("source_uuid", "source_snapshot_id", "republish_trigger", "ovf_flags") {
+		if _, ok := d.GetOk("source_uuid"); ok {
+			publish, err := expandContentLibraryItemPublishSpec(d, meta)
+			if err != nil {
+				return err
+			}
+			cleanup, err := resourceVSphereContentLibraryItemCloneSnapshot(d, meta, publish)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+			if err := contentlibrary.RepublishLibraryItem(rc, item, d.Get("description").(string), publish, resourceTimeout(d, schema.TimeoutUpdate, 0)); err != nil {
+				return err
+			}
+		}
+	}
+	if d.HasChange("sync_trigger") && d.Get("sync_trigger").(string) != "" {
+		lib, err := contentlibrary.FromID(rc, item.LibraryID)
+		if err != nil {
+			return err
+		}
+		if lib.Subscription == nil {
+			return fmt.Errorf("content library item %s is not in a subscribed library and cannot be synchronized", d.Id())
+		}
+		if err := contentlibrary.SyncLibraryItem(rc, item, true, resourceTimeout(d, schema.TimeoutUpdate, contentLibrarySyncTimeout)); err != nil {
+			return err
+		}
+	}
+	log.Printf("[DEBUG] resourceVSphereContentLibraryItemUpdate : Content Library item (%s) update complete", d.Id())
+	return resourceVSphereContentLibraryItemRead(d, meta)
+}
+
 func resourceVSphereContentLibraryItemDelete(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[DEBUG] resourceVSphereContentLibraryItemDelete : Deleting Content Library item (%s)", d.Id())
 	rc := meta.(*Client).restClient
@@ -130,3 +314,142 @@ func resourceVSphereContentLibraryItemImport(d *schema.ResourceData, meta interf
 	}
 	return []*schema.ResourceData{d}, nil
 }
+
+func resourceVSphereContentLibraryItemCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	// Only ovf items can be republished in place. A vm-template item is
+	// replaced when it is republished, and any item is replaced when it stops
+	// being published from a virtual machine.
+	if d.Id() != "" {
+		if d.HasChange("source_uuid") && (d.Get("type").(string) == "vm-template" || d.Get("source_uuid").(string) == "") {
+			if err := d.ForceNew("source_uuid"); err != nil {
+				return err
+			}
+		}
+		for _, k := range []string{"source_snapshot_id", "republish_trigger"} {
+			if d.HasChange(k) && d.Get("type").(string) == "vm-template" {
+				if err := d.ForceNew(k); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if d.Get("type").(string) != "ovf" && d.Get("ovf_flags").(*schema.Set).Len() > 0 {
+		return fmt.Errorf("ovf_flags can only be set for ovf items")
+	}
+	// A checksum supplied in configuration is authoritative. Otherwise, the
+	// checksum of a local file is computed so that changes to the file content
+	// are detected even when file_url itself is unchanged.
+	if !d.GetRawConfig().GetAttr("file_checksum").IsNull() {
+		return nil
+	}
+	if !d.NewValueKnown("file_url") {
+		if err := d.SetNewComputed("file_stat"); err != nil {
+			return err
+		}
+		return d.SetNewComputed("file_checksum")
+	}
+	file := d.Get("file_url").(string)
+	stat, err := contentlibrary.FileStat(file)
+	if err != nil {
+		if os.IsNotExist(err) && d.Id() != "" && !d.HasChange("file_url") {
+			log.Printf("[DEBUG] resourceVSphereContentLibraryItemCustomizeDiff : Local file %s no longer exists, skipping checksum", file)
+			return nil
+		}
+		return fmt.Errorf("error reading file_url: %s", err)
+	}
+	// Hashing large OVA and ISO files on every plan is expensive, so the
+	// checksum is only computed again when the size or modification time of
+	// the file changed since its content was last uploaded.
+	if d.Id() != "" && !d.HasChange("file_url") && stat == d.Get("file_stat").(string) {
+		return nil
+	}
+	checksum, err := contentlibrary.FileChecksum(file)
+	if err != nil {
+		return fmt.Errorf("error computing checksum of file_url: %s", err)
+	}
+	// The new fingerprint is only stored along with content that is uploaded.
+	// A file that was only touched keeps its previous fingerprint, as a diff on
+	// file_stat alone would plan an update that does nothing.
+	if d.Id() != "" && !d.HasChange("file_url") && checksum == d.Get("file_checksum").(string) {
+		return nil
+	}
+	if err := d.SetNew("file_stat", stat); err != nil {
+		return err
+	}
+	return d.SetNew("file_checksum", checksum)
+}
+
+// resourceVSphereContentLibraryItemCloneSnapshot creates a temporary linked
+// clone of the source VM from the snapshot in source_snapshot_id, and points
+// the PublishSpec to it, as the VM can only be published from its current
+// state. The returned function destroys the clone once the item is published.
+func resourceVSphereContentLibraryItemCloneSnapshot(d *schema.ResourceData, meta interface{}, publish *contentlibrary.PublishSpec) (func(), error) {
+	snapshotID := d.Get("source_snapshot_id").(string)
+	if snapshotID == "" {
+		return func() {}, nil
+	}
+	client := meta.(*Client).vimClient
+	vm, err := virtualmachine.FromMOID(client, publish.SourceMOID)
+	if err != nil {
+		return nil, err
+	}
+	props, err := virtualmachine.Properties(vm)
+	if err != nil {
+		return nil, err
+	}
+	// Virtual machines in a vApp have no parent folder, so their clones are
+	// placed in the virtual machine folder of the datacenter.
+	var fo *object.Folder
+	if props.Parent != nil {
+		fo = object.NewFolder(client.Client, *props.Parent)
+	} else {
+		fo, err = folder.VirtualMachineFolderFromObject(client, vm, "")
+		if err != nil {
+			return nil, fmt.Errorf("could not find the folder of virtual machine %s: %s", publish.SourceMOID, err)
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	snapshot, err := vm.FindSnapshot(ctx, snapshotID)
+	if err != nil {
+		return nil, fmt.Errorf("error finding snapshot %s: %s", snapshotID, err)
+	}
+
+	spec := types.VirtualMachineCloneSpec{
+		Location: types.VirtualMachineRelocateSpec{
+			DiskMoveType: string(types.VirtualMachineRelocateDiskMoveOptionsCreateNewChildDiskBacking),
+		},
+		Snapshot: snapshot,
+	}
+	name := fmt.Sprintf("%s-%s", props.Name, snapshotID)
+	log.Printf("[DEBUG] resourceVSphereContentLibraryItemCloneSnapshot : Cloning snapshot %s of virtual machine (%s) to publish", snapshotID, publish.SourceMOID)
+	timeout := resourceCreateOrUpdateTimeout(d, contentLibraryItemSnapshotCloneTimeout)
+	clone, err := virtualmachine.Clone(client, vm, fo, name, spec, int((timeout+time.Minute-1)/time.Minute))
+	if err != nil {
+		return nil, fmt.Errorf("error cloning snapshot %s: %s", snapshotID, err)
+	}
+	publish.SourceMOID = clone.Reference().Value
+	return func() {
+		if err := virtualmachine.Destroy(clone, defaultAPITimeout); err != nil {
+			log.Printf("[WARN] resourceVSphereContentLibraryItemCloneSnapshot : Could not destroy temporary virtual machine (%s): %s", clone.Reference().Value, err)
+		}
+	}, nil
+}
+
+// expandContentLibraryItemPublishSpec reads the publishing options of the
+// vsphere_content_library_item resource and returns a PublishSpec.
+func expandContentLibraryItemPublishSpec(d *schema.ResourceData, meta interface{}) (*contentlibrary.PublishSpec, error) {
+	moid, err := virtualmachine.MOIDForUUID(meta.(*Client).vimClient, d.Get("source_uuid").(string))
+	if err != nil {
+		return nil, err
+	}
+	return &contentlibrary.PublishSpec{
+		SourceMOID:      moid.MOID,
+		DatastoreID:     d.Get("target_datastore_id").(string),
+		FolderID:        d.Get("target_folder_id").(string),
+		HostSystemID:    d.Get("target_host_system_id").(string),
+		ResourcePoolID:  d.Get("target_resource_pool_id").(string),
+		StoragePolicyID: d.Get("storage_policy_id").(string),
+		OvfFlags:        structure.SliceInterfacesToStrings(d.Get("ovf_flags").(*schema.Set).List()),
+	}, nil
+}
@@ -0,0 +1,178 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/contentlibrary"
+)
+
+func resourceVSphereContentLibraryItemDownload() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceVSphereContentLibraryItemDownloadCreate,
+		Read:     resourceVSphereContentLibraryItemDownloadRead,
+		Delete:   resourceVSphereContentLibraryItemDownloadDelete,
+		Timeouts: resourceTimeouts(schema.TimeoutCreate),
+		Schema: map[string]*schema.Schema{
+			"item_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the content library item to download.",
+			},
+			"destination_directory": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The local directory to write the content library item files to. The files are written to a subdirectory named after the item ID.",
+			},
+			"keep_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Leave the downloaded files in place when the resource is destroyed.",
+			},
+			"content_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The content version of the content library item that was downloaded.",
+			},
+			"file": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The files downloaded from the content library item.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the file in the content library item.",
+						},
+						"path": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The local path of the downloaded file.",
+						},
+						"size": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The size of the downloaded file in bytes.",
+						},
+						"checksum_algorithm": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The algorithm used to verify the downloaded file.",
+						},
+						"checksum": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The checksum of the downloaded file.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceVSphereContentLibraryItemDownloadCreate(d *schema.ResourceData, meta interface{}) error {
+	itemID := d.Get("item_id").(string)
+	dir := d.Get("destination_directory").(string)
+	log.Printf("[DEBUG] resourceVSphereContentLibraryItemDownloadCreate : Downloading Content Library item (%s) to %s", itemID, dir)
+	rc := meta.(*Client).restClient
+	item, err := contentlibrary.ItemFromID(rc, itemID)
+	if err != nil {
+		return err
+	}
+	files, err := contentlibrary.DownloadLibraryItem(rc, item, dir, resourceTimeout(d, schema.TimeoutCreate, 0))
+	if err != nil {
+		return err
+	}
+	d.SetId(resourceVSphereContentLibraryItemDownloadFlattenID(itemID, dir))
+	_ = d.Set("content_version", item.ContentVersion)
+	if err := d.Set("file", flattenContentLibraryItemDownloadedFiles(files)); err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] resourceVSphereContentLibraryItemDownloadCreate : Content Library item (%s) download complete", itemID)
+	return resourceVSphereContentLibraryItemDownloadRead(d, meta)
+}
+
+func resourceVSphereContentLibraryItemDownloadRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] resourceVSphereContentLibraryItemDownloadRead : Reading Content Library item download (%s)", d.Id())
+	rc := meta.(*Client).restClient
+	item, err := contentlibrary.ItemFromID(rc, d.Get("item_id").(string))
+	if err != nil {
+		if strings.Contains(err.Error(), "404 Not Found") {
+			log.Printf("[DEBUG] resourceVSphereContentLibraryItemDownloadRead : Content Library item (%s) not found, removing from state", d.Get("item_id").(string))
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	if item.ContentVersion != d.Get("content_version").(string) {
+		log.Printf("[DEBUG] resourceVSphereContentLibraryItemDownloadRead : Content Library item (%s) content has changed, removing from state", item.ID)
+		d.SetId("")
+		return nil
+	}
+	for _, f := range d.Get("file").([]interface{}) {
+		file := f.(map[string]interface{})
+		info, err := os.Stat(file["path"].(string))
+		if err != nil || info.Size() != int64(file["size"].(int)) {
+			log.Printf("[DEBUG] resourceVSphereContentLibraryItemDownloadRead : Local file %s is missing or has changed, removing from state", file["path"].(string))
+			d.SetId("")
+			return nil
+		}
+	}
+	log.Printf("[DEBUG] resourceVSphereContentLibraryItemDownloadRead : Content Library item download (%s) read is complete", d.Id())
+	return nil
+}
+
+func resourceVSphereContentLibraryItemDownloadDelete(d *schema.ResourceData, _ interface{}) error {
+	log.Printf("[DEBUG] resourceVSphereContentLibraryItemDownloadDelete : Deleting Content Library item download (%s)", d.Id())
+	if d.Get("keep_on_destroy").(bool) {
+		return nil
+	}
+	var itemDir string
+	for _, f := range d.Get("file").([]interface{}) {
+		path := f.(map[string]interface{})["path"].(string)
+		if err := os.Remove(filepath.Clean(path)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("error removing downloaded file %s: %s", path, err)
+		}
+		itemDir = filepath.Dir(path)
+	}
+	// The subdirectory of the item is only removed if nothing else was
+	// written to it.
+	if itemDir != "" {
+		_ = os.Remove(itemDir)
+	}
+	return nil
+}
+
+// resourceVSphereContentLibraryItemDownloadFlattenID makes an ID for the
+// vsphere_content_library_item_download resource.
+func resourceVSphereContentLibraryItemDownloadFlattenID(itemID, dir string) string {
+	return strings.Join([]string{itemID, dir}, ":")
+}
+
+func flattenContentLibraryItemDownloadedFiles(files []contentlibrary.DownloadedFile) []interface{} {
+	var result []interface{}
+	for _, file := range files {
+		result = append(result, map[string]interface{}{
+			"name":               file.Name,
+			"path":               file.Path,
+			"size":               int(file.Size),
+			"checksum_algorithm": file.ChecksumAlgorithm,
+			"checksum":           file.Checksum,
+		})
+	}
+	return result
+}
@@ -0,0 +1,83 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/testhelper"
+)
+
+func TestAccResourceVSphereContentLibraryItemDownload_basic(t *testing.T) {
+	testAccSkipUnstable(t)
+	dir := t.TempDir()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			RunSweepers()
+			testAccPreCheck(t)
+			testAccResourceVSphereContentLibraryItemPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereContentLibraryItemDownloadCheckFiles(dir, false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereContentLibraryItemDownloadConfig(dir),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("vsphere_content_library_item_download.download", "content_version"),
+					resource.TestCheckResourceAttrSet("vsphere_content_library_item_download.download", "file.0.checksum"),
+					testAccResourceVSphereContentLibraryItemDownloadCheckFiles(dir, true),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceVSphereContentLibraryItemDownloadCheckFiles(dir string, expected bool) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		files, err := filepath.Glob(filepath.Join(dir, "*", "*"))
+		if err != nil {
+			return err
+		}
+		switch {
+		case expected && len(files) == 0:
+			return fmt.Errorf("expected downloaded files in %s", dir)
+		case !expected && len(files) > 0:
+			return fmt.Errorf("expected downloaded files in %s to be removed, found %d", dir, len(files))
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereContentLibraryItemDownloadConfig(dir string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "vsphere_content_library" "library" {
+  name            = "testacc_content_library"
+  storage_backing = [data.vsphere_datastore.rootds1.id]
+  description     = "Library Description"
+}
+
+resource "vsphere_content_library_item" "item" {
+  name        = "testacc-item"
+  description = "TestAcc Description"
+  library_id  = vsphere_content_library.library.id
+  type        = "ovf"
+  file_url    = "%s"
+}
+
+resource "vsphere_content_library_item_download" "download" {
+  item_id               = vsphere_content_library_item.item.id
+  destination_directory = "%s"
+}
+`, testaccresourcevspherecontentlibraryitemconfigBase(),
+		testhelper.TestOva,
+		filepath.ToSlash(dir),
+	)
+}
@@ -142,6 +142,129 @@ func TestAccResourceVSphereContentLibraryItem_remoteOva(t *testing.T) {
 	})
 }
 
+func TestAccResourceVSphereContentLibraryItem_updateInPlace(t *testing.T) {
+	testAccSkipUnstable(t)
+	var itemID string
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			RunSweepers()
+			testAccPreCheck(t)
+			testAccResourceVSphereContentLibraryItemPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereContentLibraryItemCheckExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testaccresourcevspherecontentlibraryitemconfigUpdate("testacc-item", "TestAcc Description"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereContentLibraryItemSaveID(&itemID),
+					resource.TestCheckResourceAttrSet("vsphere_content_library_item.item", "version"),
+					resource.TestCheckResourceAttrSet("vsphere_content_library_item.item", "content_version"),
+				),
+			},
+			{
+				Config: testaccresourcevspherecontentlibraryitemconfigUpdate("testacc-item-updated", "TestAcc Updated Description"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereContentLibraryItemCheckID(&itemID),
+					testAccResourceVSphereContentLibraryItemDescription(regexp.MustCompile("TestAcc Updated Description")),
+					testAccResourceVSphereContentLibraryItemName(regexp.MustCompile("testacc-item-updated")),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereContentLibraryItem_updateFileURL(t *testing.T) {
+	testAccSkipUnstable(t)
+	var itemID, contentVersion string
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			RunSweepers()
+			testAccPreCheck(t)
+			testAccResourceVSphereContentLibraryItemPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereContentLibraryItemCheckExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testaccresourcevspherecontentlibraryitemconfigRemoteova(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereContentLibraryItemSaveID(&itemID),
+					resource.TestCheckResourceAttrWith("vsphere_content_library_item.item", "content_version", func(v string) error {
+						contentVersion = v
+						return nil
+					}),
+				),
+			},
+			{
+				PreConfig: testAccResourceVSphereContentLibraryItemGetOva,
+				Config:    testaccresourcevspherecontentlibraryitemconfigLocalova(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereContentLibraryItemCheckID(&itemID),
+					resource.TestCheckResourceAttrWith("vsphere_content_library_item.item", "content_version", func(v string) error {
+						if v == contentVersion {
+							return fmt.Errorf("expected content_version to change from %s", contentVersion)
+						}
+						return nil
+					}),
+					resource.TestCheckResourceAttrSet("vsphere_content_library_item.item", "file_checksum"),
+					resource.TestCheckResourceAttrSet("vsphere_content_library_item.item", "file_stat"),
+					testAccResourceVSphereContentLibraryItemDestroyFile("./testdata/test.ova"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereContentLibraryItem_vmTemplate(t *testing.T) {
+	testAccSkipUnstable(t)
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			RunSweepers()
+			testAccPreCheck(t)
+			testAccResourceVSphereContentLibraryItemPreCheck(t)
+			if os.Getenv("TF_VAR_VSPHERE_TEMPLATE") == "" {
+				t.Skip("set TF_VAR_VSPHERE_TEMPLATE to run vsphere_content_library_item vm-template acceptance tests")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereContentLibraryItemCheckExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testaccresourcevspherecontentlibraryitemconfigVMTemplate(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereContentLibraryItemType(regexp.MustCompile("vm-template")),
+					testAccResourceVSphereContentLibraryItemName(regexp.MustCompile("testacc-item")),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceVSphereContentLibraryItemSaveID(id *string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		item, err := testGetContentLibraryItem(nil, "item")
+		if err != nil {
+			return err
+		}
+		*id = item.ID
+		return nil
+	}
+}
+
+func testAccResourceVSphereContentLibraryItemCheckID(id *string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		item, err := testGetContentLibraryItem(nil, "item")
+		if err != nil {
+			return err
+		}
+		if item.ID != *id {
+			return fmt.Errorf("content library item was recreated. expected ID: %s, got %s", *id, item.ID)
+		}
+		return nil
+	}
+}
+
 func testAccResourceVSphereContentLibraryItemGetOva() {
 	_ = testAccResourceVSphereContentLibraryItemGetFile(testhelper.TestOva, "./testdata/test.ova")
 }
@@ -300,6 +423,67 @@ resource "vsphere_content_library_item" "item" {
 	)
 }
 
+func testaccresourcevspherecontentlibraryitemconfigUpdate(name, description string) string {
+	return fmt.Sprintf(`
+%s
+
+variable "file" {
+  default = "%s"
+}
+
+resource "vsphere_content_library" "library" {
+  name            = "testacc_content_library"
+  storage_backing = [data.vsphere_datastore.rootds1.id]
+  description     = "Library Description"
+}
+
+resource "vsphere_content_library_item" "item" {
+  name        = "%s"
+  description = "%s"
+  library_id  = vsphere_content_library.library.id
+  type        = "ovf"
+  file_url    = var.file
+}
+`, testaccresourcevspherecontentlibraryitemconfigBase(),
+		testhelper.TestOva,
+		name,
+		description,
+	)
+}
+
+func testaccresourcevspherecontentlibraryitemconfigVMTemplate() string {
+	return fmt.Sprintf(`
+%s
+
+variable "template" {
+  default = "%s"
+}
+
+data "vsphere_virtual_machine" "template" {
+  name          = var.template
+  datacenter_id = data.vsphere_datacenter.rootdc1.id
+}
+
+resource "vsphere_content_library" "library" {
+  name            = "testacc_content_library"
+  storage_backing = [data.vsphere_datastore.rootds1.id]
+  description     = "Library Description"
+}
+
+resource "vsphere_content_library_item" "item" {
+  name                    = "testacc-item"
+  description             = "TestAcc Description"
+  library_id              = vsphere_content_library.library.id
+  type                    = "vm-template"
+  source_uuid             = data.vsphere_virtual_machine.template.id
+  target_datastore_id     = data.vsphere_datastore.rootds1.id
+  target_resource_pool_id = data.vsphere_compute_cluster.rootcompute_cluster1.resource_pool_id
+}
+`, testaccresourcevspherecontentlibraryitemconfigBase(),
+		os.Getenv("TF_VAR_VSPHERE_TEMPLATE"),
+	)
+}
+
 func testaccresourcevspherecontentlibraryitemconfigBase() string {
 	return testhelper.CombineConfigs(testhelper.ConfigDataRootDC1(), testhelper.ConfigDataRootDS1(), testhelper.ConfigDataRootHost1(), testhelper.ConfigDataRootHost2(), testhelper.ConfigResDS1(), testhelper.ConfigDataRootComputeCluster1(), testhelper.ConfigResResourcePool1(), testhelper.ConfigDataRootPortGroup1())
 }
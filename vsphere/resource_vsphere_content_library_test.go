@@ -74,6 +74,35 @@ func TestAccResourceVSphereContentLibrary_subscribed(t *testing.T) {
 		},
 	})
 }
+
+func TestAccResourceVSphereContentLibrary_subscribedSync(t *testing.T) {
+	testAccSkipUnstable(t)
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			RunSweepers()
+			testAccPreCheck(t)
+			testAccResourceVSphereContentLibraryPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereContentLibraryCheckExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testaccresourcevspherecontentlibraryconfigSubscribedSync("1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("vsphere_content_library.library", "last_sync_time"),
+				),
+			},
+			{
+				Config: testaccresourcevspherecontentlibraryconfigSubscribedSync("2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vsphere_content_library.library", "sync_trigger", "2"),
+					resource.TestCheckResourceAttrSet("vsphere_content_library.library", "last_sync_time"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccResourceVSphereContentLibrary_authenticated(t *testing.T) {
 	testAccSkipUnstable(t)
 	resource.Test(t, resource.TestCase{
@@ -189,6 +218,31 @@ resource "vsphere_content_library" "library" {
 `, testaccresourcevspherecontentlibraryconfigBase())
 }
 
+func testaccresourcevspherecontentlibraryconfigSubscribedSync(trigger string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "vsphere_content_library" "library_published" {
+  name            = "testacc_published"
+  storage_backing = [data.vsphere_datastore.rootds1.id]
+  description     = "Library Description"
+  publication {
+    published = true
+  }
+}
+
+resource "vsphere_content_library" "library" {
+  name            = "testacc_subscribed"
+  storage_backing = [data.vsphere_datastore.rootds1.id]
+  description     = "Library Description"
+  sync_trigger    = "%s"
+  subscription {
+    subscription_url = vsphere_content_library.library_published.publication.0.publish_url
+  }
+}
+`, testaccresourcevspherecontentlibraryconfigBase(), trigger)
+}
+
 func testAccResourceVSphereContentLibraryConfig() string {
 	return fmt.Sprintf(`
 %s
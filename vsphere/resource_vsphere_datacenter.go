@@ -25,10 +25,11 @@ import (
 
 func resourceVSphereDatacenter() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceVSphereDatacenterCreate,
-		Read:   resourceVSphereDatacenterRead,
-		Update: resourceVSphereDatacenterUpdate,
-		Delete: resourceVSphereDatacenterDelete,
+		Create:        resourceVSphereDatacenterCreate,
+		Read:          resourceVSphereDatacenterRead,
+		Update:        resourceVSphereDatacenterUpdate,
+		Delete:        resourceVSphereDatacenterDelete,
+		CustomizeDiff: tagsAndCustomAttributesCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			State: resourceVSphereDatacenterImport,
 		},
@@ -70,7 +71,7 @@ func resourceVSphereDatacenterCreate(d *schema.ResourceData, meta interface{}) e
 		return err
 	}
 	// Verify a proper vCenter before proceeding if custom attributes are defined
-	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d)
+	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d, meta.(*Client).defaultCustomAttributes)
 	if err != nil {
 		return err
 	}
@@ -116,7 +117,7 @@ func resourceVSphereDatacenterCreate(d *schema.ResourceData, meta interface{}) e
 
 	// Apply any pending tags now
 	if tagsClient != nil {
-		if err := processTagDiff(tagsClient, d, dc); err != nil {
+		if err := processTagDiff(tagsClient, d, meta, dc); err != nil {
 			return err
 		}
 	}
@@ -208,7 +209,7 @@ func resourceVSphereDatacenterUpdate(d *schema.ResourceData, meta interface{}) e
 	}
 	// Verify a proper vCenter before proceeding if custom attributes are defined
 	client := meta.(*Client).vimClient
-	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d)
+	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d, meta.(*Client).defaultCustomAttributes)
 	if err != nil {
 		return err
 	}
@@ -220,7 +221,7 @@ func resourceVSphereDatacenterUpdate(d *schema.ResourceData, meta interface{}) e
 
 	// Apply any pending tags now
 	if tagsClient != nil {
-		if err := processTagDiff(tagsClient, d, dc); err != nil {
+		if err := processTagDiff(tagsClient, d, meta, dc); err != nil {
 			return err
 		}
 	}
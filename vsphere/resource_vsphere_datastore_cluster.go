@@ -34,10 +34,11 @@ var storageDrsSpaceLoadBalanceConfigSpaceThresholdModeAllowedValues = []string{
 
 func resourceVSphereDatastoreCluster() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceVSphereDatastoreClusterCreate,
-		Read:   resourceVSphereDatastoreClusterRead,
-		Update: resourceVSphereDatastoreClusterUpdate,
-		Delete: resourceVSphereDatastoreClusterDelete,
+		Create:        resourceVSphereDatastoreClusterCreate,
+		Read:          resourceVSphereDatastoreClusterRead,
+		Update:        resourceVSphereDatastoreClusterUpdate,
+		Delete:        resourceVSphereDatastoreClusterDelete,
+		CustomizeDiff: tagsAndCustomAttributesCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			State: resourceVSphereDatastoreClusterImport,
 		},
@@ -368,7 +369,7 @@ func resourceVSphereDatastoreClusterApplyTags(d *schema.ResourceData, meta inter
 	}
 
 	log.Printf("[DEBUG] %s: Applying any pending tags", resourceVSphereDatastoreClusterIDString(d))
-	return processTagDiff(tagsClient, d, pod)
+	return processTagDiff(tagsClient, d, meta, pod)
 }
 
 // resourceVSphereDatastoreClusterReadTags reads the tags for
@@ -390,7 +391,7 @@ func resourceVSphereDatastoreClusterReadTags(d *schema.ResourceData, meta interf
 func resourceVSphereDatastoreClusterApplyCustomAttributes(d *schema.ResourceData, meta interface{}, pod *object.StoragePod) error {
 	client := meta.(*Client).vimClient
 	// Verify a proper vCenter before proceeding if custom attributes are defined
-	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d)
+	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d, meta.(*Client).defaultCustomAttributes)
 	if err != nil {
 		return err
 	}
@@ -39,10 +39,11 @@ func resourceVSphereDistributedPortGroup() *schema.Resource {
 	structure.MergeSchema(s, schemaDVPortgroupConfigSpec())
 
 	return &schema.Resource{
-		Create: resourceVSphereDistributedPortGroupCreate,
-		Read:   resourceVSphereDistributedPortGroupRead,
-		Update: resourceVSphereDistributedPortGroupUpdate,
-		Delete: resourceVSphereDistributedPortGroupDelete,
+		Create:        resourceVSphereDistributedPortGroupCreate,
+		Read:          resourceVSphereDistributedPortGroupRead,
+		Update:        resourceVSphereDistributedPortGroupUpdate,
+		Delete:        resourceVSphereDistributedPortGroupDelete,
+		CustomizeDiff: tagsAndCustomAttributesCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			State: resourceVSphereDistributedPortGroupImport,
 		},
@@ -60,7 +61,7 @@ func resourceVSphereDistributedPortGroupCreate(d *schema.ResourceData, meta inte
 		return err
 	}
 	// Verify a proper vCenter before proceeding if custom attributes are defined
-	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d)
+	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d, meta.(*Client).defaultCustomAttributes)
 	if err != nil {
 		return err
 	}
@@ -96,7 +97,7 @@ func resourceVSphereDistributedPortGroupCreate(d *schema.ResourceData, meta inte
 
 	// Apply any pending tags now
 	if tagsClient != nil {
-		if err := processTagDiff(tagsClient, d, object.NewReference(client.Client, pg.Reference())); err != nil {
+		if err := processTagDiff(tagsClient, d, meta, object.NewReference(client.Client, pg.Reference())); err != nil {
 			return fmt.Errorf("error updating tags: %s", err)
 		}
 	}
@@ -155,7 +156,7 @@ func resourceVSphereDistributedPortGroupUpdate(d *schema.ResourceData, meta inte
 		return err
 	}
 	// Verify a proper vCenter before proceeding if custom attributes are defined
-	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d)
+	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d, meta.(*Client).defaultCustomAttributes)
 	if err != nil {
 		return err
 	}
@@ -180,7 +181,7 @@ func resourceVSphereDistributedPortGroupUpdate(d *schema.ResourceData, meta inte
 
 	// Apply any pending tags now
 	if tagsClient != nil {
-		if err := processTagDiff(tagsClient, d, object.NewReference(client.Client, pg.Reference())); err != nil {
+		if err := processTagDiff(tagsClient, d, meta, object.NewReference(client.Client, pg.Reference())); err != nil {
 			return fmt.Errorf("error updating tags: %s", err)
 		}
 	}
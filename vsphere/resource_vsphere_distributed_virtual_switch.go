@@ -43,10 +43,11 @@ func resourceVSphereDistributedVirtualSwitch() *schema.Resource {
 	structure.MergeSchema(s, schemaDVSCreateSpec())
 
 	return &schema.Resource{
-		Create: resourceVSphereDistributedVirtualSwitchCreate,
-		Read:   resourceVSphereDistributedVirtualSwitchRead,
-		Update: resourceVSphereDistributedVirtualSwitchUpdate,
-		Delete: resourceVSphereDistributedVirtualSwitchDelete,
+		Create:        resourceVSphereDistributedVirtualSwitchCreate,
+		Read:          resourceVSphereDistributedVirtualSwitchRead,
+		Update:        resourceVSphereDistributedVirtualSwitchUpdate,
+		Delete:        resourceVSphereDistributedVirtualSwitchDelete,
+		CustomizeDiff: tagsAndCustomAttributesCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			State: resourceVSphereDistributedVirtualSwitchImport,
 		},
@@ -64,7 +65,7 @@ func resourceVSphereDistributedVirtualSwitchCreate(d *schema.ResourceData, meta
 		return err
 	}
 	// Verify a proper vCenter before proceeding if custom attributes are defined
-	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d)
+	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d, meta.(*Client).defaultCustomAttributes)
 	if err != nil {
 		return err
 	}
@@ -113,7 +114,7 @@ func resourceVSphereDistributedVirtualSwitchCreate(d *schema.ResourceData, meta
 
 	// Apply any pending tags now
 	if tagsClient != nil {
-		if err := processTagDiff(tagsClient, d, object.NewReference(client.Client, dvs.Reference())); err != nil {
+		if err := processTagDiff(tagsClient, d, meta, object.NewReference(client.Client, dvs.Reference())); err != nil {
 			return fmt.Errorf("error updating tags: %s", err)
 		}
 	}
@@ -192,7 +193,7 @@ func resourceVSphereDistributedVirtualSwitchUpdate(d *schema.ResourceData, meta
 		return err
 	}
 	// Verify a proper vCenter before proceeding if custom attributes are defined
-	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d)
+	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d, meta.(*Client).defaultCustomAttributes)
 	if err != nil {
 		return err
 	}
@@ -248,7 +249,7 @@ func resourceVSphereDistributedVirtualSwitchUpdate(d *schema.ResourceData, meta
 
 	// Apply any pending tags now
 	if tagsClient != nil {
-		if err := processTagDiff(tagsClient, d, object.NewReference(client.Client, dvs.Reference())); err != nil {
+		if err := processTagDiff(tagsClient, d, meta, object.NewReference(client.Client, dvs.Reference())); err != nil {
 			return fmt.Errorf("error updating tags: %s", err)
 		}
 	}
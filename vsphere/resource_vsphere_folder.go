@@ -22,10 +22,11 @@ import (
 
 func resourceVSphereFolder() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceVSphereFolderCreate,
-		Read:   resourceVSphereFolderRead,
-		Update: resourceVSphereFolderUpdate,
-		Delete: resourceVSphereFolderDelete,
+		Create:        resourceVSphereFolderCreate,
+		Read:          resourceVSphereFolderRead,
+		Update:        resourceVSphereFolderUpdate,
+		Delete:        resourceVSphereFolderDelete,
+		CustomizeDiff: tagsAndCustomAttributesCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			State: resourceVSphereFolderImport,
 		},
@@ -75,7 +76,7 @@ func resourceVSphereFolderCreate(d *schema.ResourceData, meta interface{}) error
 		return err
 	}
 	// Verify a proper vCenter before proceeding if custom attributes are defined
-	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d)
+	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d, meta.(*Client).defaultCustomAttributes)
 	if err != nil {
 		return err
 	}
@@ -112,7 +113,7 @@ func resourceVSphereFolderCreate(d *schema.ResourceData, meta interface{}) error
 
 	// Apply any pending tags now
 	if tagsClient != nil {
-		if err := processTagDiff(tagsClient, d, targetFolder); err != nil {
+		if err := processTagDiff(tagsClient, d, meta, targetFolder); err != nil {
 			return fmt.Errorf("error updating tags: %s", err)
 		}
 	}
@@ -194,7 +195,7 @@ func resourceVSphereFolderUpdate(d *schema.ResourceData, meta interface{}) error
 		return err
 	}
 	// Verify a proper vCenter before proceeding if custom attributes are defined
-	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d)
+	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d, meta.(*Client).defaultCustomAttributes)
 	if err != nil {
 		return err
 	}
@@ -207,7 +208,7 @@ func resourceVSphereFolderUpdate(d *schema.ResourceData, meta interface{}) error
 	// Apply any pending tags first as it's the lesser expensive of the two
 	// operations
 	if tagsClient != nil {
-		if err := processTagDiff(tagsClient, d, fo); err != nil {
+		if err := processTagDiff(tagsClient, d, meta, fo); err != nil {
 			return fmt.Errorf("error updating tags: %s", err)
 		}
 	}
@@ -17,11 +17,12 @@ import (
 
 func resourceVSphereGuestOsCustomization() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceVSphereGuestOsCustomizationCreate,
-		Read:   resourceVSphereGuestOsCustomizationRead,
-		Update: resourceVSphereGuestOsCustomizationUpdate,
-		Delete: resourceVSphereGuestOsCustomizationDelete,
-		Schema: getSchema(),
+		Create:        resourceVSphereGuestOsCustomizationCreate,
+		Read:          resourceVSphereGuestOsCustomizationRead,
+		Update:        resourceVSphereGuestOsCustomizationUpdate,
+		Delete:        resourceVSphereGuestOsCustomizationDelete,
+		CustomizeDiff: resourceVSphereGuestOsCustomizationCustomizeDiff,
+		Schema:        getSchema(),
 	}
 }
 
@@ -35,6 +36,10 @@ func resourceVSphereGuestOsCustomizationRead(d *schema.ResourceData, meta interf
 	return guestoscustomizations.FlattenGuestOsCustomizationSpec(d, specItem, client)
 }
 
+func resourceVSphereGuestOsCustomizationCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	return guestoscustomizations.ValidateWindowsOptions(d, false)
+}
+
 func resourceVSphereGuestOsCustomizationCreate(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[DEBUG] Beginning creation of customization specification %s", d.Get("name"))
 	client := meta.(*Client).vimClient
@@ -13,6 +13,9 @@ import (
 	"crypto/tls"
 	"fmt"
 	"log"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -29,6 +32,7 @@ import (
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/customattribute"
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/provider"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/structure"
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/viapi"
 )
 
@@ -42,10 +46,12 @@ var servicesPolicyAllowedValues = []string{
 
 func resourceVsphereHost() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceVsphereHostCreate,
-		Read:   resourceVsphereHostRead,
-		Update: resourceVsphereHostUpdate,
-		Delete: resourceVsphereHostDelete,
+		Create:        resourceVsphereHostCreate,
+		Read:          resourceVsphereHostRead,
+		Update:        resourceVsphereHostUpdate,
+		Delete:        resourceVsphereHostDelete,
+		CustomizeDiff: resourceVsphereHostCustomizeDiff,
+		Timeouts:      resourceTimeouts(schema.TimeoutCreate, schema.TimeoutRead, schema.TimeoutUpdate, schema.TimeoutDelete),
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -78,10 +84,25 @@ func resourceVsphereHost() *schema.Resource {
 				Description: "Username of the administration account of the host.",
 			},
 			"password": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Password of the administration account of the host.",
-				Sensitive:   true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Password of the administration account of the host.",
+				Sensitive:    true,
+				ExactlyOneOf: []string{"password", "password_wo"},
+			},
+			"password_wo": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Write-only password of the administration account of the host. The value is not stored in state.",
+				Sensitive:    true,
+				WriteOnly:    true,
+				RequiredWith: []string{"password_wo_version"},
+			},
+			"password_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Version of password_wo. Changing the version reconnects the host with the current value of password_wo.",
+				RequiredWith: []string{"password_wo"},
 			},
 			"thumbprint": {
 				Type:        schema.TypeString,
@@ -118,6 +139,12 @@ func resourceVsphereHost() *schema.Resource {
 				Default:      "disabled",
 				ValidateFunc: validation.StringInSlice([]string{"disabled", "normal", "strict"}, true),
 			},
+			"lockdown_exception_users": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The users that keep their permissions on the host when it is in lockdown mode.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 			"services": {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -149,6 +176,32 @@ func resourceVsphereHost() *schema.Resource {
 								},
 							},
 						},
+						"service": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "A host service, managed by its key, with its running state and startup policy.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"key": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringIsNotEmpty,
+										Description:  "The key of the service, such as 'TSM-SSH' or 'TSM'.",
+									},
+									"running": {
+										Type:        schema.TypeBool,
+										Required:    true,
+										Description: "Whether the service should be running.",
+									},
+									"policy": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validation.StringInSlice(servicesPolicyAllowedValues, false),
+										Description:  "The startup policy for the service. Valid values are 'on', 'off' and 'automatic'.",
+									},
+								},
+							},
+						},
 					},
 				},
 			},
@@ -169,6 +222,8 @@ func resourceVsphereHostCreate(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	client := meta.(*Client).vimClient
+	ctx, cancel := provider.WithTimeout(resourceTimeout(d, schema.TimeoutCreate, 0))
+	defer cancel()
 
 	hcs, err := buildHostConnectSpec(d)
 	if err != nil {
@@ -204,7 +259,7 @@ func resourceVsphereHostCreate(d *schema.ResourceData, meta interface{}) error {
 			return fmt.Errorf("error while searching cluster %s. Error: %s", clusterID, err)
 		}
 
-		task, err = ccr.AddHost(context.TODO(), hcs, connectedState, &licenseKey, nil)
+		task, err = ccr.AddHost(ctx, hcs, connectedState, &licenseKey, nil)
 		if err != nil {
 			return fmt.Errorf("error while adding host with hostname %s to cluster %s.  Error: %s", d.Get("hostname").(string), clusterID, err)
 		}
@@ -215,22 +270,22 @@ func resourceVsphereHostCreate(d *schema.ResourceData, meta interface{}) error {
 			return fmt.Errorf("error while retrieving datacenter object for datacenter: %s. Error: %s", dcID, err)
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
-		defer cancel()
+		pctx, pcancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+		defer pcancel()
 		var dcProps mo.Datacenter
-		if err := dc.Properties(ctx, dc.Reference(), nil, &dcProps); err != nil {
+		if err := dc.Properties(pctx, dc.Reference(), nil, &dcProps); err != nil {
 			return fmt.Errorf("error while retrieving properties for datacenter %s. Error: %s", dcID, err)
 		}
 
 		hostFolder := object.NewFolder(client.Client, dcProps.HostFolder)
-		task, err = hostFolder.AddStandaloneHost(context.TODO(), hcs, connectedState, &licenseKey, nil)
+		task, err = hostFolder.AddStandaloneHost(ctx, hcs, connectedState, &licenseKey, nil)
 		if err != nil {
 			return fmt.Errorf("error while adding standalone host %s. Error: %s", hcs.HostName, err)
 		}
 	}
 
 	p := property.DefaultCollector(client.Client)
-	res, err := gtask.WaitEx(context.TODO(), task.Reference(), p, nil)
+	res, err := gtask.WaitEx(ctx, task.Reference(), p, nil)
 	if err != nil {
 		return fmt.Errorf("host addition failed. %s", err)
 	}
@@ -241,7 +296,7 @@ func resourceVsphereHostCreate(d *schema.ResourceData, meta interface{}) error {
 	switch taskResultType {
 	case "ComputeResource":
 		computeResource := object.NewComputeResource(client.Client, taskResult.(types.ManagedObjectReference))
-		crHosts, err := computeResource.Hosts(context.TODO())
+		crHosts, err := computeResource.Hosts(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to retrieve created computeResource Hosts. Error: %s", err)
 		}
@@ -269,14 +324,14 @@ func resourceVsphereHostCreate(d *schema.ResourceData, meta interface{}) error {
 
 	// Verify the vCenter Server connection before
 	// attempting to proceed if custom attributes have been defined.
-	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d)
+	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d, meta.(*Client).defaultCustomAttributes)
 	if err != nil {
 		return err
 	}
 
 	// Apply tags
 	if tagsClient != nil {
-		if err := processTagDiff(tagsClient, d, host); err != nil {
+		if err := processTagDiff(tagsClient, d, meta, host); err != nil {
 			return fmt.Errorf("error updating tags: %s", err)
 		}
 	}
@@ -302,17 +357,26 @@ func resourceVsphereHostCreate(d *schema.ResourceData, meta interface{}) error {
 
 		hamRef := hostProps.ConfigManager.HostAccessManager.Reference()
 		ham := NewHostAccessManager(client.Client, hamRef)
-		err = ham.ChangeLockdownMode(context.TODO(), lockdownMode)
+		// The exception users are set before lockdown mode is enabled, so that
+		// they keep access to the host as soon as it is locked down.
+		if users, ok := d.GetOk("lockdown_exception_users"); ok {
+			err = ham.UpdateLockdownExceptions(ctx, structure.SliceInterfacesToStrings(users.(*schema.Set).List()))
+			if err != nil {
+				return fmt.Errorf("error while updating lockdown exception users for host %s. Error: %s", hostID, err)
+			}
+		}
+		err = ham.ChangeLockdownMode(ctx, lockdownMode)
 		if err != nil {
 			return fmt.Errorf("error while changing lockdown mode for host %s. Error: %s", hostID, err)
 		}
 	}
 
 	maintenanceMode := d.Get("maintenance").(bool)
+	maintenanceTimeout := resourceTimeout(d, schema.TimeoutCreate, provider.DefaultAPITimeout)
 	if maintenanceMode {
-		err = hostsystem.EnterMaintenanceMode(host, provider.DefaultAPITimeout, true)
+		err = hostsystem.EnterMaintenanceMode(host, maintenanceTimeout, true)
 	} else {
-		err = hostsystem.ExitMaintenanceMode(host, provider.DefaultAPITimeout)
+		err = hostsystem.ExitMaintenanceMode(host, maintenanceTimeout)
 	}
 	if err != nil {
 		return fmt.Errorf("error while toggling maintenance mode for host %s. Error: %s", hostID, err)
@@ -355,35 +419,47 @@ func resourceVsphereHostRead(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("error while searching host %s. Error: %s ", hostID, err)
 	}
 
-	ctx := context.TODO()
-	serviceKey := "ntpd"
-	ntpServers, err := readHostNtpServerConfig(ctx, client, hs)
-	if err != nil {
-		return fmt.Errorf("error while reading NTP configuration for host: %s", err)
-	}
+	ctx, cancel := provider.WithTimeout(resourceTimeout(d, schema.TimeoutRead, 0))
+	defer cancel()
+	servicesConfig := make(map[string]interface{})
+	if hostServicesManageNtpd(d) {
+		serviceKey := "ntpd"
+		ntpServers, err := readHostNtpServerConfig(ctx, client, hs)
+		if err != nil {
+			return fmt.Errorf("error while reading NTP configuration for host: %s", err)
+		}
 
-	policyConfig, err := readHostServicePolicy(ctx, client, hs, serviceKey)
-	if err != nil {
-		return fmt.Errorf("error while reading policy configuration for host: %s", err)
-	}
+		policyConfig, err := readHostServicePolicy(ctx, client, hs, serviceKey)
+		if err != nil {
+			return fmt.Errorf("error while reading policy configuration for host: %s", err)
+		}
 
-	serviceEnabled, err := readHostServiceStatus(ctx, client, hs, serviceKey)
-	if err != nil {
-		return fmt.Errorf("error while reading service status for host: %s", err)
-	}
+		serviceEnabled, err := readHostServiceStatus(ctx, client, hs, serviceKey)
+		if err != nil {
+			return fmt.Errorf("error while reading service status for host: %s", err)
+		}
 
-	ntpdService := map[string]interface{}{
-		"ntpd": []interface{}{
+		servicesConfig["ntpd"] = []interface{}{
 			map[string]interface{}{
 				"enabled":     serviceEnabled,
 				"policy":      policyConfig,
 				"ntp_servers": ntpServers,
 			},
-		},
+		}
+	}
+
+	// Only services already managed by this resource are read back, so that
+	// services not declared in the configuration do not show up as drift.
+	managedServices, err := readHostManagedServices(ctx, hs, d)
+	if err != nil {
+		return fmt.Errorf("error while reading services for host: %s", err)
+	}
+	if len(managedServices) > 0 {
+		servicesConfig["service"] = managedServices
 	}
 
 	// Set this structure under the "services" key in the resource data
-	if err := d.Set("services", []interface{}{ntpdService}); err != nil {
+	if err := d.Set("services", []interface{}{servicesConfig}); err != nil {
 		return fmt.Errorf("error setting services: %s", err)
 	}
 
@@ -427,6 +503,17 @@ func resourceVsphereHostRead(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("Setting lockdown to %s", lockdownMode)
 	_ = d.Set("lockdown", lockdownMode)
 
+	// Lockdown exception users are only read when they are managed, as
+	// solutions registered with vCenter may add their own service accounts.
+	if _, ok := d.GetOk("lockdown_exception_users"); ok && host.ConfigManager.HostAccessManager != nil {
+		ham := NewHostAccessManager(client.Client, host.ConfigManager.HostAccessManager.Reference())
+		users, err := ham.QueryLockdownExceptions(context.TODO())
+		if err != nil {
+			return fmt.Errorf("error while reading lockdown exception users for host %s. Error: %s", hostID, err)
+		}
+		_ = d.Set("lockdown_exception_users", users)
+	}
+
 	licenseKey := d.Get("license").(string)
 	if licenseKey != "" {
 		licFound, err := isLicenseAssigned(client.Client, hostID, licenseKey)
@@ -471,7 +558,7 @@ func resourceVsphereHostUpdate(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d)
+	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d, meta.(*Client).defaultCustomAttributes)
 	if err != nil {
 		return err
 	}
@@ -498,7 +585,7 @@ func resourceVsphereHostUpdate(d *schema.ResourceData, meta interface{}) error {
 
 	// Have there been any changes that warrant a reconnect?
 	reconnect := false
-	connectionKeys := []string{"hostname", "username", "password", "thumbprint"}
+	connectionKeys := []string{"hostname", "username", "password", "password_wo_version", "thumbprint"}
 	for _, k := range connectionKeys {
 		if d.HasChange(k) {
 			reconnect = true
@@ -519,7 +606,7 @@ func resourceVsphereHostUpdate(d *schema.ResourceData, meta interface{}) error {
 			return fmt.Errorf("error while reconnecting host %s. Error: %s", hostID, err)
 		}
 	case -1:
-		err := resourceVSphereHostDisconnect(d, meta)
+		err := resourceVSphereHostDisconnect(d, meta, resourceTimeout(d, schema.TimeoutUpdate, 0))
 		if err != nil {
 			return fmt.Errorf("error while disconnecting host %s. Error: %s", hostID, err)
 		}
@@ -527,6 +614,15 @@ func resourceVsphereHostUpdate(d *schema.ResourceData, meta interface{}) error {
 		break
 	}
 
+	// Exception users are updated before the lockdown mode, so that they do
+	// not lose access to the host when lockdown mode is enabled.
+	if d.HasChange("lockdown_exception_users") {
+		log.Printf("[DEBUG] Key lockdown_exception_users has change, processing")
+		if err := resourceVSphereHostUpdateLockdownExceptions(d, meta); err != nil {
+			return fmt.Errorf("error while updating lockdown_exception_users: %s", err)
+		}
+	}
+
 	mutableKeys := map[string]func(*schema.ResourceData, interface{}, interface{}, interface{}) error{
 		"license":     resourceVSphereHostUpdateLicense,
 		"cluster":     resourceVSphereHostUpdateCluster,
@@ -550,7 +646,7 @@ func resourceVsphereHostUpdate(d *schema.ResourceData, meta interface{}) error {
 
 	// Apply tags
 	if tagsClient != nil {
-		if err := processTagDiff(tagsClient, d, hostObject); err != nil {
+		if err := processTagDiff(tagsClient, d, meta, hostObject); err != nil {
 			return fmt.Errorf("error updating tags: %s", err)
 		}
 	}
@@ -581,7 +677,7 @@ func resourceVsphereHostDelete(d *schema.ResourceData, meta interface{}) error {
 
 	if connectionState != types.HostSystemConnectionStateDisconnected {
 		// We cannot put a disconnected server in maintenance mode.
-		err = resourceVSphereHostDisconnect(d, meta)
+		err = resourceVSphereHostDisconnect(d, meta, resourceTimeout(d, schema.TimeoutDelete, 0))
 		if err != nil {
 			return fmt.Errorf("error while disconnecting host: %s", err.Error())
 		}
@@ -592,23 +688,26 @@ func resourceVsphereHostDelete(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("error while retrieving properties fort host %s. Error: %s", hostID, err)
 	}
 
+	ctx, cancel := provider.WithTimeout(resourceTimeout(d, schema.TimeoutDelete, 0))
+	defer cancel()
+
 	// If this is a standalone host we need to destroy the ComputeResource object
 	// and not the Hostsystem itself.
 	var task *object.Task
 	if hostProps.Parent.Type == "ComputeResource" {
 		cr := object.NewComputeResource(client.Client, *hostProps.Parent)
-		task, err = cr.Destroy(context.TODO())
+		task, err = cr.Destroy(ctx)
 		if err != nil {
 			return fmt.Errorf("error while submitting destroy task for compute resource %s. Error: %s", hostProps.Parent.Value, err)
 		}
 	} else {
-		task, err = hs.Destroy(context.TODO())
+		task, err = hs.Destroy(ctx)
 		if err != nil {
 			return fmt.Errorf("error while submitting destroy task for host system %s. Error: %s", hostProps.Parent.Value, err)
 		}
 	}
 	p := property.DefaultCollector(client.Client)
-	_, err = gtask.WaitEx(context.TODO(), task.Reference(), p, nil)
+	_, err = gtask.WaitEx(ctx, task.Reference(), p, nil)
 	if err != nil {
 		return fmt.Errorf("error while waiting for host (%s) to be removed: %s", hostID, err)
 	}
@@ -644,6 +743,30 @@ func resourceVSphereHostUpdateLockdownMode(d *schema.ResourceData, meta, _, newV
 	return nil
 }
 
+func resourceVSphereHostUpdateLockdownExceptions(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).vimClient
+	hostID := d.Id()
+	host, err := hostsystem.FromID(client, hostID)
+	if err != nil {
+		return fmt.Errorf("error while retrieving HostSystem object for host ID %s. Error: %s", hostID, err)
+	}
+
+	var hostProps mo.HostSystem
+	err = host.Properties(context.TODO(), host.ConfigManager().Reference(), []string{"configManager.hostAccessManager"}, &hostProps)
+	if err != nil {
+		return fmt.Errorf("error while retrieving HostSystem properties for host ID %s. Error: %s", hostID, err)
+	}
+
+	ham := NewHostAccessManager(client.Client, hostProps.ConfigManager.HostAccessManager.Reference())
+	users := structure.SliceInterfacesToStrings(d.Get("lockdown_exception_users").(*schema.Set).List())
+	err = ham.UpdateLockdownExceptions(context.TODO(), users)
+	if err != nil {
+		return fmt.Errorf("error while updating lockdown exception users for host ID %s. Error: %s", hostID, err)
+	}
+
+	return nil
+}
+
 func resourceVSphereHostUpdateMaintenanceMode(d *schema.ResourceData, meta, _, newVal interface{}) error {
 	client := meta.(*Client).vimClient
 	hostID := d.Id()
@@ -654,10 +777,11 @@ func resourceVSphereHostUpdateMaintenanceMode(d *schema.ResourceData, meta, _, n
 	}
 
 	maintenanceMode := newVal.(bool)
+	timeout := resourceTimeout(d, schema.TimeoutUpdate, provider.DefaultAPITimeout)
 	if maintenanceMode {
-		err = hostsystem.EnterMaintenanceMode(host, provider.DefaultAPITimeout, true)
+		err = hostsystem.EnterMaintenanceMode(host, timeout, true)
 	} else {
-		err = hostsystem.ExitMaintenanceMode(host, provider.DefaultAPITimeout)
+		err = hostsystem.ExitMaintenanceMode(host, timeout)
 	}
 	if err != nil {
 		return fmt.Errorf("error while toggling maintenance mode for host %s. Error: %s", host.Name(), err)
@@ -694,22 +818,25 @@ func resourceVSphereHostUpdateCluster(d *schema.ResourceData, meta, _, newVal in
 		return fmt.Errorf("error while retrieving HostSystem object for host ID %s. Error: %s", hostID, err)
 	}
 
-	err = hostsystem.EnterMaintenanceMode(hs, provider.DefaultAPITimeout, true)
+	timeout := resourceTimeout(d, schema.TimeoutUpdate, provider.DefaultAPITimeout)
+	err = hostsystem.EnterMaintenanceMode(hs, timeout, true)
 	if err != nil {
 		return fmt.Errorf("error while putting host to maintenance mode: %s", err.Error())
 	}
 
-	task, err := newCluster.MoveInto(context.TODO(), hs)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	task, err := newCluster.MoveInto(ctx, hs)
 	if err != nil {
 		return fmt.Errorf("error while moving HostSystem with ID %s to new cluster. Error: %s", hostID, err)
 	}
 	p := property.DefaultCollector(client.Client)
-	_, err = gtask.WaitEx(context.TODO(), task.Reference(), p, nil)
+	_, err = gtask.WaitEx(ctx, task.Reference(), p, nil)
 	if err != nil {
 		return fmt.Errorf("error while moving host to new cluster (%s): %s", newClusterID, err)
 	}
 
-	err = hostsystem.ExitMaintenanceMode(hs, provider.DefaultAPITimeout)
+	err = hostsystem.ExitMaintenanceMode(hs, timeout)
 	if err != nil {
 		return fmt.Errorf("error while taking host out of maintenance mode: %s", err.Error())
 	}
@@ -730,13 +857,15 @@ func resourceVSphereHostReconnect(d *schema.ResourceData, meta interface{}) erro
 		return fmt.Errorf("failed to build host connect spec: %v", err)
 	}
 
-	task, err := host.Reconnect(context.TODO(), &hcs, nil)
+	ctx, cancel := provider.WithTimeout(resourceTimeout(d, schema.TimeoutUpdate, 0))
+	defer cancel()
+	task, err := host.Reconnect(ctx, &hcs, nil)
 	if err != nil {
 		return fmt.Errorf("error while reconnecting host with ID %s. Error: %s", hostID, err)
 	}
 
 	p := property.DefaultCollector(client.Client)
-	_, err = gtask.WaitEx(context.TODO(), task.Reference(), p, nil)
+	_, err = gtask.WaitEx(ctx, task.Reference(), p, nil)
 	if err != nil {
 		return fmt.Errorf("error while reconnecting host(%s): %s", hostID, err)
 	}
@@ -748,7 +877,7 @@ func resourceVSphereHostReconnect(d *schema.ResourceData, meta interface{}) erro
 
 	maintenanceConfig := d.Get("maintenance").(bool)
 	if maintenanceState && !maintenanceConfig {
-		err := hostsystem.ExitMaintenanceMode(host, provider.DefaultAPITimeout)
+		err := hostsystem.ExitMaintenanceMode(host, resourceTimeout(d, schema.TimeoutUpdate, provider.DefaultAPITimeout))
 		if err != nil {
 			return fmt.Errorf("error while taking host %s out of maintenance mode. Error: %s", host.Name(), err)
 		}
@@ -756,17 +885,21 @@ func resourceVSphereHostReconnect(d *schema.ResourceData, meta interface{}) erro
 	return nil
 }
 
-func resourceVSphereHostDisconnect(d *schema.ResourceData, meta interface{}) error {
+// resourceVSphereHostDisconnect disconnects the host, waiting up to timeout for
+// the task to complete. A timeout of 0 waits indefinitely.
+func resourceVSphereHostDisconnect(d *schema.ResourceData, meta interface{}, timeout time.Duration) error {
 	hostID := d.Id()
 	client := meta.(*Client).vimClient
 	host := object.NewHostSystem(client.Client, types.ManagedObjectReference{Type: "HostSystem", Value: d.Id()})
-	task, err := host.Disconnect(context.TODO())
+	ctx, cancel := provider.WithTimeout(timeout)
+	defer cancel()
+	task, err := host.Disconnect(ctx)
 	if err != nil {
 		return fmt.Errorf("error while disconnecting host %s. Error: %s", host.Name(), err)
 	}
 
 	p := property.DefaultCollector(client.Client)
-	_, err = gtask.WaitEx(context.TODO(), task.Reference(), p, nil)
+	_, err = gtask.WaitEx(ctx, task.Reference(), p, nil)
 	if err != nil {
 		return fmt.Errorf("error while disconnecting host(%s): %s", hostID, err)
 	}
@@ -845,6 +978,9 @@ func buildHostConnectSpec(d *schema.ResourceData) (types.HostConnectSpec, error)
 	hostname := d.Get("hostname").(string)
 	username := d.Get("username").(string)
 	password := d.Get("password").(string)
+	if password == "" {
+		password = structure.GetWriteOnlyString(d, "password_wo")
+	}
 
 	log.Printf("Building HostConnectSpec for host: %s", hostname)
 	// Retrieve the actual thumbprint from the ESXi host.
@@ -994,9 +1130,31 @@ func (h HostAccessManager) ChangeLockdownMode(ctx context.Context, mode types.Ho
 	return err
 }
 
+func (h HostAccessManager) QueryLockdownExceptions(ctx context.Context) ([]string, error) {
+	req := types.QueryLockdownExceptions{
+		This: h.Reference(),
+	}
+	res, err := methods.QueryLockdownExceptions(ctx, h.Client(), &req)
+	if err != nil {
+		return nil, err
+	}
+	return res.Returnval, nil
+}
+
+func (h HostAccessManager) UpdateLockdownExceptions(ctx context.Context, users []string) error {
+	req := types.UpdateLockdownExceptions{
+		This:  h.Reference(),
+		Users: users,
+	}
+	_, err := methods.UpdateLockdownExceptions(ctx, h.Client(), &req)
+	return err
+}
+
 func resourceVSphereHostUpdateServices(d *schema.ResourceData, meta interface{}, _, _ interface{}) error {
 	client := meta.(*Client).vimClient
 	hostID := d.Id()
+	ctx, cancel := provider.WithTimeout(resourceCreateOrUpdateTimeout(d, provider.DefaultAPITimeout))
+	defer cancel()
 	hostObject, err := hostsystem.FromID(client, hostID)
 	if err != nil {
 		return fmt.Errorf("error while retrieving HostSystem object for host ID %s. Error: %s", hostID, err)
@@ -1014,13 +1172,13 @@ func resourceVSphereHostUpdateServices(d *schema.ResourceData, meta interface{},
 		serviceMap := service.(map[string]interface{})
 		updatedServiceMap := make(map[string]interface{}) // Prepare to collect updated service configuration
 
-		if ntpd, ok := serviceMap["ntpd"]; ok {
-			ntpdConfig := ntpd.([]interface{})[0].(map[string]interface{})
+		if ntpd, ok := serviceMap["ntpd"].([]interface{}); ok && len(ntpd) > 0 && ntpd[0] != nil {
+			ntpdConfig := ntpd[0].(map[string]interface{})
 			updatedNtpdConfig := make(map[string]interface{}) // Copy ntpdConfig if needed before modifications
 
 			// Start the NTP service if enabled
 			if enabled, ok := ntpdConfig["enabled"].(bool); ok && enabled {
-				err := StartHostService(context.Background(), client, hostObject, "ntpd")
+				err := StartHostService(ctx, client, hostObject, "ntpd")
 				if err != nil {
 					return fmt.Errorf("failed to start NTP service on host %s: %v", hostID, err)
 				}
@@ -1033,7 +1191,7 @@ func resourceVSphereHostUpdateServices(d *schema.ResourceData, meta interface{},
 				newServers[i] = server.(string)
 			}
 
-			err := changeHostNtpServers(context.Background(), hostObject, newServers)
+			err := changeHostNtpServers(ctx, hostObject, newServers)
 			if err != nil {
 				return fmt.Errorf("error while updating NTP servers for host %s. Error: %s", hostID, err)
 			}
@@ -1045,7 +1203,7 @@ func resourceVSphereHostUpdateServices(d *schema.ResourceData, meta interface{},
 
 			// Update the NTP service policy if applicable
 			if policy, ok := ntpdConfig["policy"].(string); ok {
-				err := UpdateHostServicePolicy(context.Background(), client, hostObject, "ntpd", policy)
+				err := UpdateHostServicePolicy(ctx, client, hostObject, "ntpd", policy)
 				if err != nil {
 					return fmt.Errorf("failed to update NTP service policy on host %s: %v", hostID, err)
 				}
@@ -1053,7 +1211,13 @@ func resourceVSphereHostUpdateServices(d *schema.ResourceData, meta interface{},
 
 			updatedServiceMap["ntpd"] = []interface{}{updatedNtpdConfig}
 		}
-		// Handle other services similarly and add to updatedServiceMap as needed
+
+		if serviceSet, ok := serviceMap["service"].(*schema.Set); ok && serviceSet.Len() > 0 {
+			if err := applyHostServices(ctx, hostObject, serviceSet.List()); err != nil {
+				return fmt.Errorf("error while updating services for host %s. Error: %s", hostID, err)
+			}
+			updatedServiceMap["service"] = serviceSet.List()
+		}
 
 		updatedServices = append(updatedServices, updatedServiceMap) // Add the updated service map to the collection
 	}
@@ -1196,3 +1360,219 @@ func readHostServiceStatus(ctx context.Context, _ *govmomi.Client, hostObject *o
 
 	return false, fmt.Errorf("NTP service not found on host")
 }
+
+// resourceVsphereHostCustomizeDiff applies the default tags and custom
+// attributes and validates the services block against the host.
+func resourceVsphereHostCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if err := tagsAndCustomAttributesCustomizeDiff(ctx, d, meta); err != nil {
+		return err
+	}
+	return resourceVsphereHostValidateServices(ctx, d, meta)
+}
+
+// resourceVsphereHostValidateServices checks the service keys declared in the
+// services block. Keys are checked against the host's actual service list for
+// hosts that are already managed; services on new hosts are checked when they
+// are applied.
+func resourceVsphereHostValidateServices(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("services") {
+		return nil
+	}
+	services := hostServiceList(d)
+	if len(services) == 0 {
+		return nil
+	}
+
+	var available []types.HostService
+	if d.Id() != "" {
+		client, ok := meta.(*Client)
+		if !ok || client.vimClient == nil {
+			return nil
+		}
+		hs, err := hostsystem.FromID(client.vimClient, d.Id())
+		if err != nil {
+			log.Printf("[DEBUG] Skipping service validation for host %q: %s", d.Id(), err)
+			return nil
+		}
+		vctx, cancel := context.WithTimeout(ctx, defaultAPITimeout)
+		defer cancel()
+		available, err = readHostServices(vctx, hs)
+		if err != nil {
+			return fmt.Errorf("error while reading services for host %s: %s", d.Id(), err)
+		}
+	}
+
+	return validateHostServices(services, len(hostServiceNtpdList(d)) > 0, available)
+}
+
+// validateHostServices checks a list of service entries for duplicate keys,
+// for a conflict with the ntpd block and, when available is not nil, for
+// keys that do not exist on the host.
+func validateHostServices(services []interface{}, ntpdManaged bool, available []types.HostService) error {
+	seen := make(map[string]struct{})
+	for _, v := range services {
+		key := v.(map[string]interface{})["key"].(string)
+		if _, ok := seen[key]; ok {
+			return fmt.Errorf("service %q is declared more than once", key)
+		}
+		seen[key] = struct{}{}
+		if key == "ntpd" && ntpdManaged {
+			return fmt.Errorf("service %q cannot be declared in both the ntpd and service blocks", key)
+		}
+	}
+	if available == nil {
+		return nil
+	}
+
+	keys := make([]string, 0, len(available))
+	for _, svc := range available {
+		keys = append(keys, svc.Key)
+	}
+	for key := range seen {
+		if !slices.Contains(keys, key) {
+			return fmt.Errorf("service %q not found on host, valid services are: %s", key, strings.Join(keys, ", "))
+		}
+	}
+	return nil
+}
+
+// hostServicesGetter is implemented by both schema.ResourceData and
+// schema.ResourceDiff.
+type hostServicesGetter interface {
+	Get(string) interface{}
+}
+
+// hostServiceList returns the service entries declared in the services block.
+func hostServiceList(d hostServicesGetter) []interface{} {
+	var services []interface{}
+	for _, v := range hostServicesElements(d) {
+		if s, ok := v["service"].(*schema.Set); ok {
+			services = append(services, s.List()...)
+		}
+	}
+	return services
+}
+
+// hostServiceNtpdList returns the ntpd entries declared in the services block.
+func hostServiceNtpdList(d hostServicesGetter) []interface{} {
+	var ntpd []interface{}
+	for _, v := range hostServicesElements(d) {
+		if l, ok := v["ntpd"].([]interface{}); ok {
+			ntpd = append(ntpd, l...)
+		}
+	}
+	return ntpd
+}
+
+// hostServicesManageNtpd returns true if the ntpd service settings should be
+// read back into the state. This is the case when the ntpd block is in use or
+// when there is no services block yet, such as during import.
+func hostServicesManageNtpd(d hostServicesGetter) bool {
+	return len(hostServicesElements(d)) == 0 || len(hostServiceNtpdList(d)) > 0
+}
+
+func hostServicesElements(d hostServicesGetter) []map[string]interface{} {
+	set, ok := d.Get("services").(*schema.Set)
+	if !ok {
+		return nil
+	}
+	var elems []map[string]interface{}
+	for _, v := range set.List() {
+		if m, ok := v.(map[string]interface{}); ok {
+			elems = append(elems, m)
+		}
+	}
+	return elems
+}
+
+// readHostServices returns the services known to the host's service system.
+func readHostServices(ctx context.Context, hostObject *object.HostSystem) ([]types.HostService, error) {
+	serviceSystem, err := hostObject.ConfigManager().ServiceSystem(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get host service system: %v", err)
+	}
+	return serviceSystem.Service(ctx)
+}
+
+// readHostManagedServices reads the running state and policy of the services
+// currently tracked in the service block. Services that no longer exist on the
+// host are dropped so that they show up as a diff.
+func readHostManagedServices(ctx context.Context, hostObject *object.HostSystem, d *schema.ResourceData) ([]interface{}, error) {
+	managed := hostServiceList(d)
+	if len(managed) == 0 {
+		return nil, nil
+	}
+	available, err := readHostServices(ctx, hostObject)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []interface{}
+	for _, v := range managed {
+		m := v.(map[string]interface{})
+		key := m["key"].(string)
+		for _, svc := range available {
+			if svc.Key != key {
+				continue
+			}
+			policy := ""
+			if m["policy"].(string) != "" {
+				policy = svc.Policy
+			}
+			result = append(result, map[string]interface{}{
+				"key":     svc.Key,
+				"running": svc.Running,
+				"policy":  policy,
+			})
+			break
+		}
+	}
+	return result, nil
+}
+
+// applyHostServices starts or stops the supplied services and updates their
+// startup policy so that they match the configuration.
+func applyHostServices(ctx context.Context, hostObject *object.HostSystem, services []interface{}) error {
+	serviceSystem, err := hostObject.ConfigManager().ServiceSystem(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get host service system: %v", err)
+	}
+	available, err := serviceSystem.Service(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list host services: %v", err)
+	}
+	if err := validateHostServices(services, false, available); err != nil {
+		return err
+	}
+
+	for _, v := range services {
+		m := v.(map[string]interface{})
+		key := m["key"].(string)
+		running := m["running"].(bool)
+		policy := m["policy"].(string)
+
+		idx := slices.IndexFunc(available, func(svc types.HostService) bool { return svc.Key == key })
+		current := available[idx]
+
+		if policy != "" && policy != current.Policy {
+			log.Printf("[DEBUG] Updating policy for service %s to %s", key, policy)
+			if err := serviceSystem.UpdatePolicy(ctx, key, policy); err != nil {
+				return fmt.Errorf("failed to update policy for service %s: %v", key, err)
+			}
+		}
+
+		switch {
+		case running && !current.Running:
+			log.Printf("[DEBUG] Starting service %s", key)
+			if err := serviceSystem.Start(ctx, key); err != nil {
+				return fmt.Errorf("failed to start service %s: %v", key, err)
+			}
+		case !running && current.Running:
+			log.Printf("[DEBUG] Stopping service %s", key)
+			if err := serviceSystem.Stop(ctx, key); err != nil {
+				return fmt.Errorf("failed to stop service %s: %v", key, err)
+			}
+		}
+	}
+	return nil
+}
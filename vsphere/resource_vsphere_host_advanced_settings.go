@@ -0,0 +1,251 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+)
+
+func resourceVSphereHostAdvancedSettings() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceVSphereHostAdvancedSettingsCreate,
+		Read:          resourceVSphereHostAdvancedSettingsRead,
+		Update:        resourceVSphereHostAdvancedSettingsUpdate,
+		Delete:        resourceVSphereHostAdvancedSettingsDelete,
+		CustomizeDiff: resourceVSphereHostAdvancedSettingsCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereHostAdvancedSettingsImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"host_system_id": {
+				Type:        schema.TypeString,
+				Description: "The managed object ID of the host to manage the advanced settings of.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"settings": {
+				Type:        schema.TypeMap,
+				Description: "A map of advanced setting names and values to apply to the host. Only the settings in this map are managed.",
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"restore_on_destroy": {
+				Type:        schema.TypeBool,
+				Description: "Restore the managed settings to their default values when they are removed from the settings map or when the resource is destroyed.",
+				Optional:    true,
+				Default:     false,
+			},
+		},
+	}
+}
+
+func resourceVSphereHostAdvancedSettingsCreate(d *schema.ResourceData, meta interface{}) error {
+	hsID := d.Get("host_system_id").(string)
+	if err := applyHostAdvancedSettings(meta, hsID, d.Get("settings").(map[string]interface{}), nil); err != nil {
+		return err
+	}
+
+	d.SetId(hsID)
+	return resourceVSphereHostAdvancedSettingsRead(d, meta)
+}
+
+func resourceVSphereHostAdvancedSettingsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).vimClient
+	om, err := hostOptionManagerFromHostSystemID(client, d.Id())
+	if err != nil {
+		if viapi.IsManagedObjectNotFoundError(err) {
+			log.Printf("[DEBUG] Host %q not found, removing advanced settings from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error loading host option manager: %s", err)
+	}
+
+	settings := make(map[string]interface{})
+	for k, v := range d.Get("settings").(map[string]interface{}) {
+		value, ok, err := readHostAdvancedSetting(om, k)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			log.Printf("[DEBUG] Advanced setting %q no longer exists on host %q", k, d.Id())
+			continue
+		}
+		settings[k] = value
+		// Keep the configured representation of a value, such as "1" for a
+		// boolean, as long as it is equivalent to the value on the host.
+		if s, ok := v.(string); ok && hostAdvancedSettingEquivalent(value, s) {
+			settings[k] = s
+		}
+	}
+
+	if err := d.Set("host_system_id", d.Id()); err != nil {
+		return err
+	}
+	return d.Set("settings", settings)
+}
+
+func resourceVSphereHostAdvancedSettingsUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange("settings") {
+		o, n := d.GetChange("settings")
+		oldSettings := o.(map[string]interface{})
+		newSettings := n.(map[string]interface{})
+
+		changed := make(map[string]interface{})
+		for k, v := range newSettings {
+			if ov, ok := oldSettings[k]; !ok || ov != v {
+				changed[k] = v
+			}
+		}
+		var removed []string
+		if d.Get("restore_on_destroy").(bool) {
+			for k := range oldSettings {
+				if _, ok := newSettings[k]; !ok {
+					removed = append(removed, k)
+				}
+			}
+		}
+
+		if err := applyHostAdvancedSettings(meta, d.Id(), changed, removed); err != nil {
+			return err
+		}
+	}
+
+	return resourceVSphereHostAdvancedSettingsRead(d, meta)
+}
+
+func resourceVSphereHostAdvancedSettingsDelete(d *schema.ResourceData, meta interface{}) error {
+	if !d.Get("restore_on_destroy").(bool) {
+		log.Printf("[DEBUG] Leaving advanced settings on host %q in place", d.Id())
+		return nil
+	}
+
+	var keys []string
+	for k := range d.Get("settings").(map[string]interface{}) {
+		keys = append(keys, k)
+	}
+	return applyHostAdvancedSettings(meta, d.Id(), nil, keys)
+}
+
+func resourceVSphereHostAdvancedSettingsImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*Client).vimClient
+	if _, err := hostOptionManagerFromHostSystemID(client, d.Id()); err != nil {
+		return nil, fmt.Errorf("error loading host option manager: %s", err)
+	}
+	if err := d.Set("host_system_id", d.Id()); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceVSphereHostAdvancedSettingsCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// Validate the settings against the option definitions of the host when
+	// both are known, so that unknown keys and values of the wrong type are
+	// caught during plan rather than apply.
+	if !d.NewValueKnown("host_system_id") || !d.NewValueKnown("settings") {
+		return nil
+	}
+	client, ok := meta.(*Client)
+	if !ok || client.vimClient == nil {
+		return nil
+	}
+	om, err := hostOptionManagerFromHostSystemID(client.vimClient, d.Get("host_system_id").(string))
+	if err != nil {
+		log.Printf("[DEBUG] Skipping advanced settings validation: %s", err)
+		return nil
+	}
+	defs, err := hostOptionDefinitions(client.vimClient, om)
+	if err != nil {
+		return err
+	}
+	_, err = expandHostOptionValues(defs, d.Get("settings").(map[string]interface{}))
+	return err
+}
+
+// applyHostAdvancedSettings updates the supplied settings on a host and
+// restores the settings named in reset to their default values.
+func applyHostAdvancedSettings(meta interface{}, hsID string, settings map[string]interface{}, reset []string) error {
+	if len(settings) == 0 && len(reset) == 0 {
+		return nil
+	}
+	client := meta.(*Client).vimClient
+	om, err := hostOptionManagerFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host option manager: %s", err)
+	}
+	defs, err := hostOptionDefinitions(client, om)
+	if err != nil {
+		return err
+	}
+
+	values, err := expandHostOptionValues(defs, settings)
+	if err != nil {
+		return err
+	}
+	defaults, err := hostOptionDefaultValues(defs, reset)
+	if err != nil {
+		return err
+	}
+	values = append(values, defaults...)
+	if len(values) == 0 {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Updating %d advanced settings on host %q", len(values), hsID)
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	if err := om.Update(ctx, values); err != nil {
+		return fmt.Errorf("error updating advanced settings on host %q: %s", hsID, err)
+	}
+	return nil
+}
+
+// readHostAdvancedSetting reads the current value of an advanced setting. The
+// boolean return value is false if the setting does not exist on the host.
+func readHostAdvancedSetting(om *object.OptionManager, key string) (string, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	values, err := om.Query(ctx, key)
+	if err != nil {
+		if viapi.IsInvalidNameError(err) {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("error reading advanced setting %q: %s", key, err)
+	}
+	for _, v := range values {
+		if ov := v.GetOptionValue(); ov.Key == key {
+			return hostOptionValueToString(ov.Value), true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// hostAdvancedSettingEquivalent returns true if a configured value represents
+// the same value as the one read from the host, such as "1" and "true".
+func hostAdvancedSettingEquivalent(actual, configured string) bool {
+	if actual == configured {
+		return true
+	}
+	for _, t := range []types.BaseOptionType{&types.BoolOption{}, &types.LongOption{}, &types.FloatOption{}} {
+		def := types.OptionDef{OptionType: t}
+		a, err := hostOptionValueFromString(def, actual)
+		if err != nil {
+			continue
+		}
+		c, err := hostOptionValueFromString(def, configured)
+		if err != nil {
+			continue
+		}
+		return a == c
+	}
+	return false
+}
@@ -0,0 +1,153 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/vmware/govmomi/vim25/types"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/testhelper"
+)
+
+func TestAccResourceVSphereHostAdvancedSettings_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			RunSweepers()
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostAdvancedSettingsConfig("1", "128"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vsphere_host_advanced_settings.settings", "settings.UserVars.SuppressShellWarning", "1"),
+					resource.TestCheckResourceAttr("vsphere_host_advanced_settings.settings", "settings.Net.TcpipHeapMax", "128"),
+				),
+			},
+			{
+				Config: testAccResourceVSphereHostAdvancedSettingsConfig("0", "256"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vsphere_host_advanced_settings.settings", "settings.UserVars.SuppressShellWarning", "0"),
+					resource.TestCheckResourceAttr("vsphere_host_advanced_settings.settings", "settings.Net.TcpipHeapMax", "256"),
+				),
+			},
+			{
+				Config:      testAccResourceVSphereHostAdvancedSettingsConfig("not-a-number", "256"),
+				ExpectError: regexp.MustCompile("expects an integer value"),
+			},
+		},
+	})
+}
+
+func TestHostOptionValueFromString(t *testing.T) {
+	cases := []struct {
+		name     string
+		def      types.OptionDef
+		value    string
+		expected interface{}
+		err      bool
+	}{
+		{
+			name:     "bool",
+			def:      types.OptionDef{OptionType: &types.BoolOption{}},
+			value:    "true",
+			expected: true,
+		},
+		{
+			name:     "int",
+			def:      types.OptionDef{OptionType: &types.IntOption{Min: 0, Max: 10}},
+			value:    "5",
+			expected: int32(5),
+		},
+		{
+			name:  "int out of range",
+			def:   types.OptionDef{OptionType: &types.IntOption{Min: 0, Max: 10}},
+			value: "11",
+			err:   true,
+		},
+		{
+			name:     "long",
+			def:      types.OptionDef{OptionType: &types.LongOption{Min: 0, Max: 1024}},
+			value:    "512",
+			expected: int64(512),
+		},
+		{
+			name:     "string",
+			def:      types.OptionDef{OptionType: &types.StringOption{}},
+			value:    "udp://syslog.example.com:514",
+			expected: "udp://syslog.example.com:514",
+		},
+		{
+			name: "choice",
+			def: types.OptionDef{OptionType: &types.ChoiceOption{ChoiceInfo: []types.BaseElementDescription{
+				&types.ElementDescription{Key: "low"},
+				&types.ElementDescription{Key: "high"},
+			}}},
+			value:    "high",
+			expected: "high",
+		},
+		{
+			name: "invalid choice",
+			def: types.OptionDef{OptionType: &types.ChoiceOption{ChoiceInfo: []types.BaseElementDescription{
+				&types.ElementDescription{Key: "low"},
+			}}},
+			value: "high",
+			err:   true,
+		},
+		{
+			name:  "read-only",
+			def:   types.OptionDef{OptionType: &types.StringOption{OptionType: types.OptionType{ValueIsReadonly: types.NewBool(true)}}},
+			value: "foo",
+			err:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := hostOptionValueFromString(tc.def, tc.value)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected error, got %#v", actual)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if actual != tc.expected {
+				t.Fatalf("expected %#v, got %#v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func testAccResourceVSphereHostAdvancedSettingsConfig(suppressShellWarning, heapMax string) string {
+	return fmt.Sprintf(`
+%s
+
+data "vsphere_host" "esxi_host" {
+  name          = "%s"
+  datacenter_id = data.vsphere_datacenter.rootdc1.id
+}
+
+resource "vsphere_host_advanced_settings" "settings" {
+  host_system_id = data.vsphere_host.esxi_host.id
+
+  settings = {
+    "UserVars.SuppressShellWarning" = "%s"
+    "Net.TcpipHeapMax"              = "%s"
+  }
+
+  restore_on_destroy = true
+}
+`, testhelper.ConfigDataRootDC1(),
+		os.Getenv("TF_VAR_VSPHERE_ESXI3"),
+		suppressShellWarning,
+		heapMax)
+}
@@ -0,0 +1,318 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+)
+
+func resourceVSphereHostCertificate() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVSphereHostCertificateCreate,
+		Read:   resourceVSphereHostCertificateRead,
+		Update: resourceVSphereHostCertificateUpdate,
+		Delete: resourceVSphereHostCertificateDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereHostCertificateImport,
+		},
+		CustomizeDiff: resourceVSphereHostCertificateCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"host_system_id": {
+				Type:        schema.TypeString,
+				Description: "The managed object ID of the host to manage the certificate of.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"csr_distinguished_name": {
+				Type:          schema.TypeString,
+				Description:   "The distinguished name to use in the certificate signing request, such as CN=esxi-01.example.com,O=Example.",
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"csr_use_ip_address"},
+			},
+			"csr_use_ip_address": {
+				Type:          schema.TypeBool,
+				Description:   "Use the IP address of the host instead of its host name as the common name of the certificate signing request.",
+				Optional:      true,
+				Default:       false,
+				ForceNew:      true,
+				ConflictsWith: []string{"csr_distinguished_name"},
+			},
+			"csr_trigger": {
+				Type:        schema.TypeString,
+				Description: "An arbitrary value that, when changed, generates a new key pair and certificate signing request on the host.",
+				Optional:    true,
+			},
+			"csr": {
+				Type:        schema.TypeString,
+				Description: "The PEM encoded certificate signing request generated by the host.",
+				Computed:    true,
+			},
+			"certificate": {
+				Type:         schema.TypeString,
+				Description:  "The PEM encoded certificate to install on the host, signed from the certificate signing request.",
+				Optional:     true,
+				ValidateFunc: validateCertificatePEM,
+			},
+			"ca_certificates": {
+				Type:        schema.TypeList,
+				Description: "The PEM encoded CA certificates of the chain of the certificate, added to the trusted CA certificates of the host.",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateCertificatePEM,
+				},
+			},
+			"subject": {
+				Type:        schema.TypeString,
+				Description: "The subject of the certificate installed on the host.",
+				Computed:    true,
+			},
+			"issuer": {
+				Type:        schema.TypeString,
+				Description: "The issuer of the certificate installed on the host.",
+				Computed:    true,
+			},
+			"not_before": {
+				Type:        schema.TypeString,
+				Description: "The start of the validity period of the certificate installed on the host, in RFC 3339 format.",
+				Computed:    true,
+			},
+			"not_after": {
+				Type:        schema.TypeString,
+				Description: "The expiry date of the certificate installed on the host, in RFC 3339 format.",
+				Computed:    true,
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Description: "The status of the certificate installed on the host, such as good, expiring or expired.",
+				Computed:    true,
+			},
+			"thumbprint": {
+				Type:        schema.TypeString,
+				Description: "The SHA-1 thumbprint of the certificate installed on the host.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceVSphereHostCertificateCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).vimClient
+	hsID := d.Get("host_system_id").(string)
+	cm, err := hostCertificateManagerFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host certificate manager: %s", err)
+	}
+
+	// The CSR is only generated when the resource is created or csr_trigger
+	// changes, as generating a new one replaces the key pair that the signed
+	// certificate must match.
+	csr, err := generateHostCertificateSigningRequest(d, cm)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(hsID)
+	_ = d.Set("csr", csr)
+	if err := resourceVSphereHostCertificateApply(d, meta, cm); err != nil {
+		return err
+	}
+
+	return resourceVSphereHostCertificateRead(d, meta)
+}
+
+func resourceVSphereHostCertificateRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).vimClient
+	cm, err := hostCertificateManagerFromHostSystemID(client, d.Id())
+	if err != nil {
+		if viapi.IsManagedObjectNotFoundError(err) {
+			log.Printf("[DEBUG] Host %q not found, removing certificate from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error loading host certificate manager: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	info, err := cm.CertificateInfo(ctx)
+	if err != nil {
+		return fmt.Errorf("error reading host certificate: %s", err)
+	}
+	_ = d.Set("host_system_id", d.Id())
+	_ = d.Set("subject", info.Subject)
+	_ = d.Set("issuer", info.Issuer)
+	_ = d.Set("status", info.Status)
+	_ = d.Set("thumbprint", info.ThumbprintSHA1)
+	if info.NotBefore != nil {
+		_ = d.Set("not_before", info.NotBefore.Format(time.RFC3339))
+	}
+	if info.NotAfter != nil {
+		_ = d.Set("not_after", info.NotAfter.Format(time.RFC3339))
+	}
+
+	// The certificate cannot be read back from the host, so it is compared to
+	// the host by its thumbprint. A different certificate on the host clears
+	// the certificate in the state so that the configured one is installed
+	// again.
+	if cert := d.Get("certificate").(string); cert != "" && info.ThumbprintSHA1 != "" {
+		thumbprint, err := certificateThumbprintSHA1(cert)
+		if err != nil || thumbprint != info.ThumbprintSHA1 {
+			log.Printf("[DEBUG] Certificate on host %q differs from the configured certificate", d.Id())
+			_ = d.Set("certificate", "")
+		}
+	}
+
+	caCerts, err := cm.ListCACertificates(ctx)
+	if err != nil {
+		return fmt.Errorf("error listing CA certificates: %s", err)
+	}
+	var trusted []string
+	for _, c := range structure.SliceInterfacesToStrings(d.Get("ca_certificates").([]interface{})) {
+		if certificatePEMListContains(caCerts, c) {
+			trusted = append(trusted, c)
+		}
+	}
+	return d.Set("ca_certificates", trusted)
+}
+
+func resourceVSphereHostCertificateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).vimClient
+	cm, err := hostCertificateManagerFromHostSystemID(client, d.Id())
+	if err != nil {
+		return fmt.Errorf("error loading host certificate manager: %s", err)
+	}
+	if d.HasChange("csr_trigger") {
+		csr, err := generateHostCertificateSigningRequest(d, cm)
+		if err != nil {
+			return err
+		}
+		// The installed certificate does not match the new key pair, so it is
+		// cleared from the state until a certificate signed from the new CSR
+		// is installed.
+		_ = d.Set("csr", csr)
+		_ = d.Set("certificate", "")
+	}
+	if err := resourceVSphereHostCertificateApply(d, meta, cm); err != nil {
+		return err
+	}
+
+	return resourceVSphereHostCertificateRead(d, meta)
+}
+
+func resourceVSphereHostCertificateCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	// The certificate can only be signed once the CSR has been generated, when
+	// the resource is created or csr_trigger changes.
+	cert := d.Get("certificate").(string)
+	if d.Id() == "" && cert != "" {
+		return fmt.Errorf("certificate cannot be set when the resource is created, set it once the csr exported by the resource has been signed")
+	}
+	if d.HasChange("csr_trigger") {
+		if d.HasChange("certificate") && cert != "" {
+			return fmt.Errorf("certificate cannot be changed together with csr_trigger, set it once the new csr has been signed")
+		}
+		return d.SetNewComputed("csr")
+	}
+
+	// A certificate that was not signed from the CSR of the resource is
+	// rejected by the host, so it is rejected before it is installed. The CSR
+	// is not known for imported hosts.
+	csr := d.Get("csr").(string)
+	if cert == "" || csr == "" || !d.HasChange("certificate") || !d.NewValueKnown("certificate") {
+		return nil
+	}
+	ok, err := certificateMatchesCSR(cert, csr)
+	if err != nil {
+		return fmt.Errorf("error comparing certificate to csr: %s", err)
+	}
+	if !ok {
+		return fmt.Errorf("certificate was not signed from the csr of the resource")
+	}
+	return nil
+}
+
+func resourceVSphereHostCertificateDelete(d *schema.ResourceData, _ interface{}) error {
+	// A host always has a certificate, so the installed certificate and the
+	// trusted CA certificates are left in place.
+	log.Printf("[DEBUG] Removing certificate of host %q from state, the certificate is left on the host", d.Id())
+	return nil
+}
+
+func resourceVSphereHostCertificateImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*Client).vimClient
+	if _, err := hostsystem.FromID(client, d.Id()); err != nil {
+		return nil, err
+	}
+	if err := d.Set("host_system_id", d.Id()); err != nil {
+		return nil, err
+	}
+	if err := d.Set("csr_use_ip_address", false); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// generateHostCertificateSigningRequest generates a new key pair and CSR on
+// the host, using the distinguished name in the resource data if set.
+func generateHostCertificateSigningRequest(d *schema.ResourceData, cm *object.HostCertificateManager) (string, error) {
+	log.Printf("[DEBUG] Generating certificate signing request on host %q", cm.Host.Reference().Value)
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	var csr string
+	var err error
+	if dn := d.Get("csr_distinguished_name").(string); dn != "" {
+		csr, err = cm.GenerateCertificateSigningRequestByDn(ctx, dn)
+	} else {
+		csr, err = cm.GenerateCertificateSigningRequest(ctx, d.Get("csr_use_ip_address").(bool))
+	}
+	if err != nil {
+		return "", fmt.Errorf("error generating certificate signing request: %s", err)
+	}
+	return csr, nil
+}
+
+// resourceVSphereHostCertificateApply adds the CA certificates in the
+// resource data to the host and installs the certificate. The CA certificates
+// are added first, as the host validates the chain of the certificate when it
+// is installed.
+func resourceVSphereHostCertificateApply(d *schema.ResourceData, meta interface{}, cm *object.HostCertificateManager) error {
+	client := meta.(*Client).vimClient
+	if d.HasChange("ca_certificates") {
+		caCerts := structure.SliceInterfacesToStrings(d.Get("ca_certificates").([]interface{}))
+		if err := addHostCACertificates(cm, caCerts); err != nil {
+			return err
+		}
+	}
+
+	cert := d.Get("certificate").(string)
+	if cert == "" || !d.HasChange("certificate") {
+		return nil
+	}
+	thumbprint, err := certificateThumbprintSHA1(cert)
+	if err != nil {
+		return fmt.Errorf("error parsing certificate: %s", err)
+	}
+
+	log.Printf("[DEBUG] Installing certificate %s on host %q", thumbprint, d.Id())
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	if err := cm.InstallServerCertificate(ctx, cert); err != nil {
+		return fmt.Errorf("error installing certificate: %s", err)
+	}
+
+	// vCenter Server keeps trusting the previous certificate of the host
+	// until the host is reconnected with the thumbprint of the new one.
+	return reconnectHostWithThumbprint(client, cm.Host, thumbprint)
+}
@@ -0,0 +1,125 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/testhelper"
+)
+
+func TestAccResourceVSphereHostCertificate_csr(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			RunSweepers()
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostCertificateConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("vsphere_host_certificate.cert", "csr", regexp.MustCompile("BEGIN CERTIFICATE REQUEST")),
+					resource.TestCheckResourceAttrSet("vsphere_host_certificate.cert", "not_after"),
+					resource.TestCheckResourceAttrSet("vsphere_host_certificate.cert", "thumbprint"),
+				),
+			},
+		},
+	})
+}
+
+func TestCertificatePEMListContains(t *testing.T) {
+	a := testGenerateCertificatePEM(t, "a.example.com")
+	b := testGenerateCertificatePEM(t, "b.example.com")
+
+	if !certificatePEMListContains([]string{b, a}, a) {
+		t.Fatalf("expected list to contain certificate")
+	}
+	// Formatting differences, such as line endings, do not matter.
+	if !certificatePEMListContains([]string{strings.ReplaceAll(a, "\n", "\r\n")}, a) {
+		t.Fatalf("expected list to contain certificate with different line endings")
+	}
+	if certificatePEMListContains([]string{b}, a) {
+		t.Fatalf("expected list not to contain certificate")
+	}
+	if certificatePEMListContains([]string{a}, "not a certificate") {
+		t.Fatalf("expected invalid certificate not to be found")
+	}
+}
+
+func TestCertificateMatchesCSR(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{Subject: pkix.Name{CommonName: "a.example.com"}}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csr := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}))
+
+	ok, err := certificateMatchesCSR(testGenerateCertificatePEMWithKey(t, "a.example.com", key), csr)
+	if err != nil || !ok {
+		t.Fatalf("expected certificate to match csr, got %t, %v", ok, err)
+	}
+	ok, err = certificateMatchesCSR(testGenerateCertificatePEM(t, "a.example.com"), csr)
+	if err != nil || ok {
+		t.Fatalf("expected certificate not to match csr, got %t, %v", ok, err)
+	}
+	if _, err := certificateMatchesCSR(testGenerateCertificatePEM(t, "a.example.com"), "not a csr"); err == nil {
+		t.Fatalf("expected error for invalid csr")
+	}
+}
+
+func testGenerateCertificatePEM(t *testing.T, cn string) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return testGenerateCertificatePEMWithKey(t, cn, key)
+}
+
+func testGenerateCertificatePEMWithKey(t *testing.T, cn string, key *ecdsa.PrivateKey) string {
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func testAccResourceVSphereHostCertificateConfig() string {
+	return fmt.Sprintf(`
+%s
+
+data "vsphere_host" "esxi_host" {
+  name          = "%s"
+  datacenter_id = data.vsphere_datacenter.rootdc1.id
+}
+
+resource "vsphere_host_certificate" "cert" {
+  host_system_id = data.vsphere_host.esxi_host.id
+}
+`, testhelper.ConfigDataRootDC1(),
+		os.Getenv("TF_VAR_VSPHERE_ESXI3"))
+}
@@ -0,0 +1,233 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+)
+
+func resourceVSphereHostFirewallRuleset() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceVSphereHostFirewallRulesetCreate,
+		Read:          resourceVSphereHostFirewallRulesetRead,
+		Update:        resourceVSphereHostFirewallRulesetUpdate,
+		Delete:        resourceVSphereHostFirewallRulesetDelete,
+		CustomizeDiff: resourceVSphereHostFirewallRulesetCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereHostFirewallRulesetImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"host_system_id": {
+				Type:        schema.TypeString,
+				Description: "The managed object ID of the host to manage the firewall ruleset on.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"key": {
+				Type:        schema.TypeString,
+				Description: "The key of the firewall ruleset, such as sshServer or ntpClient.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Description: "Whether the firewall ruleset is enabled.",
+				Optional:    true,
+				Default:     true,
+			},
+			"allowed_all_ip": {
+				Type:        schema.TypeBool,
+				Description: "Allow connections from any IP address. Must be false when allowed_ip_addresses or allowed_ip_networks are set.",
+				Optional:    true,
+				Default:     true,
+			},
+			"allowed_ip_addresses": {
+				Type:        schema.TypeSet,
+				Description: "The IP addresses allowed to connect to the services of the ruleset.",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsIPAddress,
+				},
+			},
+			"allowed_ip_networks": {
+				Type:        schema.TypeSet,
+				Description: "The networks, in CIDR notation, allowed to connect to the services of the ruleset.",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateHostFirewallRulesetNetwork,
+				},
+			},
+			"label": {
+				Type:        schema.TypeString,
+				Description: "The display label of the firewall ruleset.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceVSphereHostFirewallRulesetCreate(d *schema.ResourceData, meta interface{}) error {
+	hsID := d.Get("host_system_id").(string)
+	key := d.Get("key").(string)
+	saveHostFirewallRulesetID(d, hsID, key)
+
+	if err := resourceVSphereHostFirewallRulesetApply(d, meta, hsID, key); err != nil {
+		d.SetId("")
+		return err
+	}
+
+	return resourceVSphereHostFirewallRulesetRead(d, meta)
+}
+
+func resourceVSphereHostFirewallRulesetRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).vimClient
+	hsID, key, err := splitHostFirewallRulesetID(d.Id())
+	if err != nil {
+		return err
+	}
+	fs, err := hostFirewallSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		if viapi.IsManagedObjectNotFoundError(err) {
+			log.Printf("[DEBUG] Host %q not found, removing firewall ruleset %q from state", hsID, key)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error loading host firewall system: %s", err)
+	}
+
+	rs, err := hostFirewallRulesetFromKey(fs, key)
+	if err != nil {
+		return fmt.Errorf("error fetching firewall ruleset data: %s", err)
+	}
+
+	_ = d.Set("host_system_id", hsID)
+	_ = d.Set("key", rs.Key)
+	_ = d.Set("label", rs.Label)
+	_ = d.Set("enabled", rs.Enabled)
+	for k, v := range flattenHostFirewallRulesetIPList(rs.AllowedHosts) {
+		if err := d.Set(k, v); err != nil {
+			return fmt.Errorf("error setting %s: %s", k, err)
+		}
+	}
+
+	return nil
+}
+
+func resourceVSphereHostFirewallRulesetUpdate(d *schema.ResourceData, meta interface{}) error {
+	hsID, key, err := splitHostFirewallRulesetID(d.Id())
+	if err != nil {
+		return err
+	}
+	if err := resourceVSphereHostFirewallRulesetApply(d, meta, hsID, key); err != nil {
+		return err
+	}
+
+	return resourceVSphereHostFirewallRulesetRead(d, meta)
+}
+
+func resourceVSphereHostFirewallRulesetDelete(d *schema.ResourceData, _ interface{}) error {
+	// Firewall rulesets are built into the host and cannot be removed, so the
+	// ruleset is left in its current state.
+	log.Printf("[DEBUG] Removing firewall ruleset %q from state, the ruleset is left unchanged on the host", d.Id())
+	return nil
+}
+
+func resourceVSphereHostFirewallRulesetImport(d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	hsID, key, err := splitHostFirewallRulesetID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	if err := d.Set("host_system_id", hsID); err != nil {
+		return nil, err
+	}
+	if err := d.Set("key", key); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceVSphereHostFirewallRulesetCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Get("allowed_all_ip").(bool) && (d.Get("allowed_ip_addresses").(*schema.Set).Len() > 0 || d.Get("allowed_ip_networks").(*schema.Set).Len() > 0) {
+		return fmt.Errorf("allowed_all_ip must be set to false when allowed_ip_addresses or allowed_ip_networks are set")
+	}
+
+	// Required rulesets cannot be disabled. The ruleset is only looked up when
+	// it is being disabled, and when the host and key are known.
+	if d.Get("enabled").(bool) || (d.Id() != "" && !d.HasChange("enabled")) {
+		return nil
+	}
+	if !d.NewValueKnown("host_system_id") || !d.NewValueKnown("key") {
+		return nil
+	}
+	client, ok := meta.(*Client)
+	if !ok {
+		return nil
+	}
+	hsID := d.Get("host_system_id").(string)
+	key := d.Get("key").(string)
+	fs, err := hostFirewallSystemFromHostSystemID(client.vimClient, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host firewall system: %s", err)
+	}
+	rs, err := hostFirewallRulesetFromKey(fs, key)
+	if err != nil {
+		return err
+	}
+	if rs.Required {
+		return fmt.Errorf("firewall ruleset %q is required by host %q and cannot be disabled", key, hsID)
+	}
+	return nil
+}
+
+// resourceVSphereHostFirewallRulesetApply applies the enabled state and the
+// allowed hosts of the ruleset in the resource data to the host.
+func resourceVSphereHostFirewallRulesetApply(d *schema.ResourceData, meta interface{}, hsID, key string) error {
+	client := meta.(*Client).vimClient
+	fs, err := hostFirewallSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host firewall system: %s", err)
+	}
+	rs, err := hostFirewallRulesetFromKey(fs, key)
+	if err != nil {
+		return err
+	}
+
+	if d.IsNewResource() || d.HasChanges("allowed_all_ip", "allowed_ip_addresses", "allowed_ip_networks") {
+		allowed, err := expandHostFirewallRulesetIPList(d)
+		if err != nil {
+			return err
+		}
+		log.Printf("[DEBUG] Updating allowed hosts of firewall ruleset %q on host %q", key, hsID)
+		if err := updateHostFirewallRulesetAllowedHosts(fs, key, allowed); err != nil {
+			return fmt.Errorf("error updating allowed hosts of firewall ruleset %q: %s", key, err)
+		}
+	}
+
+	enabled := d.Get("enabled").(bool)
+	if enabled == rs.Enabled {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	if enabled {
+		log.Printf("[DEBUG] Enabling firewall ruleset %q on host %q", key, hsID)
+		err = fs.EnableRuleset(ctx, key)
+	} else {
+		log.Printf("[DEBUG] Disabling firewall ruleset %q on host %q", key, hsID)
+		err = fs.DisableRuleset(ctx, key)
+	}
+	if err != nil {
+		return fmt.Errorf("error changing state of firewall ruleset %q: %s", key, err)
+	}
+	return nil
+}
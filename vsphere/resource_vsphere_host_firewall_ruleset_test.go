@@ -0,0 +1,112 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/testhelper"
+)
+
+func TestAccResourceVSphereHostFirewallRuleset_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			RunSweepers()
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostFirewallRulesetConfig(`
+  allowed_all_ip      = false
+  allowed_ip_networks = ["10.0.0.0/24"]
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vsphere_host_firewall_ruleset.ssh", "enabled", "true"),
+					resource.TestCheckResourceAttr("vsphere_host_firewall_ruleset.ssh", "allowed_all_ip", "false"),
+					resource.TestCheckTypeSetElemAttr("vsphere_host_firewall_ruleset.ssh", "allowed_ip_networks.*", "10.0.0.0/24"),
+				),
+			},
+			{
+				Config: testAccResourceVSphereHostFirewallRulesetConfig(`
+  allowed_all_ip = true
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vsphere_host_firewall_ruleset.ssh", "allowed_all_ip", "true"),
+					resource.TestCheckResourceAttr("vsphere_host_firewall_ruleset.ssh", "allowed_ip_networks.#", "0"),
+				),
+			},
+			{
+				ResourceName:      "vsphere_host_firewall_ruleset.ssh",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccResourceVSphereHostFirewallRulesetConfig(`
+  allowed_ip_addresses = ["10.0.0.10"]
+`),
+				ExpectError: regexp.MustCompile("allowed_all_ip must be set to false"),
+			},
+			{
+				Config: testAccResourceVSphereHostFirewallRulesetConfig(`
+  allowed_all_ip      = false
+  allowed_ip_networks = ["10.0.0.1/24"]
+`),
+				ExpectError: regexp.MustCompile("canonical form"),
+			},
+		},
+	})
+}
+
+func TestValidateHostFirewallRulesetNetwork(t *testing.T) {
+	cases := []struct {
+		value       string
+		expectError bool
+	}{
+		{value: "10.0.0.0/24"},
+		{value: "0.0.0.0/0"},
+		{value: "192.168.1.10/32"},
+		{value: "2001:db8::/32"},
+		{value: "10.0.0.1/24", expectError: true},
+		{value: "2001:DB8::/32", expectError: true},
+		{value: "2001:db8:0::/32", expectError: true},
+		{value: "10.0.0.0", expectError: true},
+		{value: "not-a-network", expectError: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.value, func(t *testing.T) {
+			_, errs := validateHostFirewallRulesetNetwork(tc.value, "allowed_ip_networks")
+			if tc.expectError && len(errs) == 0 {
+				t.Fatalf("expected an error for %q, got none", tc.value)
+			}
+			if !tc.expectError && len(errs) > 0 {
+				t.Fatalf("unexpected error for %q: %v", tc.value, errs)
+			}
+		})
+	}
+}
+
+func testAccResourceVSphereHostFirewallRulesetConfig(allowed string) string {
+	return fmt.Sprintf(`
+%s
+
+data "vsphere_host" "esxi_host" {
+  name          = "%s"
+  datacenter_id = data.vsphere_datacenter.rootdc1.id
+}
+
+resource "vsphere_host_firewall_ruleset" "ssh" {
+  host_system_id = data.vsphere_host.esxi_host.id
+  key            = "sshServer"
+%s}
+`, testhelper.ConfigDataRootDC1(),
+		os.Getenv("TF_VAR_VSPHERE_ESXI3"),
+		allowed)
+}
@@ -0,0 +1,486 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/types"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+)
+
+var hostInternetScsiChapAuthenticationTypeAllowedValues = []string{
+	string(types.HostInternetScsiHbaChapAuthenticationTypeChapDiscouraged),
+	string(types.HostInternetScsiHbaChapAuthenticationTypeChapPreferred),
+	string(types.HostInternetScsiHbaChapAuthenticationTypeChapRequired),
+}
+
+var hostInternetScsiMutualChapAuthenticationTypeAllowedValues = []string{
+	string(types.HostInternetScsiHbaChapAuthenticationTypeChapRequired),
+}
+
+func resourceVSphereHostIscsiAdapter() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceVSphereHostIscsiAdapterCreate,
+		Read:          resourceVSphereHostIscsiAdapterRead,
+		Update:        resourceVSphereHostIscsiAdapterUpdate,
+		Delete:        resourceVSphereHostIscsiAdapterDelete,
+		CustomizeDiff: resourceVSphereHostIscsiAdapterCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereHostIscsiAdapterImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"host_system_id": {
+				Type:        schema.TypeString,
+				Description: "The managed object ID of the host to enable the software iSCSI adapter on.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"iscsi_name": {
+				Type:        schema.TypeString,
+				Description: "The iSCSI qualified name (IQN) of the adapter. If not set, the name generated by the host is used.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"alias": {
+				Type:        schema.TypeString,
+				Description: "The iSCSI alias of the adapter.",
+				Optional:    true,
+			},
+			"chap": {
+				Type:        schema.TypeList,
+				Description: "The CHAP settings the adapter uses to authenticate to its targets.",
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"authentication_type": {
+							Type:         schema.TypeString,
+							Description:  "The CHAP authentication type. Can be one of chapDiscouraged, chapPreferred or chapRequired.",
+							Optional:     true,
+							Default:      string(types.HostInternetScsiHbaChapAuthenticationTypeChapRequired),
+							ValidateFunc: validation.StringInSlice(hostInternetScsiChapAuthenticationTypeAllowedValues, false),
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The CHAP name.",
+							Required:    true,
+						},
+						"secret": {
+							Type:         schema.TypeString,
+							Description:  "The CHAP secret.",
+							Optional:     true,
+							Sensitive:    true,
+							ExactlyOneOf: []string{"chap.0.secret", "chap.0.secret_wo"},
+						},
+						"secret_wo": {
+							Type:         schema.TypeString,
+							Description:  "Write-only CHAP secret. The value is not stored in state.",
+							Optional:     true,
+							Sensitive:    true,
+							WriteOnly:    true,
+							RequiredWith: []string{"chap.0.secret_wo_version"},
+						},
+						"secret_wo_version": {
+							Type:         schema.TypeInt,
+							Description:  "Version of secret_wo. Changing the version updates the CHAP secret of the adapter with the current value of secret_wo.",
+							Optional:     true,
+							RequiredWith: []string{"chap.0.secret_wo"},
+						},
+					},
+				},
+			},
+			"mutual_chap": {
+				Type:        schema.TypeList,
+				Description: "The CHAP settings the targets use to authenticate to the adapter. Requires chap with an authentication_type of chapRequired.",
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"authentication_type": {
+							Type:         schema.TypeString,
+							Description:  "The mutual CHAP authentication type. Must be chapRequired.",
+							Optional:     true,
+							Default:      string(types.HostInternetScsiHbaChapAuthenticationTypeChapRequired),
+							ValidateFunc: validation.StringInSlice(hostInternetScsiMutualChapAuthenticationTypeAllowedValues, false),
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The mutual CHAP name.",
+							Required:    true,
+						},
+						"secret": {
+							Type:         schema.TypeString,
+							Description:  "The mutual CHAP secret.",
+							Optional:     true,
+							Sensitive:    true,
+							ExactlyOneOf: []string{"mutual_chap.0.secret", "mutual_chap.0.secret_wo"},
+						},
+						"secret_wo": {
+							Type:         schema.TypeString,
+							Description:  "Write-only mutual CHAP secret. The value is not stored in state.",
+							Optional:     true,
+							Sensitive:    true,
+							WriteOnly:    true,
+							RequiredWith: []string{"mutual_chap.0.secret_wo_version"},
+						},
+						"secret_wo_version": {
+							Type:         schema.TypeInt,
+							Description:  "Version of secret_wo. Changing the version updates the mutual CHAP secret of the adapter with the current value of secret_wo.",
+							Optional:     true,
+							RequiredWith: []string{"mutual_chap.0.secret_wo"},
+						},
+					},
+				},
+			},
+			"advanced_options": {
+				Type:        schema.TypeMap,
+				Description: "A map of advanced iSCSI parameters, such as LoginTimeout or DelayedAck, and their values. Only the parameters in this map are managed.",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"device": {
+				Type:        schema.TypeString,
+				Description: "The device name of the adapter, such as vmhba65.",
+				Computed:    true,
+			},
+			"initiator_enabled_by_resource": {
+				Type:        schema.TypeBool,
+				Description: "Whether the software iSCSI initiator was enabled when the resource was created. The initiator is only disabled on destroy if it was.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceVSphereHostIscsiAdapterCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).vimClient
+	hsID := d.Get("host_system_id").(string)
+	ss, err := hostStorageSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host storage system: %s", err)
+	}
+
+	hba, err := hostSoftwareInternetScsiHba(client, ss)
+	if err != nil {
+		return err
+	}
+	// An initiator that is already enabled is adopted, and left enabled when
+	// the resource is destroyed.
+	enabled := hba == nil
+	if enabled {
+		log.Printf("[DEBUG] Enabling software iSCSI adapter on host %q", hsID)
+		if err := updateHostSoftwareInternetScsiEnabled(ss, true); err != nil {
+			return fmt.Errorf("error enabling software iSCSI adapter: %s", err)
+		}
+		if hba, err = hostSoftwareInternetScsiHba(client, ss); err != nil {
+			return err
+		}
+		if hba == nil {
+			return fmt.Errorf("software iSCSI adapter not found on host %q after enabling it", hsID)
+		}
+	} else {
+		log.Printf("[DEBUG] Software iSCSI adapter is already enabled on host %q, adopting it", hsID)
+	}
+	d.SetId(hsID)
+	_ = d.Set("initiator_enabled_by_resource", enabled)
+
+	if err := resourceVSphereHostIscsiAdapterApply(d, ss, hba); err != nil {
+		return err
+	}
+
+	return resourceVSphereHostIscsiAdapterRead(d, meta)
+}
+
+func resourceVSphereHostIscsiAdapterRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).vimClient
+	ss, err := hostStorageSystemFromHostSystemID(client, d.Id())
+	if err != nil {
+		if viapi.IsManagedObjectNotFoundError(err) {
+			log.Printf("[DEBUG] Host %q not found, removing software iSCSI adapter from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error loading host storage system: %s", err)
+	}
+	hba, err := hostSoftwareInternetScsiHba(client, ss)
+	if err != nil {
+		return err
+	}
+	if hba == nil {
+		log.Printf("[DEBUG] Software iSCSI adapter is disabled on host %q, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	_ = d.Set("host_system_id", d.Id())
+	_ = d.Set("device", hba.Device)
+	_ = d.Set("iscsi_name", hba.IScsiName)
+	_ = d.Set("alias", hba.IScsiAlias)
+	if err := d.Set("chap", flattenHostInternetScsiChap(d, "chap", hba.AuthenticationProperties.ChapAuthEnabled, hba.AuthenticationProperties.ChapAuthenticationType, hba.AuthenticationProperties.ChapName)); err != nil {
+		return fmt.Errorf("error setting chap: %s", err)
+	}
+	if err := d.Set("mutual_chap", flattenHostInternetScsiChap(d, "mutual_chap", true, hba.AuthenticationProperties.MutualChapAuthenticationType, hba.AuthenticationProperties.MutualChapName)); err != nil {
+		return fmt.Errorf("error setting mutual_chap: %s", err)
+	}
+
+	options := make(map[string]interface{})
+	for k, v := range d.Get("advanced_options").(map[string]interface{}) {
+		for _, opt := range hba.AdvancedOptions {
+			if opt.Key != k {
+				continue
+			}
+			value := hostOptionValueToString(opt.Value)
+			options[k] = value
+			if s, ok := v.(string); ok && hostAdvancedSettingEquivalent(value, s) {
+				options[k] = s
+			}
+		}
+	}
+	return d.Set("advanced_options", options)
+}
+
+func resourceVSphereHostIscsiAdapterUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).vimClient
+	ss, err := hostStorageSystemFromHostSystemID(client, d.Id())
+	if err != nil {
+		return fmt.Errorf("error loading host storage system: %s", err)
+	}
+	hba, err := hostSoftwareInternetScsiHba(client, ss)
+	if err != nil {
+		return err
+	}
+	if hba == nil {
+		return fmt.Errorf("software iSCSI adapter is not enabled on host %q", d.Id())
+	}
+	if err := resourceVSphereHostIscsiAdapterApply(d, ss, hba); err != nil {
+		return err
+	}
+
+	return resourceVSphereHostIscsiAdapterRead(d, meta)
+}
+
+func resourceVSphereHostIscsiAdapterDelete(d *schema.ResourceData, meta interface{}) error {
+	if !d.Get("initiator_enabled_by_resource").(bool) {
+		log.Printf("[DEBUG] Software iSCSI adapter on host %q was not enabled by this resource, leaving it enabled", d.Id())
+		return nil
+	}
+	client := meta.(*Client).vimClient
+	ss, err := hostStorageSystemFromHostSystemID(client, d.Id())
+	if err != nil {
+		return fmt.Errorf("error loading host storage system: %s", err)
+	}
+
+	log.Printf("[DEBUG] Disabling software iSCSI adapter on host %q", d.Id())
+	if err := updateHostSoftwareInternetScsiEnabled(ss, false); err != nil {
+		return fmt.Errorf("error disabling software iSCSI adapter: %s", err)
+	}
+	return nil
+}
+
+func resourceVSphereHostIscsiAdapterImport(d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	if err := d.Set("host_system_id", d.Id()); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceVSphereHostIscsiAdapterCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if len(d.Get("mutual_chap").([]interface{})) == 0 {
+		return nil
+	}
+	chap := d.Get("chap").([]interface{})
+	if len(chap) == 0 || chap[0] == nil ||
+		chap[0].(map[string]interface{})["authentication_type"].(string) != string(types.HostInternetScsiHbaChapAuthenticationTypeChapRequired) {
+		return fmt.Errorf("mutual_chap requires chap with an authentication_type of %s", types.HostInternetScsiHbaChapAuthenticationTypeChapRequired)
+	}
+	return nil
+}
+
+// resourceVSphereHostIscsiAdapterApply applies the name, alias,
+// authentication and advanced options in the resource data to the adapter and
+// rescans the adapter if anything was changed.
+func resourceVSphereHostIscsiAdapterApply(d *schema.ResourceData, ss *object.HostStorageSystem, hba *types.HostInternetScsiHba) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	var changed bool
+
+	if name := d.Get("iscsi_name").(string); name != "" && name != hba.IScsiName {
+		log.Printf("[DEBUG] Updating iSCSI name of adapter %q to %q", hba.Device, name)
+		req := types.UpdateInternetScsiName{
+			This:           ss.Reference(),
+			IScsiHbaDevice: hba.Device,
+			IScsiName:      name,
+		}
+		if _, err := methods.UpdateInternetScsiName(ctx, ss.Client(), &req); err != nil {
+			return fmt.Errorf("error updating iSCSI name: %s", err)
+		}
+		changed = true
+	}
+
+	if alias := d.Get("alias").(string); alias != hba.IScsiAlias {
+		log.Printf("[DEBUG] Updating iSCSI alias of adapter %q to %q", hba.Device, alias)
+		req := types.UpdateInternetScsiAlias{
+			This:           ss.Reference(),
+			IScsiHbaDevice: hba.Device,
+			IScsiAlias:     alias,
+		}
+		if _, err := methods.UpdateInternetScsiAlias(ctx, ss.Client(), &req); err != nil {
+			return fmt.Errorf("error updating iSCSI alias: %s", err)
+		}
+		changed = true
+	}
+
+	if d.IsNewResource() || d.HasChanges("chap", "mutual_chap") {
+		log.Printf("[DEBUG] Updating authentication properties of adapter %q", hba.Device)
+		req := types.UpdateInternetScsiAuthenticationProperties{
+			This:                     ss.Reference(),
+			IScsiHbaDevice:           hba.Device,
+			AuthenticationProperties: expandHostInternetScsiAuthenticationProperties(d),
+		}
+		if _, err := methods.UpdateInternetScsiAuthenticationProperties(ctx, ss.Client(), &req); err != nil {
+			return fmt.Errorf("error updating iSCSI authentication properties: %s", err)
+		}
+		changed = true
+	}
+
+	if d.HasChange("advanced_options") {
+		// Options removed from the map are restored to their defaults.
+		o, n := d.GetChange("advanced_options")
+		var reset []string
+		for k := range o.(map[string]interface{}) {
+			if _, ok := n.(map[string]interface{})[k]; !ok {
+				reset = append(reset, k)
+			}
+		}
+		sort.Strings(reset)
+		options, err := expandHostInternetScsiParamValues(hba, n.(map[string]interface{}), reset)
+		if err != nil {
+			return err
+		}
+		if len(options) > 0 {
+			log.Printf("[DEBUG] Updating advanced options of adapter %q", hba.Device)
+			req := types.UpdateInternetScsiAdvancedOptions{
+				This:           ss.Reference(),
+				IScsiHbaDevice: hba.Device,
+				Options:        options,
+			}
+			if _, err := methods.UpdateInternetScsiAdvancedOptions(ctx, ss.Client(), &req); err != nil {
+				return fmt.Errorf("error updating iSCSI advanced options: %s", err)
+			}
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+	return rescanHostBusAdapter(ss, hba.Device)
+}
+
+// updateHostSoftwareInternetScsiEnabled enables or disables the software
+// iSCSI initiator of a host.
+func updateHostSoftwareInternetScsiEnabled(ss *object.HostStorageSystem, enabled bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	req := types.UpdateSoftwareInternetScsiEnabled{
+		This:    ss.Reference(),
+		Enabled: enabled,
+	}
+	_, err := methods.UpdateSoftwareInternetScsiEnabled(ctx, ss.Client(), &req)
+	return err
+}
+
+// expandHostInternetScsiAuthenticationProperties reads the CHAP and mutual
+// CHAP settings of the adapter from the resource data.
+func expandHostInternetScsiAuthenticationProperties(d *schema.ResourceData) types.HostInternetScsiHbaAuthenticationProperties {
+	obj := types.HostInternetScsiHbaAuthenticationProperties{
+		ChapAuthenticationType:       string(types.HostInternetScsiHbaChapAuthenticationTypeChapProhibited),
+		MutualChapAuthenticationType: string(types.HostInternetScsiHbaChapAuthenticationTypeChapProhibited),
+	}
+	if chap := d.Get("chap").([]interface{}); len(chap) > 0 && chap[0] != nil {
+		m := chap[0].(map[string]interface{})
+		obj.ChapAuthEnabled = true
+		obj.ChapAuthenticationType = m["authentication_type"].(string)
+		obj.ChapName = m["name"].(string)
+		obj.ChapSecret = hostInternetScsiChapSecret(d, "chap")
+	}
+	if chap := d.Get("mutual_chap").([]interface{}); len(chap) > 0 && chap[0] != nil {
+		m := chap[0].(map[string]interface{})
+		obj.MutualChapAuthenticationType = m["authentication_type"].(string)
+		obj.MutualChapName = m["name"].(string)
+		obj.MutualChapSecret = hostInternetScsiChapSecret(d, "mutual_chap")
+	}
+	return obj
+}
+
+// hostInternetScsiChapSecret returns the secret of the CHAP block at key from
+// either secret or secret_wo.
+func hostInternetScsiChapSecret(d *schema.ResourceData, key string) string {
+	if v := d.Get(key + ".0.secret").(string); v != "" {
+		return v
+	}
+	return structure.GetWriteOnlyString(d, key+".0.secret_wo")
+}
+
+// flattenHostInternetScsiChap returns a CHAP block for the resource data. The
+// secret is not returned by the host, so it is carried over from the current
+// resource data, along with the version of the write-only secret.
+func flattenHostInternetScsiChap(d *schema.ResourceData, key string, enabled bool, authType, name string) []interface{} {
+	if !enabled || authType == "" || authType == string(types.HostInternetScsiHbaChapAuthenticationTypeChapProhibited) {
+		return nil
+	}
+	var secret string
+	var version int
+	if chap := d.Get(key).([]interface{}); len(chap) > 0 && chap[0] != nil {
+		secret = chap[0].(map[string]interface{})["secret"].(string)
+		version = chap[0].(map[string]interface{})["secret_wo_version"].(int)
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"authentication_type": authType,
+			"name":                name,
+			"secret":              secret,
+			"secret_wo_version":   version,
+		},
+	}
+}
+
+// expandHostInternetScsiParamValues converts a map of advanced iSCSI
+// parameters into typed parameter values, using the option definitions
+// supported by the adapter. The parameters named in reset are restored to
+// their default values.
+func expandHostInternetScsiParamValues(hba *types.HostInternetScsiHba, options map[string]interface{}, reset []string) ([]types.HostInternetScsiHbaParamValue, error) {
+	defs := make(map[string]types.OptionDef)
+	for _, def := range hba.SupportedAdvancedOptions {
+		defs[def.Key] = def
+	}
+	values, err := expandHostOptionValues(defs, options)
+	if err != nil {
+		return nil, err
+	}
+	defaults, err := hostOptionDefaultValues(defs, reset)
+	if err != nil {
+		return nil, err
+	}
+	values = append(values, defaults...)
+
+	var params []types.HostInternetScsiHbaParamValue
+	for _, v := range values {
+		params = append(params, types.HostInternetScsiHbaParamValue{
+			OptionValue: *v.GetOptionValue(),
+			IsInherited: types.NewBool(false),
+		})
+	}
+	return params, nil
+}
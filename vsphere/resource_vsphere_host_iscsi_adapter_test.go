@@ -0,0 +1,65 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/testhelper"
+)
+
+func TestAccResourceVSphereHostIscsiAdapter_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			RunSweepers()
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostIscsiAdapterConfig("terraform-test"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vsphere_host_iscsi_adapter.iscsi", "alias", "terraform-test"),
+					resource.TestMatchResourceAttr("vsphere_host_iscsi_adapter.iscsi", "device", regexp.MustCompile("^vmhba")),
+					resource.TestCheckResourceAttr("vsphere_host_iscsi_adapter.iscsi", "chap.0.name", "terraform"),
+				),
+			},
+			{
+				Config: testAccResourceVSphereHostIscsiAdapterConfig("terraform-test-updated"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vsphere_host_iscsi_adapter.iscsi", "alias", "terraform-test-updated"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceVSphereHostIscsiAdapterConfig(alias string) string {
+	return fmt.Sprintf(`
+%s
+
+data "vsphere_host" "esxi_host" {
+  name          = "%s"
+  datacenter_id = data.vsphere_datacenter.rootdc1.id
+}
+
+resource "vsphere_host_iscsi_adapter" "iscsi" {
+  host_system_id = data.vsphere_host.esxi_host.id
+  alias          = "%s"
+
+  chap {
+    authentication_type = "chapPreferred"
+    name                = "terraform"
+    secret              = "terraform-secret"
+  }
+}
+`, testhelper.ConfigDataRootDC1(),
+		os.Getenv("TF_VAR_VSPHERE_ESXI3"),
+		alias)
+}
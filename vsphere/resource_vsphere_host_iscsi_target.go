@@ -0,0 +1,373 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/types"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+)
+
+const hostIscsiTargetIDPrefix = "tf-HostIscsiTarget"
+
+// hostIscsiDefaultPort is the default TCP port of an iSCSI target.
+const hostIscsiDefaultPort = 3260
+
+func resourceVSphereHostIscsiTarget() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVSphereHostIscsiTargetCreate,
+		Read:   resourceVSphereHostIscsiTargetRead,
+		Update: resourceVSphereHostIscsiTargetUpdate,
+		Delete: resourceVSphereHostIscsiTargetDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereHostIscsiTargetImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"host_system_id": {
+				Type:        schema.TypeString,
+				Description: "The managed object ID of the host the iSCSI adapter belongs to.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"adapter_device": {
+				Type:        schema.TypeString,
+				Description: "The device name of the iSCSI adapter, such as vmhba65.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"send_target": {
+				Type:        schema.TypeSet,
+				Description: "The send targets used for dynamic discovery.",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address": {
+							Type:        schema.TypeString,
+							Description: "The IP address or host name of the target.",
+							Required:    true,
+						},
+						"port": {
+							Type:         schema.TypeInt,
+							Description:  "The TCP port of the target.",
+							Optional:     true,
+							Default:      hostIscsiDefaultPort,
+							ValidateFunc: validation.IsPortNumber,
+						},
+					},
+				},
+			},
+			"static_target": {
+				Type:        schema.TypeSet,
+				Description: "The statically configured targets.",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address": {
+							Type:        schema.TypeString,
+							Description: "The IP address or host name of the target.",
+							Required:    true,
+						},
+						"port": {
+							Type:         schema.TypeInt,
+							Description:  "The TCP port of the target.",
+							Optional:     true,
+							Default:      hostIscsiDefaultPort,
+							ValidateFunc: validation.IsPortNumber,
+						},
+						"iscsi_name": {
+							Type:        schema.TypeString,
+							Description: "The iSCSI qualified name (IQN) of the target.",
+							Required:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceVSphereHostIscsiTargetCreate(d *schema.ResourceData, meta interface{}) error {
+	hsID := d.Get("host_system_id").(string)
+	device := d.Get("adapter_device").(string)
+
+	sendTargets := d.Get("send_target").(*schema.Set)
+	if err := updateHostIscsiSendTargets(meta, hsID, device, &schema.Set{F: sendTargets.F}, sendTargets); err != nil {
+		return err
+	}
+	staticTargets := d.Get("static_target").(*schema.Set)
+	if err := updateHostIscsiStaticTargets(meta, hsID, device, &schema.Set{F: staticTargets.F}, staticTargets); err != nil {
+		// The resource is not saved to state, so the send targets added above
+		// are removed again.
+		if rerr := updateHostIscsiSendTargets(meta, hsID, device, sendTargets, &schema.Set{F: sendTargets.F}); rerr != nil {
+			log.Printf("[WARN] Could not remove send targets of iSCSI adapter %q after error: %s", device, rerr)
+		}
+		return err
+	}
+	d.SetId(fmt.Sprintf("%s:%s:%s", hostIscsiTargetIDPrefix, hsID, device))
+
+	return resourceVSphereHostIscsiTargetRead(d, meta)
+}
+
+func resourceVSphereHostIscsiTargetRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).vimClient
+	hsID, device, err := splitHostIscsiTargetID(d.Id())
+	if err != nil {
+		return err
+	}
+	ss, err := hostStorageSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		if viapi.IsManagedObjectNotFoundError(err) {
+			log.Printf("[DEBUG] Host %q not found, removing iSCSI targets from state", hsID)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error loading host storage system: %s", err)
+	}
+	hba, err := hostInternetScsiHbaFromDevice(client, ss, device)
+	if err != nil {
+		return err
+	}
+
+	// Only the targets declared in the configuration are tracked, so that the
+	// targets of the adapter managed outside of Terraform do not show up as
+	// drift.
+	sendTargets := d.Get("send_target").(*schema.Set)
+	staticTargets := d.Get("static_target").(*schema.Set)
+	_ = d.Set("host_system_id", hsID)
+	_ = d.Set("adapter_device", device)
+	if err := d.Set("send_target", sendTargets.Intersection(schema.NewSet(sendTargets.F, flattenHostInternetScsiSendTargets(hba)))); err != nil {
+		return fmt.Errorf("error setting send_target: %s", err)
+	}
+	if err := d.Set("static_target", staticTargets.Intersection(schema.NewSet(staticTargets.F, flattenHostInternetScsiStaticTargets(hba)))); err != nil {
+		return fmt.Errorf("error setting static_target: %s", err)
+	}
+	return nil
+}
+
+func resourceVSphereHostIscsiTargetUpdate(d *schema.ResourceData, meta interface{}) error {
+	hsID, device, err := splitHostIscsiTargetID(d.Id())
+	if err != nil {
+		return err
+	}
+	if d.HasChange("send_target") {
+		o, n := d.GetChange("send_target")
+		if err := updateHostIscsiSendTargets(meta, hsID, device, o.(*schema.Set), n.(*schema.Set)); err != nil {
+			return err
+		}
+	}
+	if d.HasChange("static_target") {
+		o, n := d.GetChange("static_target")
+		if err := updateHostIscsiStaticTargets(meta, hsID, device, o.(*schema.Set), n.(*schema.Set)); err != nil {
+			return err
+		}
+	}
+
+	return resourceVSphereHostIscsiTargetRead(d, meta)
+}
+
+func resourceVSphereHostIscsiTargetDelete(d *schema.ResourceData, meta interface{}) error {
+	hsID, device, err := splitHostIscsiTargetID(d.Id())
+	if err != nil {
+		return err
+	}
+	sendTargets := d.Get("send_target").(*schema.Set)
+	if err := updateHostIscsiSendTargets(meta, hsID, device, sendTargets, &schema.Set{F: sendTargets.F}); err != nil {
+		return err
+	}
+	staticTargets := d.Get("static_target").(*schema.Set)
+	return updateHostIscsiStaticTargets(meta, hsID, device, staticTargets, &schema.Set{F: staticTargets.F})
+}
+
+func resourceVSphereHostIscsiTargetImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*Client).vimClient
+	hsID, device, err := splitHostIscsiTargetID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	ss, err := hostStorageSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return nil, fmt.Errorf("error loading host storage system: %s", err)
+	}
+	hba, err := hostInternetScsiHbaFromDevice(client, ss, device)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.Set("host_system_id", hsID); err != nil {
+		return nil, err
+	}
+	if err := d.Set("adapter_device", device); err != nil {
+		return nil, err
+	}
+	// All targets of the adapter are imported, as no targets are declared yet.
+	if err := d.Set("send_target", flattenHostInternetScsiSendTargets(hba)); err != nil {
+		return nil, err
+	}
+	if err := d.Set("static_target", flattenHostInternetScsiStaticTargets(hba)); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// updateHostIscsiSendTargets removes the send targets that are only in the old
+// set, adds the ones that are only in the new set, and rescans the adapter.
+func updateHostIscsiSendTargets(meta interface{}, hsID, device string, o, n *schema.Set) error {
+	removed := expandHostInternetScsiSendTargets(o.Difference(n).List())
+	added := expandHostInternetScsiSendTargets(n.Difference(o).List())
+	if len(removed) == 0 && len(added) == 0 {
+		return nil
+	}
+	ss, err := hostStorageSystemFromHostSystemID(meta.(*Client).vimClient, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host storage system: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	if len(removed) > 0 {
+		log.Printf("[DEBUG] Removing %d send targets from adapter %q on host %q", len(removed), device, hsID)
+		req := types.RemoveInternetScsiSendTargets{
+			This:           ss.Reference(),
+			IScsiHbaDevice: device,
+			Targets:        removed,
+		}
+		if _, err := methods.RemoveInternetScsiSendTargets(ctx, ss.Client(), &req); err != nil {
+			return fmt.Errorf("error removing send targets: %s", err)
+		}
+	}
+	if len(added) > 0 {
+		log.Printf("[DEBUG] Adding %d send targets to adapter %q on host %q", len(added), device, hsID)
+		req := types.AddInternetScsiSendTargets{
+			This:           ss.Reference(),
+			IScsiHbaDevice: device,
+			Targets:        added,
+		}
+		if _, err := methods.AddInternetScsiSendTargets(ctx, ss.Client(), &req); err != nil {
+			return fmt.Errorf("error adding send targets: %s", err)
+		}
+	}
+	return rescanHostBusAdapter(ss, device)
+}
+
+// updateHostIscsiStaticTargets removes the static targets that are only in
+// the old set, adds the ones that are only in the new set, and rescans the
+// adapter.
+func updateHostIscsiStaticTargets(meta interface{}, hsID, device string, o, n *schema.Set) error {
+	removed := expandHostInternetScsiStaticTargets(o.Difference(n).List())
+	added := expandHostInternetScsiStaticTargets(n.Difference(o).List())
+	if len(removed) == 0 && len(added) == 0 {
+		return nil
+	}
+	ss, err := hostStorageSystemFromHostSystemID(meta.(*Client).vimClient, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host storage system: %s", err)
+	}
+
+	if err := removeHostIscsiStaticTargets(ss, device, removed); err != nil {
+		return err
+	}
+	if len(added) > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+		defer cancel()
+		log.Printf("[DEBUG] Adding %d static targets to adapter %q on host %q", len(added), device, hsID)
+		req := types.AddInternetScsiStaticTargets{
+			This:           ss.Reference(),
+			IScsiHbaDevice: device,
+			Targets:        added,
+		}
+		if _, err := methods.AddInternetScsiStaticTargets(ctx, ss.Client(), &req); err != nil {
+			return fmt.Errorf("error adding static targets: %s", err)
+		}
+	}
+	return rescanHostBusAdapter(ss, device)
+}
+
+func removeHostIscsiStaticTargets(ss *object.HostStorageSystem, device string, targets []types.HostInternetScsiHbaStaticTarget) error {
+	if len(targets) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	log.Printf("[DEBUG] Removing %d static targets from adapter %q", len(targets), device)
+	req := types.RemoveInternetScsiStaticTargets{
+		This:           ss.Reference(),
+		IScsiHbaDevice: device,
+		Targets:        targets,
+	}
+	if _, err := methods.RemoveInternetScsiStaticTargets(ctx, ss.Client(), &req); err != nil {
+		return fmt.Errorf("error removing static targets: %s", err)
+	}
+	return nil
+}
+
+func expandHostInternetScsiSendTargets(l []interface{}) []types.HostInternetScsiHbaSendTarget {
+	var targets []types.HostInternetScsiHbaSendTarget
+	for _, v := range l {
+		m := v.(map[string]interface{})
+		targets = append(targets, types.HostInternetScsiHbaSendTarget{
+			Address: m["address"].(string),
+			Port:    int32(m["port"].(int)),
+		})
+	}
+	return targets
+}
+
+func expandHostInternetScsiStaticTargets(l []interface{}) []types.HostInternetScsiHbaStaticTarget {
+	var targets []types.HostInternetScsiHbaStaticTarget
+	for _, v := range l {
+		m := v.(map[string]interface{})
+		targets = append(targets, types.HostInternetScsiHbaStaticTarget{
+			Address:   m["address"].(string),
+			Port:      int32(m["port"].(int)),
+			IScsiName: m["iscsi_name"].(string),
+		})
+	}
+	return targets
+}
+
+func flattenHostInternetScsiSendTargets(hba *types.HostInternetScsiHba) []interface{} {
+	var targets []interface{}
+	for _, t := range hba.ConfiguredSendTarget {
+		targets = append(targets, map[string]interface{}{
+			"address": t.Address,
+			"port":    int(t.Port),
+		})
+	}
+	return targets
+}
+
+func flattenHostInternetScsiStaticTargets(hba *types.HostInternetScsiHba) []interface{} {
+	var targets []interface{}
+	for _, t := range hba.ConfiguredStaticTarget {
+		// Targets found through dynamic discovery are also listed as static
+		// targets, but are managed through the send targets.
+		if t.DiscoveryMethod != "" && t.DiscoveryMethod != string(types.HostInternetScsiHbaStaticTargetTargetDiscoveryMethodStaticMethod) {
+			continue
+		}
+		targets = append(targets, map[string]interface{}{
+			"address":    t.Address,
+			"port":       int(t.Port),
+			"iscsi_name": t.IScsiName,
+		})
+	}
+	return targets
+}
+
+// splitHostIscsiTargetID splits a vsphere_host_iscsi_target resource ID into
+// its counterparts: the HostSystem ID and the adapter device name.
+func splitHostIscsiTargetID(raw string) (string, string, error) {
+	s := strings.SplitN(raw, ":", 3)
+	if len(s) != 3 || s[0] != hostIscsiTargetIDPrefix || s[1] == "" || s[2] == "" {
+		return "", "", fmt.Errorf("corrupt ID: %s", raw)
+	}
+	return s[1], s[2], nil
+}
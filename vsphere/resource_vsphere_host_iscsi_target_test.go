@@ -0,0 +1,56 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccResourceVSphereHostIscsiTarget_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			RunSweepers()
+			testAccPreCheck(t)
+			testAccCheckEnvVariables(t, []string{"TF_VAR_VSPHERE_ISCSI_TARGET"})
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostIscsiTargetConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("vsphere_host_iscsi_target.targets", "send_target.*", map[string]string{
+						"address": os.Getenv("TF_VAR_VSPHERE_ISCSI_TARGET"),
+						"port":    "3260",
+					}),
+				),
+			},
+			{
+				ResourceName:      "vsphere_host_iscsi_target.targets",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceVSphereHostIscsiTargetConfig() string {
+	return fmt.Sprintf(`
+%s
+
+resource "vsphere_host_iscsi_target" "targets" {
+  host_system_id = data.vsphere_host.esxi_host.id
+  adapter_device = vsphere_host_iscsi_adapter.iscsi.device
+
+  send_target {
+    address = "%s"
+  }
+}
+`, testAccResourceVSphereHostIscsiAdapterConfig("terraform-test"),
+		os.Getenv("TF_VAR_VSPHERE_ISCSI_TARGET"))
+}
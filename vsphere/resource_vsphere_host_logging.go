@@ -0,0 +1,398 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+)
+
+const (
+	hostSyslogOptionLogHost      = "Syslog.global.logHost"
+	hostSyslogOptionLogDir       = "Syslog.global.logDir"
+	hostSyslogOptionLogDirUnique = "Syslog.global.logDirUnique"
+	hostSyslogOptionDefaultSize  = "Syslog.global.defaultSize"
+	hostSyslogOptionDefaultRot   = "Syslog.global.defaultRotate"
+)
+
+// hostSyslogDefaultPorts are the ports used by ESXi for a remote syslog target
+// when no port is specified.
+var hostSyslogDefaultPorts = map[string]int{
+	"udp": 514,
+	"tcp": 514,
+	"ssl": 1514,
+}
+
+const hostNetdumpDefaultPort = 6500
+
+func resourceVSphereHostLogging() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVSphereHostLoggingCreate,
+		Read:   resourceVSphereHostLoggingRead,
+		Update: resourceVSphereHostLoggingUpdate,
+		Delete: resourceVSphereHostLoggingDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereHostLoggingImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"host_system_id": {
+				Type:        schema.TypeString,
+				Description: "The managed object ID of the host to manage the logging configuration of.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"syslog_target": {
+				Type:        schema.TypeList,
+				Description: "A remote syslog target to send the logs of the host to.",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"protocol": {
+							Type:         schema.TypeString,
+							Description:  "The protocol used to send logs to the target. Can be one of udp, tcp or ssl.",
+							Optional:     true,
+							Default:      "udp",
+							ValidateFunc: validation.StringInSlice([]string{"udp", "tcp", "ssl"}, false),
+						},
+						"host": {
+							Type:        schema.TypeString,
+							Description: "The host name or IP address of the target.",
+							Required:    true,
+						},
+						"port": {
+							Type:         schema.TypeInt,
+							Description:  "The port of the target. Defaults to 514 for udp and tcp, and 1514 for ssl.",
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.IsPortNumber,
+						},
+					},
+				},
+			},
+			"log_dir": {
+				Type:        schema.TypeString,
+				Description: "The directory to store the logs of the host in, such as [datastore1] /logs.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"log_dir_unique": {
+				Type:        schema.TypeBool,
+				Description: "Store the logs in a subdirectory named after the host within log_dir.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"log_rotate_size": {
+				Type:         schema.TypeInt,
+				Description:  "The size in KiB a log file grows to before it is rotated.",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"log_rotations": {
+				Type:         schema.TypeInt,
+				Description:  "The number of rotated log files to keep.",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"netdump": {
+				Type:        schema.TypeList,
+				Description: "The network core dump server to send the core dumps of the host to.",
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"vnic": {
+							Type:        schema.TypeString,
+							Description: "The virtual NIC used to send core dumps, such as vmk0.",
+							Required:    true,
+						},
+						"server_address": {
+							Type:         schema.TypeString,
+							Description:  "The IP address of the network core dump server.",
+							Required:     true,
+							ValidateFunc: validation.IsIPAddress,
+						},
+						"server_port": {
+							Type:         schema.TypeInt,
+							Description:  "The port of the network core dump server.",
+							Optional:     true,
+							Default:      hostNetdumpDefaultPort,
+							ValidateFunc: validation.IsPortNumber,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceVSphereHostLoggingCreate(d *schema.ResourceData, meta interface{}) error {
+	hsID := d.Get("host_system_id").(string)
+	if err := resourceVSphereHostLoggingApply(d, meta, hsID); err != nil {
+		return err
+	}
+
+	d.SetId(hsID)
+	return resourceVSphereHostLoggingRead(d, meta)
+}
+
+func resourceVSphereHostLoggingRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).vimClient
+	hs, err := hostsystem.FromID(client, d.Id())
+	if err != nil {
+		if viapi.IsManagedObjectNotFoundError(err) {
+			log.Printf("[DEBUG] Host %q not found, removing logging configuration from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	om, err := hostOptionManagerFromHostSystemID(client, d.Id())
+	if err != nil {
+		return fmt.Errorf("error loading host option manager: %s", err)
+	}
+
+	values := make(map[string]string)
+	for _, k := range []string{hostSyslogOptionLogHost, hostSyslogOptionLogDir, hostSyslogOptionLogDirUnique, hostSyslogOptionDefaultSize, hostSyslogOptionDefaultRot} {
+		v, _, err := readHostAdvancedSetting(om, k)
+		if err != nil {
+			return err
+		}
+		values[k] = v
+	}
+
+	targets, err := flattenHostSyslogTargets(values[hostSyslogOptionLogHost])
+	if err != nil {
+		return err
+	}
+	if err := d.Set("syslog_target", targets); err != nil {
+		return err
+	}
+	_ = d.Set("host_system_id", d.Id())
+	_ = d.Set("log_dir", values[hostSyslogOptionLogDir])
+	if v, err := strconv.ParseBool(values[hostSyslogOptionLogDirUnique]); err == nil {
+		_ = d.Set("log_dir_unique", v)
+	}
+	if v, err := strconv.Atoi(values[hostSyslogOptionDefaultSize]); err == nil {
+		_ = d.Set("log_rotate_size", v)
+	}
+	if v, err := strconv.Atoi(values[hostSyslogOptionDefaultRot]); err == nil {
+		_ = d.Set("log_rotations", v)
+	}
+
+	netdump, err := readHostNetdumpConfig(client, hs)
+	if err != nil {
+		return err
+	}
+	return d.Set("netdump", netdump)
+}
+
+func resourceVSphereHostLoggingUpdate(d *schema.ResourceData, meta interface{}) error {
+	if err := resourceVSphereHostLoggingApply(d, meta, d.Id()); err != nil {
+		return err
+	}
+
+	return resourceVSphereHostLoggingRead(d, meta)
+}
+
+func resourceVSphereHostLoggingDelete(d *schema.ResourceData, meta interface{}) error {
+	// Only the remote targets are removed. The log directory and the rotation
+	// settings are left in place, so that logs are not moved when the resource
+	// is destroyed.
+	if err := applyHostAdvancedSettings(meta, d.Id(), nil, []string{hostSyslogOptionLogHost}); err != nil {
+		return err
+	}
+
+	client := meta.(*Client).vimClient
+	hs, err := hostsystem.FromID(client, d.Id())
+	if err != nil {
+		return err
+	}
+	if err := reloadHostSyslog(client, hs); err != nil {
+		return err
+	}
+	if len(d.Get("netdump").([]interface{})) > 0 {
+		return disableHostNetdump(client, hs)
+	}
+	return nil
+}
+
+func resourceVSphereHostLoggingImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*Client).vimClient
+	if _, err := hostsystem.FromID(client, d.Id()); err != nil {
+		return nil, err
+	}
+	if err := d.Set("host_system_id", d.Id()); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceVSphereHostLoggingApply applies the changes in the resource data to
+// the syslog and network core dump configuration of the host.
+func resourceVSphereHostLoggingApply(d *schema.ResourceData, meta interface{}, hsID string) error {
+	client := meta.(*Client).vimClient
+	hs, err := hostsystem.FromID(client, hsID)
+	if err != nil {
+		return err
+	}
+
+	settings := make(map[string]interface{})
+	if d.HasChange("syslog_target") {
+		settings[hostSyslogOptionLogHost] = expandHostSyslogTargets(d.Get("syslog_target").([]interface{}))
+	}
+	if v, ok := d.GetOk("log_dir"); ok && d.HasChange("log_dir") {
+		settings[hostSyslogOptionLogDir] = v.(string)
+	}
+	if d.HasChange("log_dir_unique") {
+		settings[hostSyslogOptionLogDirUnique] = strconv.FormatBool(d.Get("log_dir_unique").(bool))
+	}
+	if v, ok := d.GetOk("log_rotate_size"); ok && d.HasChange("log_rotate_size") {
+		settings[hostSyslogOptionDefaultSize] = strconv.Itoa(v.(int))
+	}
+	if v, ok := d.GetOk("log_rotations"); ok && d.HasChange("log_rotations") {
+		settings[hostSyslogOptionDefaultRot] = strconv.Itoa(v.(int))
+	}
+	if len(settings) > 0 {
+		if err := applyHostAdvancedSettings(meta, hsID, settings, nil); err != nil {
+			return err
+		}
+		if err := reloadHostSyslog(client, hs); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("netdump") {
+		netdump := d.Get("netdump").([]interface{})
+		if len(netdump) == 0 || netdump[0] == nil {
+			return disableHostNetdump(client, hs)
+		}
+		return enableHostNetdump(client, hs, netdump[0].(map[string]interface{}))
+	}
+	return nil
+}
+
+// expandHostSyslogTargets returns the list of syslog_target blocks as the
+// comma-separated list of URLs expected by the Syslog.global.logHost setting.
+func expandHostSyslogTargets(targets []interface{}) string {
+	var hosts []string
+	for _, raw := range targets {
+		t := raw.(map[string]interface{})
+		protocol := t["protocol"].(string)
+		port := t["port"].(int)
+		if port == 0 {
+			port = hostSyslogDefaultPorts[protocol]
+		}
+		hosts = append(hosts, fmt.Sprintf("%s://%s", protocol, net.JoinHostPort(t["host"].(string), strconv.Itoa(port))))
+	}
+	return strings.Join(hosts, ",")
+}
+
+// flattenHostSyslogTargets parses the value of the Syslog.global.logHost
+// setting into a list of syslog_target blocks. Targets without a protocol or
+// port use the defaults of ESXi.
+func flattenHostSyslogTargets(logHost string) ([]interface{}, error) {
+	var targets []interface{}
+	for _, s := range strings.Split(logHost, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		if !strings.Contains(s, "://") {
+			s = "udp://" + s
+		}
+		u, err := url.Parse(s)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing syslog target %q: %s", s, err)
+		}
+		protocol := strings.ToLower(u.Scheme)
+		port := hostSyslogDefaultPorts[protocol]
+		if p := u.Port(); p != "" {
+			if port, err = strconv.Atoi(p); err != nil {
+				return nil, fmt.Errorf("error parsing port of syslog target %q: %s", s, err)
+			}
+		}
+		targets = append(targets, map[string]interface{}{
+			"protocol": protocol,
+			"host":     u.Hostname(),
+			"port":     port,
+		})
+	}
+	return targets, nil
+}
+
+// reloadHostSyslog reloads the syslog daemon of a host so that changes to the
+// Syslog.global settings take effect.
+func reloadHostSyslog(client *govmomi.Client, hs *object.HostSystem) error {
+	if _, err := runHostEsxcli(client, hs, "system", "syslog", "reload"); err != nil {
+		return fmt.Errorf("error reloading syslog on host %q: %s", hs.Reference().Value, err)
+	}
+	return nil
+}
+
+// readHostNetdumpConfig returns the network core dump configuration of a host
+// as a netdump block, or an empty list if network core dumps are disabled.
+func readHostNetdumpConfig(client *govmomi.Client, hs *object.HostSystem) ([]interface{}, error) {
+	res, err := runHostEsxcli(client, hs, "system", "coredump", "network", "get")
+	if err != nil {
+		return nil, fmt.Errorf("error reading network core dump configuration: %s", err)
+	}
+	for _, v := range res.Values {
+		if enabled, _ := strconv.ParseBool(esxcliValue(v, "Enabled")); !enabled {
+			continue
+		}
+		port, err := strconv.Atoi(esxcliValue(v, "NetworkServerPort"))
+		if err != nil {
+			port = hostNetdumpDefaultPort
+		}
+		return []interface{}{map[string]interface{}{
+			"vnic":           esxcliValue(v, "HostVNic"),
+			"server_address": esxcliValue(v, "NetworkServerIP"),
+			"server_port":    port,
+		}}, nil
+	}
+	return nil, nil
+}
+
+// enableHostNetdump configures and enables the network core dump server of a
+// host. esxcli does not allow to enable the server in the same command that
+// configures it, so two commands are run.
+func enableHostNetdump(client *govmomi.Client, hs *object.HostSystem, netdump map[string]interface{}) error {
+	log.Printf("[DEBUG] Configuring network core dump server %s on host %q", netdump["server_address"], hs.Reference().Value)
+	args := []string{
+		"system", "coredump", "network", "set",
+		"--interface-name=" + netdump["vnic"].(string),
+		"--server-ip=" + netdump["server_address"].(string),
+		"--server-port=" + strconv.Itoa(netdump["server_port"].(int)),
+	}
+	if _, err := runHostEsxcli(client, hs, args...); err != nil {
+		return fmt.Errorf("error configuring network core dump server: %s", err)
+	}
+	if _, err := runHostEsxcli(client, hs, "system", "coredump", "network", "set", "--enable=true"); err != nil {
+		return fmt.Errorf("error enabling network core dump: %s", err)
+	}
+	return nil
+}
+
+// disableHostNetdump disables the network core dump server of a host.
+func disableHostNetdump(client *govmomi.Client, hs *object.HostSystem) error {
+	log.Printf("[DEBUG] Disabling network core dump on host %q", hs.Reference().Value)
+	if _, err := runHostEsxcli(client, hs, "system", "coredump", "network", "set", "--enable=false"); err != nil {
+		return fmt.Errorf("error disabling network core dump: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/testhelper"
+)
+
+func TestAccResourceVSphereHostLogging_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			RunSweepers()
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostLoggingConfig("udp", 1024),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vsphere_host_logging.logging", "syslog_target.#", "1"),
+					resource.TestCheckResourceAttr("vsphere_host_logging.logging", "syslog_target.0.protocol", "udp"),
+					resource.TestCheckResourceAttr("vsphere_host_logging.logging", "syslog_target.0.port", "514"),
+					resource.TestCheckResourceAttr("vsphere_host_logging.logging", "log_rotate_size", "1024"),
+				),
+			},
+			{
+				Config: testAccResourceVSphereHostLoggingConfig("ssl", 2048),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vsphere_host_logging.logging", "syslog_target.0.protocol", "ssl"),
+					resource.TestCheckResourceAttr("vsphere_host_logging.logging", "syslog_target.0.port", "1514"),
+					resource.TestCheckResourceAttr("vsphere_host_logging.logging", "log_rotate_size", "2048"),
+				),
+			},
+		},
+	})
+}
+
+func TestFlattenHostSyslogTargets(t *testing.T) {
+	cases := []struct {
+		name     string
+		logHost  string
+		expected []interface{}
+	}{
+		{
+			name:    "empty",
+			logHost: "",
+		},
+		{
+			name:    "default protocol and port",
+			logHost: "syslog.example.com",
+			expected: []interface{}{
+				map[string]interface{}{"protocol": "udp", "host": "syslog.example.com", "port": 514},
+			},
+		},
+		{
+			name:    "multiple targets",
+			logHost: "tcp://10.0.0.1:1514, ssl://syslog.example.com",
+			expected: []interface{}{
+				map[string]interface{}{"protocol": "tcp", "host": "10.0.0.1", "port": 1514},
+				map[string]interface{}{"protocol": "ssl", "host": "syslog.example.com", "port": 1514},
+			},
+		},
+		{
+			name:    "ipv6",
+			logHost: "udp://[fd00::1]:514",
+			expected: []interface{}{
+				map[string]interface{}{"protocol": "udp", "host": "fd00::1", "port": 514},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := flattenHostSyslogTargets(tc.logHost)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("expected %#v, got %#v", tc.expected, actual)
+			}
+			if tc.logHost == "" {
+				return
+			}
+			// The targets must survive a round trip through the setting.
+			roundTrip, err := flattenHostSyslogTargets(expandHostSyslogTargets(actual))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(roundTrip, tc.expected) {
+				t.Fatalf("expected %#v after round trip, got %#v", tc.expected, roundTrip)
+			}
+		})
+	}
+}
+
+func testAccResourceVSphereHostLoggingConfig(protocol string, rotateSize int) string {
+	return fmt.Sprintf(`
+%s
+
+data "vsphere_host" "esxi_host" {
+  name          = "%s"
+  datacenter_id = data.vsphere_datacenter.rootdc1.id
+}
+
+resource "vsphere_host_logging" "logging" {
+  host_system_id  = data.vsphere_host.esxi_host.id
+  log_rotate_size = %d
+
+  syslog_target {
+    protocol = "%s"
+    host     = "syslog.example.com"
+  }
+}
+`, testhelper.ConfigDataRootDC1(),
+		os.Getenv("TF_VAR_VSPHERE_ESXI3"),
+		rotateSize,
+		protocol)
+}
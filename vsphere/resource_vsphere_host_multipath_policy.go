@@ -0,0 +1,245 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/types"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+)
+
+const hostMultipathPolicyIDPrefix = "tf-HostMultipathPolicy"
+
+const (
+	hostMultipathPolicyRoundRobin       = "VMW_PSP_RR"
+	hostMultipathPolicyMostRecentlyUsed = "VMW_PSP_MRU"
+	hostMultipathPolicyFixed            = "VMW_PSP_FIXED"
+)
+
+var hostMultipathPolicyAllowedValues = []string{
+	hostMultipathPolicyRoundRobin,
+	hostMultipathPolicyMostRecentlyUsed,
+	hostMultipathPolicyFixed,
+}
+
+func resourceVSphereHostMultipathPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceVSphereHostMultipathPolicyCreate,
+		Read:          resourceVSphereHostMultipathPolicyRead,
+		Update:        resourceVSphereHostMultipathPolicyUpdate,
+		Delete:        resourceVSphereHostMultipathPolicyDelete,
+		CustomizeDiff: resourceVSphereHostMultipathPolicyCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereHostMultipathPolicyImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"host_system_id": {
+				Type:        schema.TypeString,
+				Description: "The managed object ID of the host the LUN is attached to.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"lun_canonical_name": {
+				Type:        schema.TypeString,
+				Description: "The canonical name of the SCSI LUN, such as naa.600a098038303053.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"path_selection_policy": {
+				Type:         schema.TypeString,
+				Description:  "The path selection policy of the LUN. Can be one of VMW_PSP_RR, VMW_PSP_MRU or VMW_PSP_FIXED.",
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(hostMultipathPolicyAllowedValues, false),
+			},
+			"preferred_path": {
+				Type:        schema.TypeString,
+				Description: "The name of the preferred path of the LUN. Only valid with the VMW_PSP_FIXED policy.",
+				Optional:    true,
+			},
+			"round_robin_iops": {
+				Type:         schema.TypeInt,
+				Description:  "The number of I/O operations after which the next path is used. Only valid with the VMW_PSP_RR policy.",
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+		},
+	}
+}
+
+func resourceVSphereHostMultipathPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	hsID := d.Get("host_system_id").(string)
+	name := d.Get("lun_canonical_name").(string)
+	if err := resourceVSphereHostMultipathPolicyApply(d, meta, hsID, name); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s:%s", hostMultipathPolicyIDPrefix, hsID, name))
+	return resourceVSphereHostMultipathPolicyRead(d, meta)
+}
+
+func resourceVSphereHostMultipathPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).vimClient
+	hsID, name, err := splitHostMultipathPolicyID(d.Id())
+	if err != nil {
+		return err
+	}
+	ss, err := hostStorageSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		if viapi.IsManagedObjectNotFoundError(err) {
+			log.Printf("[DEBUG] Host %q not found, removing multipath policy from state", hsID)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error loading host storage system: %s", err)
+	}
+	info, err := hostStorageDeviceInfo(client, ss)
+	if err != nil {
+		return err
+	}
+	lu, err := hostMultipathLogicalUnitFromCanonicalName(info, name)
+	if err != nil {
+		return err
+	}
+
+	_ = d.Set("host_system_id", hsID)
+	_ = d.Set("lun_canonical_name", name)
+	_ = d.Set("path_selection_policy", lu.Policy.GetHostMultipathInfoLogicalUnitPolicy().Policy)
+	if fixed, ok := lu.Policy.(*types.HostMultipathInfoFixedLogicalUnitPolicy); ok && d.Get("preferred_path").(string) != "" {
+		_ = d.Set("preferred_path", fixed.Prefer)
+	} else {
+		_ = d.Set("preferred_path", "")
+	}
+
+	iops := 0
+	if lu.Policy.GetHostMultipathInfoLogicalUnitPolicy().Policy == hostMultipathPolicyRoundRobin && d.Get("round_robin_iops").(int) != 0 {
+		hs, err := hostsystem.FromID(client, hsID)
+		if err != nil {
+			return err
+		}
+		iops, err = hostRoundRobinIopsLimit(client, hs, name)
+		if err != nil {
+			return err
+		}
+	}
+	return d.Set("round_robin_iops", iops)
+}
+
+func resourceVSphereHostMultipathPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+	hsID, name, err := splitHostMultipathPolicyID(d.Id())
+	if err != nil {
+		return err
+	}
+	if err := resourceVSphereHostMultipathPolicyApply(d, meta, hsID, name); err != nil {
+		return err
+	}
+
+	return resourceVSphereHostMultipathPolicyRead(d, meta)
+}
+
+func resourceVSphereHostMultipathPolicyDelete(d *schema.ResourceData, _ interface{}) error {
+	// The default policy of a LUN depends on the storage array type plugin
+	// claiming it, so the policy is left unchanged on the host.
+	log.Printf("[DEBUG] Removing multipath policy %q from state, the policy is left unchanged on the host", d.Id())
+	return nil
+}
+
+func resourceVSphereHostMultipathPolicyImport(d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	hsID, name, err := splitHostMultipathPolicyID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	if err := d.Set("host_system_id", hsID); err != nil {
+		return nil, err
+	}
+	if err := d.Set("lun_canonical_name", name); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceVSphereHostMultipathPolicyCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	policy := d.Get("path_selection_policy").(string)
+	if d.Get("preferred_path").(string) != "" && policy != hostMultipathPolicyFixed {
+		return fmt.Errorf("preferred_path can only be set with the %s policy", hostMultipathPolicyFixed)
+	}
+	if d.Get("round_robin_iops").(int) != 0 && policy != hostMultipathPolicyRoundRobin {
+		return fmt.Errorf("round_robin_iops can only be set with the %s policy", hostMultipathPolicyRoundRobin)
+	}
+	return nil
+}
+
+// resourceVSphereHostMultipathPolicyApply sets the path selection policy and
+// the round robin I/O operation limit in the resource data on the LUN.
+func resourceVSphereHostMultipathPolicyApply(d *schema.ResourceData, meta interface{}, hsID, name string) error {
+	client := meta.(*Client).vimClient
+	ss, err := hostStorageSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host storage system: %s", err)
+	}
+	info, err := hostStorageDeviceInfo(client, ss)
+	if err != nil {
+		return err
+	}
+	lu, err := hostMultipathLogicalUnitFromCanonicalName(info, name)
+	if err != nil {
+		return err
+	}
+
+	if d.HasChanges("path_selection_policy", "preferred_path") {
+		var policy types.BaseHostMultipathInfoLogicalUnitPolicy = &types.HostMultipathInfoLogicalUnitPolicy{
+			Policy: d.Get("path_selection_policy").(string),
+		}
+		if prefer := d.Get("preferred_path").(string); prefer != "" {
+			policy = &types.HostMultipathInfoFixedLogicalUnitPolicy{
+				HostMultipathInfoLogicalUnitPolicy: types.HostMultipathInfoLogicalUnitPolicy{
+					Policy: hostMultipathPolicyFixed,
+				},
+				Prefer: prefer,
+			}
+		}
+
+		log.Printf("[DEBUG] Setting path selection policy of LUN %q on host %q to %s", name, hsID, policy.GetHostMultipathInfoLogicalUnitPolicy().Policy)
+		ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+		defer cancel()
+		req := types.SetMultipathLunPolicy{
+			This:   ss.Reference(),
+			LunId:  lu.Id,
+			Policy: policy,
+		}
+		if _, err := methods.SetMultipathLunPolicy(ctx, ss.Client(), &req); err != nil {
+			return fmt.Errorf("error setting path selection policy of LUN %s: %s", name, err)
+		}
+	}
+
+	if iops := d.Get("round_robin_iops").(int); iops != 0 && d.HasChanges("path_selection_policy", "round_robin_iops") {
+		hs, err := hostsystem.FromID(client, hsID)
+		if err != nil {
+			return err
+		}
+		log.Printf("[DEBUG] Setting round robin I/O operation limit of LUN %q on host %q to %d", name, hsID, iops)
+		if err := setHostRoundRobinIopsLimit(client, hs, name, iops); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitHostMultipathPolicyID splits a vsphere_host_multipath_policy resource
+// ID into its counterparts: the HostSystem ID and the LUN canonical name.
+func splitHostMultipathPolicyID(raw string) (string, string, error) {
+	s := strings.SplitN(raw, ":", 3)
+	if len(s) != 3 || s[0] != hostMultipathPolicyIDPrefix || s[1] == "" || s[2] == "" {
+		return "", "", fmt.Errorf("corrupt ID: %s", raw)
+	}
+	return s[1], s[2], nil
+}
@@ -0,0 +1,66 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/testhelper"
+)
+
+func TestAccResourceVSphereHostMultipathPolicy_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			RunSweepers()
+			testAccPreCheck(t)
+			testAccCheckEnvVariables(t, []string{"TF_VAR_VSPHERE_DS_VMFS_ESXI1_DISK0"})
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostMultipathPolicyConfig(`
+  path_selection_policy = "VMW_PSP_RR"
+  round_robin_iops      = 1
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vsphere_host_multipath_policy.policy", "path_selection_policy", "VMW_PSP_RR"),
+					resource.TestCheckResourceAttr("vsphere_host_multipath_policy.policy", "round_robin_iops", "1"),
+				),
+			},
+			{
+				Config: testAccResourceVSphereHostMultipathPolicyConfig(`
+  path_selection_policy = "VMW_PSP_MRU"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vsphere_host_multipath_policy.policy", "path_selection_policy", "VMW_PSP_MRU"),
+				),
+			},
+			{
+				Config: testAccResourceVSphereHostMultipathPolicyConfig(`
+  path_selection_policy = "VMW_PSP_MRU"
+  round_robin_iops      = 1
+`),
+				ExpectError: regexp.MustCompile("round_robin_iops can only be set with the VMW_PSP_RR policy"),
+			},
+		},
+	})
+}
+
+func testAccResourceVSphereHostMultipathPolicyConfig(policy string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "vsphere_host_multipath_policy" "policy" {
+  host_system_id     = data.vsphere_host.roothost1.id
+  lun_canonical_name = "%s"
+%s}
+`, testhelper.CombineConfigs(testhelper.ConfigDataRootDC1(), testhelper.ConfigDataRootHost1()),
+		os.Getenv("TF_VAR_VSPHERE_DS_VMFS_ESXI1_DISK0"),
+		policy)
+}
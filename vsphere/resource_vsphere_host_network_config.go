@@ -0,0 +1,631 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+)
+
+func resourceVSphereHostNetworkConfig() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVSphereHostNetworkConfigCreate,
+		Read:   resourceVSphereHostNetworkConfigRead,
+		Update: resourceVSphereHostNetworkConfigUpdate,
+		Delete: resourceVSphereHostNetworkConfigDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereHostNetworkConfigImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"host_system_id": {
+				Type:        schema.TypeString,
+				Description: "The managed object ID of the host to manage the network configuration of.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"hostname": {
+				Type:        schema.TypeString,
+				Description: "The host name of the host.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"domain_name": {
+				Type:        schema.TypeString,
+				Description: "The domain name of the host.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"dns_servers": {
+				Type:        schema.TypeList,
+				Description: "The IP addresses of the DNS servers of the host, in order of preference.",
+				Optional:    true,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsIPAddress,
+				},
+			},
+			"search_domains": {
+				Type:        schema.TypeList,
+				Description: "The domains in which to search for hosts, in order of preference.",
+				Optional:    true,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"ipv4_gateway": {
+				Type:         schema.TypeString,
+				Description:  "The IPv4 default gateway of the default TCP/IP stack.",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsIPv4Address,
+			},
+			"ipv6_gateway": {
+				Type:         schema.TypeString,
+				Description:  "The IPv6 default gateway of the default TCP/IP stack.",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsIPv6Address,
+			},
+			"static_route": {
+				Type:        schema.TypeSet,
+				Description: "A static route of the default TCP/IP stack.",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"network": {
+							Type:         schema.TypeString,
+							Description:  "The destination network of the route.",
+							Required:     true,
+							ValidateFunc: validation.IsIPAddress,
+						},
+						"prefix_length": {
+							Type:         schema.TypeInt,
+							Description:  "The prefix length of the destination network.",
+							Required:     true,
+							ValidateFunc: validation.IntBetween(0, 128),
+						},
+						"gateway": {
+							Type:         schema.TypeString,
+							Description:  "The gateway of the route.",
+							Required:     true,
+							ValidateFunc: validation.IsIPAddress,
+						},
+						"device": {
+							Type:        schema.TypeString,
+							Description: "The virtual NIC to send the traffic of the route through, such as vmk1.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"netstack": {
+				Type:        schema.TypeSet,
+				Description: "A TCP/IP stack instance other than the default stack, such as vmotion, vSphereProvisioning or a custom stack.",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:         schema.TypeString,
+							Description:  "The key of the TCP/IP stack instance.",
+							Required:     true,
+							ValidateFunc: validation.StringNotInSlice([]string{string(types.HostNetStackInstanceSystemStackKeyDefaultTcpipStack)}, false),
+						},
+						"ipv4_gateway": {
+							Type:         schema.TypeString,
+							Description:  "The IPv4 default gateway of the TCP/IP stack.",
+							Optional:     true,
+							ValidateFunc: validation.IsIPv4Address,
+						},
+						"ipv6_gateway": {
+							Type:         schema.TypeString,
+							Description:  "The IPv6 default gateway of the TCP/IP stack.",
+							Optional:     true,
+							ValidateFunc: validation.IsIPv6Address,
+						},
+						"congestion_control_algorithm": {
+							Type:         schema.TypeString,
+							Description:  "The TCP congestion control algorithm of the TCP/IP stack. Can be one of newreno or cubic.",
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice(types.HostNetStackInstanceCongestionControlAlgorithmType("").Strings(), false),
+						},
+						"max_connections": {
+							Type:         schema.TypeInt,
+							Description:  "The requested maximum number of socket connections of the TCP/IP stack.",
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+					},
+				},
+			},
+			"netstacks_created_by_resource": {
+				Type:        schema.TypeSet,
+				Description: "The keys of the TCP/IP stacks that were created by this resource. Only these stacks are removed from the host.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceVSphereHostNetworkConfigCreate(d *schema.ResourceData, meta interface{}) error {
+	hsID := d.Get("host_system_id").(string)
+	// The ID is set before the configuration is applied, so that the TCP/IP
+	// stacks already created are saved to state, and removed on destroy, when
+	// a later step fails.
+	d.SetId(hsID)
+	if err := resourceVSphereHostNetworkConfigApply(d, meta, hsID); err != nil {
+		return err
+	}
+
+	return resourceVSphereHostNetworkConfigRead(d, meta)
+}
+
+func resourceVSphereHostNetworkConfigRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).vimClient
+	ns, err := hostNetworkSystemFromHostSystemID(client, d.Id())
+	if err != nil {
+		if viapi.IsManagedObjectNotFoundError(err) {
+			log.Printf("[DEBUG] Host %q not found, removing network configuration from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error loading host network system: %s", err)
+	}
+	info, err := hostNetworkInfo(client, ns)
+	if err != nil {
+		return err
+	}
+
+	_ = d.Set("host_system_id", d.Id())
+	if info.DnsConfig != nil {
+		dns := info.DnsConfig.GetHostDnsConfig()
+		_ = d.Set("hostname", dns.HostName)
+		_ = d.Set("domain_name", dns.DomainName)
+		if err := d.Set("dns_servers", dns.Address); err != nil {
+			return err
+		}
+		if err := d.Set("search_domains", dns.SearchDomain); err != nil {
+			return err
+		}
+	}
+	if info.IpRouteConfig != nil {
+		rc := info.IpRouteConfig.GetHostIpRouteConfig()
+		_ = d.Set("ipv4_gateway", rc.DefaultGateway)
+		_ = d.Set("ipv6_gateway", rc.IpV6DefaultGateway)
+	}
+
+	// Only the routes and TCP/IP stacks declared in the configuration are
+	// tracked, so that the routes of connected networks and the stacks created
+	// by ESXi do not show up as drift.
+	var routes []interface{}
+	for _, r := range d.Get("static_route").(*schema.Set).List() {
+		if hostIPRouteExists(info.RouteTableInfo, r.(map[string]interface{})) {
+			routes = append(routes, r)
+		}
+	}
+	if err := d.Set("static_route", routes); err != nil {
+		return err
+	}
+
+	var stacks []interface{}
+	for _, raw := range d.Get("netstack").(*schema.Set).List() {
+		configured := raw.(map[string]interface{})
+		instance := hostNetStackInstanceFromKey(info, configured["key"].(string))
+		if instance == nil {
+			log.Printf("[DEBUG] TCP/IP stack %q no longer exists on host %q", configured["key"], d.Id())
+			continue
+		}
+		stacks = append(stacks, flattenHostNetStackInstance(instance, configured))
+	}
+	if err := d.Set("netstack", stacks); err != nil {
+		return err
+	}
+
+	var created []interface{}
+	for _, key := range d.Get("netstacks_created_by_resource").(*schema.Set).List() {
+		if hostNetStackInstanceFromKey(info, key.(string)) != nil {
+			created = append(created, key)
+		}
+	}
+	return d.Set("netstacks_created_by_resource", created)
+}
+
+func resourceVSphereHostNetworkConfigUpdate(d *schema.ResourceData, meta interface{}) error {
+	if err := resourceVSphereHostNetworkConfigApply(d, meta, d.Id()); err != nil {
+		return err
+	}
+
+	return resourceVSphereHostNetworkConfigRead(d, meta)
+}
+
+func resourceVSphereHostNetworkConfigDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).vimClient
+	hs, err := hostsystem.FromID(client, d.Id())
+	if err != nil {
+		return err
+	}
+	ns, err := hostNetworkSystemFromHostSystem(hs)
+	if err != nil {
+		return fmt.Errorf("error loading host network system: %s", err)
+	}
+
+	// The DNS configuration and the default gateways of the host are left in
+	// place, as the host cannot operate without them.
+	routes := expandHostIPRouteTableConfig(d.Get("static_route").(*schema.Set).List(), nil)
+	if len(routes.IpRoute) > 0 || len(routes.Ipv6Route) > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+		defer cancel()
+		if err := ns.UpdateIpRouteTableConfig(ctx, routes); err != nil {
+			return fmt.Errorf("error removing static routes from host %q: %s", d.Id(), err)
+		}
+	}
+
+	// Stacks that existed on the host before they were added to the
+	// configuration are left in place.
+	for _, raw := range d.Get("netstacks_created_by_resource").(*schema.Set).List() {
+		key := raw.(string)
+		log.Printf("[DEBUG] Removing TCP/IP stack %q from host %q", key, d.Id())
+		if err := removeHostNetStackInstance(client, hs, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceVSphereHostNetworkConfigImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*Client).vimClient
+	if _, err := hostNetworkSystemFromHostSystemID(client, d.Id()); err != nil {
+		return nil, fmt.Errorf("error loading host network system: %s", err)
+	}
+	if err := d.Set("host_system_id", d.Id()); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceVSphereHostNetworkConfigApply applies the changes in the resource
+// data to the network configuration of the host.
+func resourceVSphereHostNetworkConfigApply(d *schema.ResourceData, meta interface{}, hsID string) error {
+	client := meta.(*Client).vimClient
+	hs, err := hostsystem.FromID(client, hsID)
+	if err != nil {
+		return err
+	}
+	ns, err := hostNetworkSystemFromHostSystem(hs)
+	if err != nil {
+		return fmt.Errorf("error loading host network system: %s", err)
+	}
+	info, err := hostNetworkInfo(client, ns)
+	if err != nil {
+		return err
+	}
+
+	if err := applyHostDNSConfig(d, ns, info); err != nil {
+		return err
+	}
+	if err := applyHostIPRouteConfig(d, ns, info); err != nil {
+		return err
+	}
+
+	if d.HasChange("static_route") {
+		o, n := d.GetChange("static_route")
+		routes := expandHostIPRouteTableConfig(o.(*schema.Set).List(), n.(*schema.Set).List())
+		log.Printf("[DEBUG] Updating %d static routes on host %q", len(routes.IpRoute)+len(routes.Ipv6Route), hsID)
+		ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+		defer cancel()
+		if err := ns.UpdateIpRouteTableConfig(ctx, routes); err != nil {
+			return fmt.Errorf("error updating static routes on host %q: %s", hsID, err)
+		}
+	}
+
+	if d.HasChange("netstack") {
+		if err := applyHostNetStackInstances(d, client, hs, ns, info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyHostDNSConfig updates the DNS configuration of a host with the values
+// set in the resource data.
+func applyHostDNSConfig(d *schema.ResourceData, ns *object.HostNetworkSystem, info *types.HostNetworkInfo) error {
+	if !d.HasChanges("hostname", "domain_name", "dns_servers", "search_domains") {
+		return nil
+	}
+
+	config := &types.HostDnsConfig{}
+	if info.DnsConfig != nil {
+		*config = *info.DnsConfig.GetHostDnsConfig()
+	}
+	if v, ok := d.GetOk("hostname"); ok {
+		config.HostName = v.(string)
+	}
+	if v, ok := d.GetOk("domain_name"); ok {
+		config.DomainName = v.(string)
+	}
+	// DNS servers and search domains can only be set when the DNS
+	// configuration is not obtained through DHCP.
+	if v, ok := d.GetOk("dns_servers"); ok {
+		config.Address = structure.SliceInterfacesToStrings(v.([]interface{}))
+		config.Dhcp = false
+	}
+	if v, ok := d.GetOk("search_domains"); ok {
+		config.SearchDomain = structure.SliceInterfacesToStrings(v.([]interface{}))
+		config.Dhcp = false
+	}
+
+	log.Printf("[DEBUG] Updating DNS configuration of host %q", d.Get("host_system_id"))
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	if err := ns.UpdateDnsConfig(ctx, config); err != nil {
+		return fmt.Errorf("error updating DNS configuration: %s", err)
+	}
+	return nil
+}
+
+// applyHostIPRouteConfig updates the default gateways of the default TCP/IP
+// stack of a host with the values set in the resource data.
+func applyHostIPRouteConfig(d *schema.ResourceData, ns *object.HostNetworkSystem, info *types.HostNetworkInfo) error {
+	if !d.HasChanges("ipv4_gateway", "ipv6_gateway") {
+		return nil
+	}
+
+	config := &types.HostIpRouteConfig{}
+	if info.IpRouteConfig != nil {
+		*config = *info.IpRouteConfig.GetHostIpRouteConfig()
+	}
+	if v, ok := d.GetOk("ipv4_gateway"); ok {
+		config.DefaultGateway = v.(string)
+	}
+	if v, ok := d.GetOk("ipv6_gateway"); ok {
+		config.IpV6DefaultGateway = v.(string)
+	}
+
+	log.Printf("[DEBUG] Updating default gateways of host %q", d.Get("host_system_id"))
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	if err := ns.UpdateIpRouteConfig(ctx, config); err != nil {
+		return fmt.Errorf("error updating default gateways: %s", err)
+	}
+	return nil
+}
+
+// applyHostNetStackInstances creates, updates and removes the TCP/IP stack
+// instances of a host based on the changes to the netstack set.
+func applyHostNetStackInstances(d *schema.ResourceData, client *govmomi.Client, hs *object.HostSystem, ns *object.HostNetworkSystem, info *types.HostNetworkInfo) error {
+	o, n := d.GetChange("netstack")
+	oldStacks, newStacks := o.(*schema.Set), n.(*schema.Set)
+	created := d.Get("netstacks_created_by_resource").(*schema.Set)
+
+	keys := make(map[string]bool)
+	for _, raw := range newStacks.List() {
+		keys[raw.(map[string]interface{})["key"].(string)] = true
+	}
+	oldByKey := make(map[string]map[string]interface{})
+	for _, raw := range oldStacks.List() {
+		stack := raw.(map[string]interface{})
+		oldByKey[stack["key"].(string)] = stack
+	}
+	for _, raw := range oldStacks.Difference(newStacks).List() {
+		key := raw.(map[string]interface{})["key"].(string)
+		if keys[key] || !created.Contains(key) {
+			continue
+		}
+		log.Printf("[DEBUG] Removing TCP/IP stack %q from host %q", key, hs.Reference().Value)
+		if err := removeHostNetStackInstance(client, hs, key); err != nil {
+			return err
+		}
+		created.Remove(key)
+		if err := d.Set("netstacks_created_by_resource", created); err != nil {
+			return err
+		}
+	}
+
+	var specs []types.HostNetworkConfigNetStackSpec
+	for _, raw := range newStacks.Difference(oldStacks).List() {
+		stack := raw.(map[string]interface{})
+		key := stack["key"].(string)
+		instance := hostNetStackInstanceFromKey(info, key)
+		if instance == nil {
+			log.Printf("[DEBUG] Creating TCP/IP stack %q on host %q", key, hs.Reference().Value)
+			if err := addHostNetStackInstance(client, hs, key); err != nil {
+				return err
+			}
+			created.Add(key)
+			if err := d.Set("netstacks_created_by_resource", created); err != nil {
+				return err
+			}
+			instance = &types.HostNetStackInstance{Key: key}
+		}
+		spec := expandHostNetStackInstance(instance, oldByKey[key], stack)
+		if err := removeClearedHostNetStackGateways(client, hs, instance, spec.IpRouteConfig.GetHostIpRouteConfig()); err != nil {
+			return err
+		}
+		specs = append(specs, types.HostNetworkConfigNetStackSpec{
+			NetStackInstance: spec,
+			Operation:        string(types.ConfigSpecOperationEdit),
+		})
+	}
+	if len(specs) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	if _, err := ns.UpdateNetworkConfig(ctx, types.HostNetworkConfig{NetStackSpec: specs}, string(types.HostConfigChangeModeModify)); err != nil {
+		return fmt.Errorf("error updating TCP/IP stacks on host %q: %s", hs.Reference().Value, err)
+	}
+	return nil
+}
+
+// expandHostNetStackInstance returns the edit specification of a TCP/IP stack
+// instance, applying the values set in a netstack block to the current
+// configuration of the instance. A gateway that was set in the old block and
+// is no longer set in the new one is cleared. old can be nil.
+func expandHostNetStackInstance(current *types.HostNetStackInstance, old, stack map[string]interface{}) types.HostNetStackInstance {
+	rc := &types.HostIpRouteConfig{}
+	if current.IpRouteConfig != nil {
+		*rc = *current.IpRouteConfig.GetHostIpRouteConfig()
+	}
+	if v := stack["ipv4_gateway"].(string); v != "" {
+		rc.DefaultGateway = v
+	} else if old != nil && old["ipv4_gateway"].(string) != "" {
+		rc.DefaultGateway = ""
+		rc.GatewayDevice = ""
+	}
+	if v := stack["ipv6_gateway"].(string); v != "" {
+		rc.IpV6DefaultGateway = v
+	} else if old != nil && old["ipv6_gateway"].(string) != "" {
+		rc.IpV6DefaultGateway = ""
+		rc.IpV6GatewayDevice = ""
+	}
+
+	instance := types.HostNetStackInstance{
+		Key:                             current.Key,
+		IpRouteConfig:                   rc,
+		CongestionControlAlgorithm:      current.CongestionControlAlgorithm,
+		RequestedMaxNumberOfConnections: current.RequestedMaxNumberOfConnections,
+	}
+	if v := stack["congestion_control_algorithm"].(string); v != "" {
+		instance.CongestionControlAlgorithm = v
+	}
+	if v := stack["max_connections"].(int); v != 0 {
+		instance.RequestedMaxNumberOfConnections = int32(v)
+	}
+	return instance
+}
+
+// removeClearedHostNetStackGateways removes the default routes of a TCP/IP
+// stack instance whose gateways are cleared in the supplied route
+// configuration.
+//
+// The vSphere API omits empty gateways from the edit specification, which
+// leaves the current gateway in place, so the default routes are removed
+// through esxcli instead.
+func removeClearedHostNetStackGateways(client *govmomi.Client, hs *object.HostSystem, current *types.HostNetStackInstance, rc *types.HostIpRouteConfig) error {
+	if current.IpRouteConfig == nil {
+		return nil
+	}
+	crc := current.IpRouteConfig.GetHostIpRouteConfig()
+	if crc.DefaultGateway != "" && rc.DefaultGateway == "" {
+		log.Printf("[DEBUG] Clearing IPv4 default gateway of TCP/IP stack %q on host %q", current.Key, hs.Reference().Value)
+		if err := removeHostNetStackDefaultGateway(client, hs, current.Key, crc.DefaultGateway); err != nil {
+			return err
+		}
+	}
+	if crc.IpV6DefaultGateway != "" && rc.IpV6DefaultGateway == "" {
+		log.Printf("[DEBUG] Clearing IPv6 default gateway of TCP/IP stack %q on host %q", current.Key, hs.Reference().Value)
+		if err := removeHostNetStackDefaultGateway(client, hs, current.Key, crc.IpV6DefaultGateway); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flattenHostNetStackInstance returns a TCP/IP stack instance as a netstack
+// block. Only the attributes set in the configured block are read, so that
+// the values chosen by ESXi for the others do not show up as drift.
+func flattenHostNetStackInstance(instance *types.HostNetStackInstance, configured map[string]interface{}) map[string]interface{} {
+	m := map[string]interface{}{
+		"key":                          instance.Key,
+		"ipv4_gateway":                 "",
+		"ipv6_gateway":                 "",
+		"congestion_control_algorithm": "",
+		"max_connections":              0,
+	}
+	if instance.IpRouteConfig != nil {
+		rc := instance.IpRouteConfig.GetHostIpRouteConfig()
+		if configured["ipv4_gateway"].(string) != "" {
+			m["ipv4_gateway"] = rc.DefaultGateway
+		}
+		if configured["ipv6_gateway"].(string) != "" {
+			m["ipv6_gateway"] = rc.IpV6DefaultGateway
+		}
+	}
+	if configured["congestion_control_algorithm"].(string) != "" {
+		m["congestion_control_algorithm"] = instance.CongestionControlAlgorithm
+	}
+	if configured["max_connections"].(int) != 0 {
+		m["max_connections"] = int(instance.RequestedMaxNumberOfConnections)
+	}
+	return m
+}
+
+// expandHostIPRouteTableConfig returns the route table operations that turn
+// the old list of static_route blocks into the new one. Routes are removed
+// before new routes are added.
+func expandHostIPRouteTableConfig(oldRoutes, newRoutes []interface{}) types.HostIpRouteTableConfig {
+	var config types.HostIpRouteTableConfig
+	appendOp := func(op string, raw interface{}) {
+		route := expandHostIPRouteEntry(raw.(map[string]interface{}))
+		entry := types.HostIpRouteOp{ChangeOperation: op, Route: route}
+		if isIPv6Route(route.Network) {
+			config.Ipv6Route = append(config.Ipv6Route, entry)
+		} else {
+			config.IpRoute = append(config.IpRoute, entry)
+		}
+	}
+
+	for _, o := range oldRoutes {
+		if !hostIPRouteListContains(newRoutes, o) {
+			appendOp(string(types.HostConfigChangeOperationRemove), o)
+		}
+	}
+	for _, n := range newRoutes {
+		if !hostIPRouteListContains(oldRoutes, n) {
+			appendOp(string(types.HostConfigChangeOperationAdd), n)
+		}
+	}
+	return config
+}
+
+// expandHostIPRouteEntry reads a static_route block into a HostIpRouteEntry.
+func expandHostIPRouteEntry(route map[string]interface{}) types.HostIpRouteEntry {
+	return types.HostIpRouteEntry{
+		Network:      route["network"].(string),
+		PrefixLength: int32(route["prefix_length"].(int)),
+		Gateway:      route["gateway"].(string),
+		DeviceName:   route["device"].(string),
+	}
+}
+
+func hostIPRouteListContains(routes []interface{}, route interface{}) bool {
+	entry := expandHostIPRouteEntry(route.(map[string]interface{}))
+	for _, r := range routes {
+		if expandHostIPRouteEntry(r.(map[string]interface{})) == entry {
+			return true
+		}
+	}
+	return false
+}
+
+// hostIPRouteExists returns true if a route matching the network, prefix
+// length and gateway of a static_route block is in the route table of a host.
+func hostIPRouteExists(info *types.HostIpRouteTableInfo, route map[string]interface{}) bool {
+	if info == nil {
+		return false
+	}
+	entry := expandHostIPRouteEntry(route)
+	table := info.IpRoute
+	if isIPv6Route(entry.Network) {
+		table = info.Ipv6Route
+	}
+	for _, r := range table {
+		if r.Network == entry.Network && r.PrefixLength == entry.PrefixLength && r.Gateway == entry.Gateway {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,202 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/vmware/govmomi/vim25/types"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/testhelper"
+)
+
+func TestAccResourceVSphereHostNetworkConfig_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			RunSweepers()
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostNetworkConfigConfig("10.0.100.0", "cubic"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vsphere_host_network_config.config", "search_domains.0", "example.com"),
+					resource.TestCheckResourceAttr("vsphere_host_network_config.config", "static_route.#", "1"),
+					resource.TestCheckResourceAttr("vsphere_host_network_config.config", "netstack.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("vsphere_host_network_config.config", "netstack.*", map[string]string{
+						"key":                          "tf-test",
+						"congestion_control_algorithm": "cubic",
+					}),
+				),
+			},
+			{
+				Config: testAccResourceVSphereHostNetworkConfigConfig("10.0.200.0", "newreno"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("vsphere_host_network_config.config", "static_route.*", map[string]string{
+						"network": "10.0.200.0",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("vsphere_host_network_config.config", "netstack.*", map[string]string{
+						"key":                          "tf-test",
+						"congestion_control_algorithm": "newreno",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestExpandHostIPRouteTableConfig(t *testing.T) {
+	route := func(network string, prefix int, gateway string) interface{} {
+		return map[string]interface{}{
+			"network":       network,
+			"prefix_length": prefix,
+			"gateway":       gateway,
+			"device":        "",
+		}
+	}
+
+	cases := []struct {
+		name     string
+		old      []interface{}
+		new      []interface{}
+		expected types.HostIpRouteTableConfig
+	}{
+		{
+			name: "add",
+			new:  []interface{}{route("10.0.0.0", 24, "192.168.0.1")},
+			expected: types.HostIpRouteTableConfig{
+				IpRoute: []types.HostIpRouteOp{
+					{ChangeOperation: "add", Route: types.HostIpRouteEntry{Network: "10.0.0.0", PrefixLength: 24, Gateway: "192.168.0.1"}},
+				},
+			},
+		},
+		{
+			name: "unchanged",
+			old:  []interface{}{route("10.0.0.0", 24, "192.168.0.1")},
+			new:  []interface{}{route("10.0.0.0", 24, "192.168.0.1")},
+		},
+		{
+			name: "replace",
+			old:  []interface{}{route("10.0.0.0", 24, "192.168.0.1")},
+			new:  []interface{}{route("10.0.0.0", 24, "192.168.0.2")},
+			expected: types.HostIpRouteTableConfig{
+				IpRoute: []types.HostIpRouteOp{
+					{ChangeOperation: "remove", Route: types.HostIpRouteEntry{Network: "10.0.0.0", PrefixLength: 24, Gateway: "192.168.0.1"}},
+					{ChangeOperation: "add", Route: types.HostIpRouteEntry{Network: "10.0.0.0", PrefixLength: 24, Gateway: "192.168.0.2"}},
+				},
+			},
+		},
+		{
+			name: "ipv6",
+			old:  []interface{}{route("fd00:1::", 64, "fd00::1")},
+			expected: types.HostIpRouteTableConfig{
+				Ipv6Route: []types.HostIpRouteOp{
+					{ChangeOperation: "remove", Route: types.HostIpRouteEntry{Network: "fd00:1::", PrefixLength: 64, Gateway: "fd00::1"}},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := expandHostIPRouteTableConfig(tc.old, tc.new)
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("expected %#v, got %#v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestExpandHostNetStackInstance(t *testing.T) {
+	stack := func(ipv4Gateway, ipv6Gateway string) map[string]interface{} {
+		return map[string]interface{}{
+			"key":                          "tf-test",
+			"ipv4_gateway":                 ipv4Gateway,
+			"ipv6_gateway":                 ipv6Gateway,
+			"congestion_control_algorithm": "",
+			"max_connections":              0,
+		}
+	}
+	current := &types.HostNetStackInstance{
+		Key: "tf-test",
+		IpRouteConfig: &types.HostIpRouteConfig{
+			DefaultGateway:     "172.16.0.1",
+			IpV6DefaultGateway: "fd00::1",
+		},
+		CongestionControlAlgorithm: "cubic",
+	}
+
+	cases := []struct {
+		name     string
+		old      map[string]interface{}
+		new      map[string]interface{}
+		expected types.HostIpRouteConfig
+	}{
+		{
+			name:     "unmanaged gateways are kept",
+			new:      stack("", ""),
+			expected: types.HostIpRouteConfig{DefaultGateway: "172.16.0.1", IpV6DefaultGateway: "fd00::1"},
+		},
+		{
+			name:     "gateway is set",
+			old:      stack("172.16.0.1", ""),
+			new:      stack("172.16.0.254", ""),
+			expected: types.HostIpRouteConfig{DefaultGateway: "172.16.0.254", IpV6DefaultGateway: "fd00::1"},
+		},
+		{
+			name:     "removed gateway is cleared",
+			old:      stack("172.16.0.1", "fd00::1"),
+			new:      stack("", "fd00::1"),
+			expected: types.HostIpRouteConfig{IpV6DefaultGateway: "fd00::1"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := expandHostNetStackInstance(current, tc.old, tc.new)
+			if !reflect.DeepEqual(*actual.IpRouteConfig.GetHostIpRouteConfig(), tc.expected) {
+				t.Fatalf("expected %#v, got %#v", tc.expected, actual.IpRouteConfig)
+			}
+			if actual.CongestionControlAlgorithm != "cubic" {
+				t.Fatalf("expected congestion control algorithm to be kept, got %q", actual.CongestionControlAlgorithm)
+			}
+		})
+	}
+}
+
+func testAccResourceVSphereHostNetworkConfigConfig(network, congestionControlAlgorithm string) string {
+	return fmt.Sprintf(`
+%s
+
+data "vsphere_host" "esxi_host" {
+  name          = "%s"
+  datacenter_id = data.vsphere_datacenter.rootdc1.id
+}
+
+resource "vsphere_host_network_config" "config" {
+  host_system_id = data.vsphere_host.esxi_host.id
+  search_domains = ["example.com"]
+
+  static_route {
+    network       = "%s"
+    prefix_length = 24
+    gateway       = "%s"
+  }
+
+  netstack {
+    key                          = "tf-test"
+    congestion_control_algorithm = "%s"
+  }
+}
+`, testhelper.ConfigDataRootDC1(),
+		os.Getenv("TF_VAR_VSPHERE_ESXI3"),
+		network,
+		os.Getenv("TF_VAR_VSPHERE_IPV4_GATEWAY"),
+		congestionControlAlgorithm)
+}
@@ -0,0 +1,349 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+)
+
+const hostPciPassthroughIDPrefix = "tf-HostPciPassthrough"
+
+// hostPciPassthroughRebootTimeout is the time allowed for a host to enter
+// maintenance mode, reboot and exit maintenance mode, all steps together, when
+// no timeout is configured for the operation.
+const hostPciPassthroughRebootTimeout = 30 * time.Minute
+
+func resourceVSphereHostPciPassthrough() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVSphereHostPciPassthroughCreate,
+		Read:   resourceVSphereHostPciPassthroughRead,
+		Update: resourceVSphereHostPciPassthroughUpdate,
+		Delete: resourceVSphereHostPciPassthroughDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereHostPciPassthroughImport,
+		},
+		CustomizeDiff: resourceVSphereHostPciPassthroughCustomizeDiff,
+		Timeouts:      resourceTimeouts(schema.TimeoutCreate, schema.TimeoutUpdate, schema.TimeoutDelete),
+		Schema: map[string]*schema.Schema{
+			"host_system_id": {
+				Type:        schema.TypeString,
+				Description: "The managed object ID of the host of the PCI device.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"pci_device_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the PCI device, such as 0000:3b:00.0.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"passthrough_enabled": {
+				Type:          schema.TypeBool,
+				Description:   "Enable the PCI device for passthrough to virtual machines.",
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"num_virtual_functions"},
+			},
+			"num_virtual_functions": {
+				Type:          schema.TypeInt,
+				Description:   "The number of SR-IOV virtual functions to enable on the PCI device. Setting this to 0 disables SR-IOV.",
+				Optional:      true,
+				Default:       0,
+				ValidateFunc:  validation.IntAtLeast(0),
+				ConflictsWith: []string{"passthrough_enabled"},
+			},
+			"reboot": {
+				Type:        schema.TypeBool,
+				Description: "Reboot the host when the configuration of the PCI device requires a reboot to take effect. Requires maintenance_mode and a connection to vCenter Server.",
+				Optional:    true,
+				Default:     false,
+			},
+			"maintenance_mode": {
+				Type:        schema.TypeBool,
+				Description: "Put the host in maintenance mode before it is rebooted, and take it out of maintenance mode once it is back, unless it was already in maintenance mode. Must be set when reboot is set, as a host outside maintenance mode can only be rebooted by force.",
+				Optional:    true,
+				Default:     false,
+			},
+			"passthrough_capable": {
+				Type:        schema.TypeBool,
+				Description: "Whether the PCI device can be enabled for passthrough.",
+				Computed:    true,
+			},
+			"passthrough_active": {
+				Type:        schema.TypeBool,
+				Description: "Whether passthrough is active on the PCI device.",
+				Computed:    true,
+			},
+			"sriov_capable": {
+				Type:        schema.TypeBool,
+				Description: "Whether the PCI device supports SR-IOV.",
+				Computed:    true,
+			},
+			"max_virtual_functions": {
+				Type:        schema.TypeInt,
+				Description: "The maximum number of SR-IOV virtual functions supported by the PCI device.",
+				Computed:    true,
+			},
+			"active_virtual_functions": {
+				Type:        schema.TypeInt,
+				Description: "The number of SR-IOV virtual functions active on the PCI device.",
+				Computed:    true,
+			},
+			"reboot_required": {
+				Type:        schema.TypeBool,
+				Description: "Whether the host must be rebooted for the configuration of the PCI device to take effect.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceVSphereHostPciPassthroughCreate(d *schema.ResourceData, meta interface{}) error {
+	hsID := d.Get("host_system_id").(string)
+	pciID := d.Get("pci_device_id").(string)
+	timeout := resourceTimeout(d, schema.TimeoutCreate, hostPciPassthroughRebootTimeout)
+	if err := resourceVSphereHostPciPassthroughApply(d, meta, hsID, pciID, timeout); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s:%s", hostPciPassthroughIDPrefix, hsID, pciID))
+	return resourceVSphereHostPciPassthroughRead(d, meta)
+}
+
+func resourceVSphereHostPciPassthroughRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).vimClient
+	hsID, pciID, err := splitHostPciPassthroughID(d.Id())
+	if err != nil {
+		return err
+	}
+	hs, err := hostsystem.FromID(client, hsID)
+	if err != nil {
+		if viapi.IsManagedObjectNotFoundError(err) {
+			log.Printf("[DEBUG] Host %q not found, removing PCI passthrough from state", hsID)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	info, err := hostPciPassthruInfoFromID(client, hs, pciID)
+	if err != nil {
+		return err
+	}
+
+	_ = d.Set("host_system_id", hsID)
+	_ = d.Set("pci_device_id", pciID)
+	return flattenHostPciPassthruInfo(d, info)
+}
+
+func resourceVSphereHostPciPassthroughUpdate(d *schema.ResourceData, meta interface{}) error {
+	hsID, pciID, err := splitHostPciPassthroughID(d.Id())
+	if err != nil {
+		return err
+	}
+	timeout := resourceTimeout(d, schema.TimeoutUpdate, hostPciPassthroughRebootTimeout)
+	if err := resourceVSphereHostPciPassthroughApply(d, meta, hsID, pciID, timeout); err != nil {
+		return err
+	}
+
+	return resourceVSphereHostPciPassthroughRead(d, meta)
+}
+
+func resourceVSphereHostPciPassthroughDelete(d *schema.ResourceData, meta interface{}) error {
+	hsID, pciID, err := splitHostPciPassthroughID(d.Id())
+	if err != nil {
+		return err
+	}
+	_ = d.Set("passthrough_enabled", false)
+	_ = d.Set("num_virtual_functions", 0)
+	timeout := resourceTimeout(d, schema.TimeoutDelete, hostPciPassthroughRebootTimeout)
+	return resourceVSphereHostPciPassthroughApply(d, meta, hsID, pciID, timeout)
+}
+
+func resourceVSphereHostPciPassthroughImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*Client).vimClient
+	hsID, pciID, err := splitHostPciPassthroughID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	hs, err := hostsystem.FromID(client, hsID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := hostPciPassthruInfoFromID(client, hs, pciID); err != nil {
+		return nil, err
+	}
+	_ = d.Set("reboot", false)
+	_ = d.Set("maintenance_mode", false)
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceVSphereHostPciPassthroughCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.Get("reboot").(bool) {
+		return nil
+	}
+	// Hosts are never rebooted with virtual machines running on them.
+	if !d.Get("maintenance_mode").(bool) {
+		return fmt.Errorf("maintenance_mode must be set to true when reboot is set")
+	}
+	client, ok := meta.(*Client)
+	if !ok {
+		return nil
+	}
+	if err := viapi.ValidateVirtualCenter(client.vimClient); err != nil {
+		return fmt.Errorf("reboot can only be set when connected to vCenter Server: %s", err)
+	}
+	return nil
+}
+
+// resourceVSphereHostPciPassthroughApply applies the passthrough
+// configuration in the resource data to the PCI device, and reboots the host
+// if the configuration requires it and reboot is set.
+func resourceVSphereHostPciPassthroughApply(d *schema.ResourceData, meta interface{}, hsID, pciID string, timeout time.Duration) error {
+	client := meta.(*Client).vimClient
+	hs, err := hostsystem.FromID(client, hsID)
+	if err != nil {
+		return err
+	}
+	info, err := hostPciPassthruInfoFromID(client, hs, pciID)
+	if err != nil {
+		return err
+	}
+	config, err := expandHostPciPassthruConfig(d, info)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Updating passthrough configuration of PCI device %q on host %q", pciID, hsID)
+	if err := updateHostPciPassthruConfig(hs, config); err != nil {
+		return err
+	}
+
+	if !d.Get("reboot").(bool) {
+		return nil
+	}
+	info, err = hostPciPassthruInfoFromID(client, hs, pciID)
+	if err != nil {
+		return err
+	}
+	if !hostPciPassthruRebootRequired(info) {
+		return nil
+	}
+	return resourceVSphereHostPciPassthroughReboot(d, hs, timeout)
+}
+
+// resourceVSphereHostPciPassthroughReboot puts the host in maintenance mode,
+// reboots it, and takes it out of maintenance mode once it is back. A host
+// that was already in maintenance mode is left in it. All steps share a single
+// deadline of timeout.
+func resourceVSphereHostPciPassthroughReboot(d *schema.ResourceData, hs *object.HostSystem, timeout time.Duration) error {
+	if !d.Get("maintenance_mode").(bool) {
+		return fmt.Errorf("host %s is not rebooted as maintenance_mode is not set", hs.Reference().Value)
+	}
+	deadline := time.Now().Add(timeout)
+
+	inMaintenance, err := hostsystem.HostInMaintenance(hs)
+	if err != nil {
+		return err
+	}
+	if !inMaintenance {
+		if err := hostsystem.EnterMaintenanceMode(hs, time.Until(deadline), true); err != nil {
+			return fmt.Errorf("error putting host in maintenance mode: %s", err)
+		}
+	}
+
+	if err := hostsystem.Reboot(hs, time.Until(deadline)); err != nil {
+		return err
+	}
+
+	if inMaintenance {
+		log.Printf("[DEBUG] Host %q was in maintenance mode before the reboot, leaving it in maintenance mode", hs.Reference().Value)
+		return nil
+	}
+	if err := hostsystem.ExitMaintenanceMode(hs, time.Until(deadline)); err != nil {
+		return fmt.Errorf("error taking host out of maintenance mode: %s", err)
+	}
+	return nil
+}
+
+// expandHostPciPassthruConfig reads the resource data into the passthrough
+// configuration of a PCI device. A HostSriovConfig is returned for devices
+// that support SR-IOV.
+func expandHostPciPassthruConfig(d *schema.ResourceData, info types.BaseHostPciPassthruInfo) (types.BaseHostPciPassthruConfig, error) {
+	p := info.GetHostPciPassthruInfo()
+	passthruEnabled := d.Get("passthrough_enabled").(bool)
+	numVirtualFunction := int32(d.Get("num_virtual_functions").(int))
+	if passthruEnabled && !p.PassthruCapable {
+		return nil, fmt.Errorf("PCI device %s does not support passthrough", p.Id)
+	}
+
+	config := types.HostPciPassthruConfig{
+		Id:              p.Id,
+		PassthruEnabled: passthruEnabled,
+		ApplyNow:        types.NewBool(true),
+	}
+	sriov, ok := info.(*types.HostSriovInfo)
+	if !ok || !sriov.SriovCapable {
+		if numVirtualFunction > 0 {
+			return nil, fmt.Errorf("PCI device %s does not support SR-IOV", p.Id)
+		}
+		return &config, nil
+	}
+	if numVirtualFunction > sriov.MaxVirtualFunctionSupported {
+		return nil, fmt.Errorf("PCI device %s supports at most %d virtual functions", p.Id, sriov.MaxVirtualFunctionSupported)
+	}
+	return &types.HostSriovConfig{
+		HostPciPassthruConfig: config,
+		SriovEnabled:          numVirtualFunction > 0,
+		NumVirtualFunction:    numVirtualFunction,
+	}, nil
+}
+
+// flattenHostPciPassthruInfo reads the passthrough information of a PCI
+// device into the resource data.
+func flattenHostPciPassthruInfo(d *schema.ResourceData, info types.BaseHostPciPassthruInfo) error {
+	p := info.GetHostPciPassthruInfo()
+	var sriovCapable bool
+	var numVirtualFunction, maxVirtualFunction, activeVirtualFunction int32
+	if sriov, ok := info.(*types.HostSriovInfo); ok {
+		sriovCapable = sriov.SriovCapable
+		maxVirtualFunction = sriov.MaxVirtualFunctionSupported
+		if sriov.SriovEnabled {
+			numVirtualFunction = sriov.NumVirtualFunctionRequested
+		}
+		if sriov.SriovActive {
+			activeVirtualFunction = sriov.NumVirtualFunction
+		}
+	}
+
+	_ = d.Set("passthrough_enabled", p.PassthruEnabled)
+	_ = d.Set("passthrough_capable", p.PassthruCapable)
+	_ = d.Set("passthrough_active", p.PassthruActive)
+	_ = d.Set("num_virtual_functions", numVirtualFunction)
+	_ = d.Set("sriov_capable", sriovCapable)
+	_ = d.Set("max_virtual_functions", maxVirtualFunction)
+	_ = d.Set("active_virtual_functions", activeVirtualFunction)
+	return d.Set("reboot_required", hostPciPassthruRebootRequired(info))
+}
+
+// splitHostPciPassthroughID splits a vsphere_host_pci_passthrough resource ID
+// into its counterparts: the HostSystem ID and the ID of the PCI device.
+func splitHostPciPassthroughID(raw string) (string, string, error) {
+	s := strings.SplitN(raw, ":", 3)
+	if len(s) != 3 || s[0] != hostPciPassthroughIDPrefix || s[1] == "" || s[2] == "" {
+		return "", "", fmt.Errorf("corrupt ID: %s", raw)
+	}
+	return s[1], s[2], nil
+}
@@ -0,0 +1,122 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/vmware/govmomi/vim25/types"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/testhelper"
+)
+
+func TestAccResourceVSphereHostPciPassthrough_sriov(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			RunSweepers()
+			testAccPreCheck(t)
+			testAccCheckEnvVariables(t, []string{"TF_VAR_VSPHERE_SRIOV_HOST", "TF_VAR_VSPHERE_SRIOV_PHYSICAL_FUNCTION"})
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostPciPassthroughConfigSriov(2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vsphere_host_pci_passthrough.pf", "num_virtual_functions", "2"),
+					resource.TestCheckResourceAttr("vsphere_host_pci_passthrough.pf", "sriov_capable", "true"),
+					resource.TestCheckResourceAttrSet("vsphere_host_pci_passthrough.pf", "reboot_required"),
+				),
+			},
+			{
+				ResourceName:            "vsphere_host_pci_passthrough.pf",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"reboot", "maintenance_mode"},
+			},
+		},
+	})
+}
+
+func TestHostPciPassthruRebootRequired(t *testing.T) {
+	cases := []struct {
+		name     string
+		info     types.BaseHostPciPassthruInfo
+		expected bool
+	}{
+		{
+			name: "passthrough active",
+			info: &types.HostPciPassthruInfo{
+				PassthruEnabled: true,
+				PassthruActive:  true,
+			},
+			expected: false,
+		},
+		{
+			name: "passthrough pending",
+			info: &types.HostPciPassthruInfo{
+				PassthruEnabled: true,
+			},
+			expected: true,
+		},
+		{
+			name: "sriov active",
+			info: &types.HostSriovInfo{
+				SriovEnabled:                true,
+				SriovActive:                 true,
+				NumVirtualFunctionRequested: 4,
+				NumVirtualFunction:          4,
+			},
+			expected: false,
+		},
+		{
+			name: "sriov pending",
+			info: &types.HostSriovInfo{
+				SriovEnabled:                true,
+				NumVirtualFunctionRequested: 4,
+			},
+			expected: true,
+		},
+		{
+			name: "sriov virtual functions pending",
+			info: &types.HostSriovInfo{
+				SriovEnabled:                true,
+				SriovActive:                 true,
+				NumVirtualFunctionRequested: 8,
+				NumVirtualFunction:          4,
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := hostPciPassthruRebootRequired(tc.info); actual != tc.expected {
+				t.Fatalf("expected %t, got %t", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func testAccResourceVSphereHostPciPassthroughConfigSriov(numVirtualFunctions int) string {
+	return fmt.Sprintf(`
+%s
+
+data "vsphere_host" "sriov_host" {
+  name          = "%s"
+  datacenter_id = data.vsphere_datacenter.rootdc1.id
+}
+
+resource "vsphere_host_pci_passthrough" "pf" {
+  host_system_id        = data.vsphere_host.sriov_host.id
+  pci_device_id         = "%s"
+  num_virtual_functions = %d
+}
+`, testhelper.ConfigDataRootDC1(),
+		os.Getenv("TF_VAR_VSPHERE_SRIOV_HOST"),
+		os.Getenv("TF_VAR_VSPHERE_SRIOV_PHYSICAL_FUNCTION"),
+		numVirtualFunctions)
+}
@@ -0,0 +1,191 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+)
+
+const hostPermissionIDPrefix = "tf-HostPermission"
+
+// hostPermissionRoles are the roles that can be granted on the root folder of
+// a host.
+var hostPermissionRoles = []string{"Admin", "ReadOnly", "NoAccess"}
+
+func resourceVSphereHostPermission() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVSphereHostPermissionCreate,
+		Read:   resourceVSphereHostPermissionRead,
+		Update: resourceVSphereHostPermissionUpdate,
+		Delete: resourceVSphereHostPermissionDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereHostPermissionImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"host_system_id": {
+				Type:        schema.TypeString,
+				Description: "The managed object ID of the host to grant the permission on.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"principal": {
+				Type:        schema.TypeString,
+				Description: "The user or group to grant the role to.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"is_group": {
+				Type:        schema.TypeBool,
+				Description: "Whether the principal is a group.",
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+			},
+			"role": {
+				Type:         schema.TypeString,
+				Description:  "The role to grant to the principal. Can be one of Admin, ReadOnly or NoAccess.",
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(hostPermissionRoles, false),
+			},
+		},
+	}
+}
+
+func resourceVSphereHostPermissionCreate(d *schema.ResourceData, meta interface{}) error {
+	hsID := d.Get("host_system_id").(string)
+	if err := resourceVSphereHostPermissionApply(d, meta, hsID); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s:%s", hostPermissionIDPrefix, hsID, d.Get("principal").(string)))
+	return resourceVSphereHostPermissionRead(d, meta)
+}
+
+func resourceVSphereHostPermissionRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).vimClient
+	hsID, principal, err := splitHostPermissionID(d.Id())
+	if err != nil {
+		return err
+	}
+	hs, err := hostsystem.FromID(client, hsID)
+	if err != nil {
+		if viapi.IsManagedObjectNotFoundError(err) {
+			log.Printf("[DEBUG] Host %q not found, removing permission from state", hsID)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	permissions, err := hostPermissions(client, hs)
+	if err != nil {
+		return err
+	}
+
+	isGroup := d.Get("is_group").(bool)
+	for _, p := range permissions {
+		if p.Principal != principal || p.IsGroup != isGroup {
+			continue
+		}
+		_ = d.Set("host_system_id", hsID)
+		_ = d.Set("principal", principal)
+		_ = d.Set("is_group", p.IsGroup)
+		return d.Set("role", p.Role)
+	}
+
+	log.Printf("[DEBUG] Permission for %q not found on host %q, removing from state", principal, hsID)
+	d.SetId("")
+	return nil
+}
+
+func resourceVSphereHostPermissionUpdate(d *schema.ResourceData, meta interface{}) error {
+	hsID, _, err := splitHostPermissionID(d.Id())
+	if err != nil {
+		return err
+	}
+	if err := resourceVSphereHostPermissionApply(d, meta, hsID); err != nil {
+		return err
+	}
+
+	return resourceVSphereHostPermissionRead(d, meta)
+}
+
+func resourceVSphereHostPermissionDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).vimClient
+	hsID, principal, err := splitHostPermissionID(d.Id())
+	if err != nil {
+		return err
+	}
+	hs, err := hostsystem.FromID(client, hsID)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Removing permission for %q from host %q", principal, hsID)
+	return unsetHostPermission(client, hs, hostPermission{
+		Principal: principal,
+		IsGroup:   d.Get("is_group").(bool),
+	})
+}
+
+func resourceVSphereHostPermissionImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*Client).vimClient
+	hsID, principal, err := splitHostPermissionID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	hs, err := hostsystem.FromID(client, hsID)
+	if err != nil {
+		return nil, err
+	}
+	permissions, err := hostPermissions(client, hs)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range permissions {
+		if p.Principal != principal {
+			continue
+		}
+		_ = d.Set("host_system_id", hsID)
+		_ = d.Set("principal", principal)
+		_ = d.Set("is_group", p.IsGroup)
+		return []*schema.ResourceData{d}, nil
+	}
+	return nil, fmt.Errorf("no permission found for %s on host %s", principal, hsID)
+}
+
+// resourceVSphereHostPermissionApply grants the role in the resource data to
+// the principal on the root folder of the host.
+func resourceVSphereHostPermissionApply(d *schema.ResourceData, meta interface{}, hsID string) error {
+	client := meta.(*Client).vimClient
+	hs, err := hostsystem.FromID(client, hsID)
+	if err != nil {
+		return err
+	}
+
+	p := hostPermission{
+		Principal: d.Get("principal").(string),
+		IsGroup:   d.Get("is_group").(bool),
+		Role:      d.Get("role").(string),
+	}
+	log.Printf("[DEBUG] Granting role %q to %q on host %q", p.Role, p.Principal, hsID)
+	return setHostPermission(client, hs, p)
+}
+
+// splitHostPermissionID splits a vsphere_host_permission resource ID into its
+// counterparts: the HostSystem ID and the principal.
+func splitHostPermissionID(raw string) (string, string, error) {
+	s := strings.SplitN(raw, ":", 3)
+	if len(s) != 3 || s[0] != hostPermissionIDPrefix || s[1] == "" || s[2] == "" {
+		return "", "", fmt.Errorf("corrupt ID: %s", raw)
+	}
+	return s[1], s[2], nil
+}
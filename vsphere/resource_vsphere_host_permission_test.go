@@ -0,0 +1,69 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/testhelper"
+)
+
+func TestAccResourceVSphereHostPermission_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			RunSweepers()
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostPermissionConfig("ReadOnly"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vsphere_host_permission.permission", "role", "ReadOnly"),
+					resource.TestCheckResourceAttr("vsphere_host_permission.permission", "is_group", "false"),
+				),
+			},
+			{
+				Config: testAccResourceVSphereHostPermissionConfig("Admin"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vsphere_host_permission.permission", "role", "Admin"),
+				),
+			},
+			{
+				ResourceName:      "vsphere_host_permission.permission",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceVSphereHostPermissionConfig(role string) string {
+	return fmt.Sprintf(`
+%s
+
+data "vsphere_host" "esxi_host" {
+  name          = "%s"
+  datacenter_id = data.vsphere_datacenter.rootdc1.id
+}
+
+resource "vsphere_host_user" "user" {
+  host_system_id = data.vsphere_host.esxi_host.id
+  name           = "tf-operator"
+  password       = "VMware1!VMware1!"
+}
+
+resource "vsphere_host_permission" "permission" {
+  host_system_id = data.vsphere_host.esxi_host.id
+  principal      = vsphere_host_user.user.name
+  role           = "%s"
+}
+`, testhelper.ConfigDataRootDC1(),
+		os.Getenv("TF_VAR_VSPHERE_ESXI3"),
+		role)
+}
@@ -174,6 +174,37 @@ func TestAccResourceVSphereHost_lockdown(t *testing.T) {
 	})
 }
 
+func TestAccResourceVSphereHost_lockdownExceptionUsers(t *testing.T) {
+	testAccSkipUnstable(t)
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			RunSweepers()
+			testAccPreCheck(t)
+			testAccCheckEnvVariables(t, []string{"ESX_HOSTNAME", "ESX_USERNAME", "ESX_PASSWORD"})
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccVSphereHostDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVSphereHostConfigLockdownExceptionUsers("strict", `["root"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccVSphereHostExists("vsphere_host.h1"),
+					testAccVSphereHostLockdownState("vsphere_host.h1", "strict"),
+					resource.TestCheckResourceAttr("vsphere_host.h1", "lockdown_exception_users.#", "1"),
+					resource.TestCheckTypeSetElemAttr("vsphere_host.h1", "lockdown_exception_users.*", "root"),
+				),
+			},
+			{
+				Config: testAccVSphereHostConfigLockdownExceptionUsers("disabled", `[]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccVSphereHostLockdownState("vsphere_host.h1", "disabled"),
+					resource.TestCheckResourceAttr("vsphere_host.h1", "lockdown_exception_users.#", "0"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccResourceVSphereHost_lockdown_invalid(t *testing.T) {
 	testAccSkipUnstable(t)
 	resource.Test(t, resource.TestCase{
@@ -507,6 +538,45 @@ func testAccCheckVSphereHostNTPServers(resourceName string, expectedServers []st
 	}
 }
 
+func TestAccResourceVSphereHostServices(t *testing.T) {
+	testAccSkipUnstable(t)
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccCheckEnvVariables(t, []string{"ESX_HOSTNAME", "ESX_USERNAME", "ESX_PASSWORD"})
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccVSphereHostDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVSphereHostConfigServices("TSM-SSH", false, "off"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccVSphereHostExists("vsphere_host.h1"),
+					resource.TestCheckTypeSetElemNestedAttrs("vsphere_host.h1", "services.*.service.*", map[string]string{
+						"key":     "TSM-SSH",
+						"running": "false",
+						"policy":  "off",
+					}),
+				),
+			},
+			{
+				Config: testAccVSphereHostConfigServices("TSM-SSH", true, "on"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("vsphere_host.h1", "services.*.service.*", map[string]string{
+						"key":     "TSM-SSH",
+						"running": "true",
+						"policy":  "on",
+					}),
+				),
+			},
+			{
+				Config:      testAccVSphereHostConfigServices("no-such-service", false, "off"),
+				ExpectError: regexp.MustCompile("not found on host"),
+			},
+		},
+	})
+}
+
 func testAccVSphereHostConfig() string {
 	return fmt.Sprintf(`
 	%s
@@ -698,6 +768,39 @@ func testaccvspherehostconfigLockdown(lockdown string) string {
 		lockdown)
 }
 
+func testAccVSphereHostConfigLockdownExceptionUsers(lockdown, users string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "vsphere_compute_cluster" "c1" {
+  name = "%s"
+  datacenter_id = data.vsphere_datacenter.rootdc1.id
+}
+
+data "vsphere_host_thumbprint" "thumbprint" {
+    address = "%s"
+    insecure = true
+}
+
+resource "vsphere_host" "h1" {
+    hostname = "%s"
+    username = "%s"
+    password = "%s"
+    thumbprint = data.vsphere_host_thumbprint.thumbprint.id
+    lockdown = "%s"
+    lockdown_exception_users = %s
+    cluster = vsphere_compute_cluster.c1.id
+}
+`, testhelper.ConfigDataRootDC1(),
+		"TestCluster",
+		os.Getenv("ESX_HOSTNAME"),
+		os.Getenv("ESX_HOSTNAME"),
+		os.Getenv("ESX_USERNAME"),
+		os.Getenv("ESX_PASSWORD"),
+		lockdown,
+		users)
+}
+
 type NtpdServiceConfig struct {
 	Enabled bool
 	Policy  string
@@ -779,3 +882,42 @@ resource "vsphere_host" "h1" {
 	log.Printf("Generated Terraform configuration: %s", config)
 	return config
 }
+
+func testAccVSphereHostConfigServices(key string, running bool, policy string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "vsphere_compute_cluster" "c1" {
+  name = "%s"
+  datacenter_id = data.vsphere_datacenter.rootdc1.id
+}
+
+data "vsphere_host_thumbprint" "thumbprint" {
+    address = "%s"
+    insecure = true
+}
+
+resource "vsphere_host" "h1" {
+    hostname = "%s"
+    username = "%s"
+    password = "%s"
+    thumbprint = data.vsphere_host_thumbprint.thumbprint.id
+    services {
+        service {
+            key     = "%s"
+            running = %t
+            policy  = "%s"
+        }
+    }
+    cluster = vsphere_compute_cluster.c1.id
+}
+`, testhelper.ConfigDataRootDC1(),
+		"TestCluster",
+		os.Getenv("ESX_HOSTNAME"),
+		os.Getenv("ESX_HOSTNAME"),
+		os.Getenv("ESX_USERNAME"),
+		os.Getenv("ESX_PASSWORD"),
+		key,
+		running,
+		policy)
+}
@@ -0,0 +1,236 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/types"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+)
+
+const hostUserIDPrefix = "tf-HostUser"
+
+func resourceVSphereHostUser() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVSphereHostUserCreate,
+		Read:   resourceVSphereHostUserRead,
+		Update: resourceVSphereHostUserUpdate,
+		Delete: resourceVSphereHostUserDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereHostUserImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"host_system_id": {
+				Type:        schema.TypeString,
+				Description: "The managed object ID of the host to create the local user on.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the local user.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"password": {
+				Type:         schema.TypeString,
+				Description:  "The password of the local user.",
+				Optional:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"password", "password_wo"},
+			},
+			"password_wo": {
+				Type:         schema.TypeString,
+				Description:  "Write-only password of the local user. The value is not stored in state.",
+				Optional:     true,
+				Sensitive:    true,
+				WriteOnly:    true,
+				RequiredWith: []string{"password_wo_version"},
+			},
+			"password_wo_version": {
+				Type:         schema.TypeInt,
+				Description:  "Version of password_wo. Changing the version sets the password of the local user to the current value of password_wo.",
+				Optional:     true,
+				RequiredWith: []string{"password_wo"},
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "The description of the local user.",
+				Optional:    true,
+			},
+			"shell_access": {
+				Type:        schema.TypeBool,
+				Description: "Grant shell access to the local user. Only applies to users with the Admin role on the host. This value is write-only and is not read from the host.",
+				Optional:    true,
+				Default:     false,
+			},
+		},
+	}
+}
+
+func resourceVSphereHostUserCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).vimClient
+	hsID := d.Get("host_system_id").(string)
+	name := d.Get("name").(string)
+	am, err := hostAccountManagerFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host account manager: %s", err)
+	}
+
+	log.Printf("[DEBUG] Creating local user %q on host %q", name, hsID)
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	req := types.CreateUser{
+		This: am.Reference(),
+		User: expandHostPosixAccountSpec(d, hostUserPassword(d)),
+	}
+	if _, err := methods.CreateUser(ctx, am.Client(), &req); err != nil {
+		return fmt.Errorf("error creating local user %s: %s", name, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s:%s", hostUserIDPrefix, hsID, name))
+	return resourceVSphereHostUserRead(d, meta)
+}
+
+func resourceVSphereHostUserRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).vimClient
+	hsID, name, err := splitHostUserID(d.Id())
+	if err != nil {
+		return err
+	}
+	hs, err := hostsystem.FromID(client, hsID)
+	if err != nil {
+		if viapi.IsManagedObjectNotFoundError(err) {
+			log.Printf("[DEBUG] Host %q not found, removing local user from state", hsID)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	accounts, err := hostLocalAccountDescriptions(client, hs)
+	if err != nil {
+		return err
+	}
+	description, ok := accounts[name]
+	if !ok {
+		log.Printf("[DEBUG] Local user %q not found on host %q, removing from state", name, hsID)
+		d.SetId("")
+		return nil
+	}
+
+	// The password and shell access of a user cannot be read back from the
+	// host, so they are kept as they are in the state. Neither is imported.
+	_ = d.Set("host_system_id", hsID)
+	_ = d.Set("name", name)
+	return d.Set("description", description)
+}
+
+func resourceVSphereHostUserUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).vimClient
+	hsID, name, err := splitHostUserID(d.Id())
+	if err != nil {
+		return err
+	}
+	am, err := hostAccountManagerFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host account manager: %s", err)
+	}
+
+	// The password is only sent when it changes, as the host may reject the
+	// reuse of a previous password.
+	var password string
+	if d.HasChanges("password", "password_wo_version") {
+		password = hostUserPassword(d)
+	}
+
+	log.Printf("[DEBUG] Updating local user %q on host %q", name, hsID)
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	req := types.UpdateUser{
+		This: am.Reference(),
+		User: expandHostPosixAccountSpec(d, password),
+	}
+	if _, err := methods.UpdateUser(ctx, am.Client(), &req); err != nil {
+		return fmt.Errorf("error updating local user %s: %s", name, err)
+	}
+
+	return resourceVSphereHostUserRead(d, meta)
+}
+
+func resourceVSphereHostUserDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).vimClient
+	hsID, name, err := splitHostUserID(d.Id())
+	if err != nil {
+		return err
+	}
+	am, err := hostAccountManagerFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host account manager: %s", err)
+	}
+
+	log.Printf("[DEBUG] Removing local user %q from host %q", name, hsID)
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	if err := am.Remove(ctx, name); err != nil {
+		return fmt.Errorf("error removing local user %s: %s", name, err)
+	}
+	return nil
+}
+
+func resourceVSphereHostUserImport(d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	hsID, name, err := splitHostUserID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	if err := d.Set("host_system_id", hsID); err != nil {
+		return nil, err
+	}
+	if err := d.Set("name", name); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// hostUserPassword returns the password of the user from either password or
+// password_wo.
+func hostUserPassword(d *schema.ResourceData) string {
+	if v := d.Get("password").(string); v != "" {
+		return v
+	}
+	return structure.GetWriteOnlyString(d, "password_wo")
+}
+
+// expandHostPosixAccountSpec reads the resource data into a
+// HostPosixAccountSpec. The password is left out of the specification when it
+// is empty.
+func expandHostPosixAccountSpec(d *schema.ResourceData, password string) *types.HostPosixAccountSpec {
+	shellAccess := d.Get("shell_access").(bool)
+	return &types.HostPosixAccountSpec{
+		HostAccountSpec: types.HostAccountSpec{
+			Id:          d.Get("name").(string),
+			Password:    password,
+			Description: d.Get("description").(string),
+		},
+		ShellAccess: &shellAccess,
+	}
+}
+
+// splitHostUserID splits a vsphere_host_user resource ID into its
+// counterparts: the HostSystem ID and the name of the user.
+func splitHostUserID(raw string) (string, string, error) {
+	s := strings.SplitN(raw, ":", 3)
+	if len(s) != 3 || s[0] != hostUserIDPrefix || s[1] == "" || s[2] == "" {
+		return "", "", fmt.Errorf("corrupt ID: %s", raw)
+	}
+	return s[1], s[2], nil
+}
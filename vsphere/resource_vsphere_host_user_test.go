@@ -0,0 +1,72 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/testhelper"
+)
+
+func TestAccResourceVSphereHostUser_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			RunSweepers()
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostUserConfig("Break-glass account"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vsphere_host_user.user", "name", "tf-breakglass"),
+					resource.TestCheckResourceAttr("vsphere_host_user.user", "description", "Break-glass account"),
+					resource.TestCheckResourceAttr("vsphere_host_permission.permission", "role", "Admin"),
+				),
+			},
+			{
+				Config: testAccResourceVSphereHostUserConfig("Emergency access"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vsphere_host_user.user", "description", "Emergency access"),
+				),
+			},
+			{
+				ResourceName:            "vsphere_host_user.user",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password", "shell_access"},
+			},
+		},
+	})
+}
+
+func testAccResourceVSphereHostUserConfig(description string) string {
+	return fmt.Sprintf(`
+%s
+
+data "vsphere_host" "esxi_host" {
+  name          = "%s"
+  datacenter_id = data.vsphere_datacenter.rootdc1.id
+}
+
+resource "vsphere_host_user" "user" {
+  host_system_id = data.vsphere_host.esxi_host.id
+  name           = "tf-breakglass"
+  password       = "VMware1!VMware1!"
+  description    = "%s"
+}
+
+resource "vsphere_host_permission" "permission" {
+  host_system_id = data.vsphere_host.esxi_host.id
+  principal      = vsphere_host_user.user.name
+  role           = "Admin"
+}
+`, testhelper.ConfigDataRootDC1(),
+		os.Getenv("TF_VAR_VSPHERE_ESXI3"),
+		description)
+}
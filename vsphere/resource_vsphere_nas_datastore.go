@@ -54,14 +54,16 @@ func resourceVSphereNasDatastore() *schema.Resource {
 	s[customattribute.ConfigKey] = customattribute.ConfigSchema()
 
 	return &schema.Resource{
-		Create: resourceVSphereNasDatastoreCreate,
-		Read:   resourceVSphereNasDatastoreRead,
-		Update: resourceVSphereNasDatastoreUpdate,
-		Delete: resourceVSphereNasDatastoreDelete,
+		Create:        resourceVSphereNasDatastoreCreate,
+		Read:          resourceVSphereNasDatastoreRead,
+		Update:        resourceVSphereNasDatastoreUpdate,
+		Delete:        resourceVSphereNasDatastoreDelete,
+		CustomizeDiff: tagsAndCustomAttributesCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			State: resourceVSphereNasDatastoreImport,
 		},
-		Schema: s,
+		Timeouts: resourceTimeouts(schema.TimeoutCreate, schema.TimeoutUpdate),
+		Schema:   s,
 	}
 }
 
@@ -75,7 +77,7 @@ func resourceVSphereNasDatastoreCreate(d *schema.ResourceData, meta interface{})
 		return err
 	}
 	// Verify a proper vCenter before proceeding if custom attributes are defined
-	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d)
+	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d, meta.(*Client).defaultCustomAttributes)
 	if err != nil {
 		return err
 	}
@@ -90,6 +92,7 @@ func resourceVSphereNasDatastoreCreate(d *schema.ResourceData, meta interface{})
 		oldHSIDs: nil,
 		newHSIDs: hosts,
 		volSpec:  volSpec,
+		timeout:  resourceTimeout(d, schema.TimeoutCreate, defaultAPITimeout),
 	}
 	ds, err := p.processMountOperations()
 	if ds != nil {
@@ -113,7 +116,7 @@ func resourceVSphereNasDatastoreCreate(d *schema.ResourceData, meta interface{})
 
 	// Apply any pending tags now
 	if tagsClient != nil {
-		if err := processTagDiff(tagsClient, d, ds); err != nil {
+		if err := processTagDiff(tagsClient, d, meta, ds); err != nil {
 			return err
 		}
 	}
@@ -188,7 +191,7 @@ func resourceVSphereNasDatastoreUpdate(d *schema.ResourceData, meta interface{})
 		return err
 	}
 	// Verify a proper vCenter before proceeding if custom attributes are defined
-	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d)
+	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d, meta.(*Client).defaultCustomAttributes)
 	if err != nil {
 		return err
 	}
@@ -219,7 +222,7 @@ func resourceVSphereNasDatastoreUpdate(d *schema.ResourceData, meta interface{})
 
 	// Apply any pending tags now
 	if tagsClient != nil {
-		if err := processTagDiff(tagsClient, d, ds); err != nil {
+		if err := processTagDiff(tagsClient, d, meta, ds); err != nil {
 			return err
 		}
 	}
@@ -243,6 +246,7 @@ func resourceVSphereNasDatastoreUpdate(d *schema.ResourceData, meta interface{})
 		newHSIDs: structure.SliceInterfacesToStrings(n.(*schema.Set).List()),
 		volSpec:  volSpec,
 		ds:       ds,
+		timeout:  resourceTimeout(d, schema.TimeoutUpdate, defaultAPITimeout),
 	}
 	// Unmount first
 	if err := p.processUnmountOperations(); err != nil {
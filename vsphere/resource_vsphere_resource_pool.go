@@ -5,6 +5,7 @@
 package vsphere
 
 import (
+	"context"
 	"fmt"
 	"log"
 
@@ -124,10 +125,11 @@ func resourceVSphereResourcePool() *schema.Resource {
 		customattribute.ConfigKey: customattribute.ConfigSchema(),
 	}
 	return &schema.Resource{
-		Create: resourceVSphereResourcePoolCreate,
-		Read:   resourceVSphereResourcePoolRead,
-		Update: resourceVSphereResourcePoolUpdate,
-		Delete: resourceVSphereResourcePoolDelete,
+		Create:        resourceVSphereResourcePoolCreate,
+		Read:          resourceVSphereResourcePoolRead,
+		Update:        resourceVSphereResourcePoolUpdate,
+		Delete:        resourceVSphereResourcePoolDelete,
+		CustomizeDiff: resourceVSphereResourcePoolCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			State: resourceVSphereResourcePoolImport,
 		},
@@ -148,6 +150,12 @@ func resourceVSphereResourcePoolImport(d *schema.ResourceData, meta interface{})
 	return []*schema.ResourceData{d}, nil
 }
 
+// resourceVSphereResourcePoolCustomizeDiff merges default_tags and
+// default_custom_attributes into the planned tags and custom attributes.
+func resourceVSphereResourcePoolCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	return tagsAndCustomAttributesCustomizeDiff(ctx, d, meta)
+}
+
 func resourceVSphereResourcePoolCreate(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[DEBUG] %s: Beginning create", resourceVSphereResourcePoolIDString(d))
 	client, err := resourceVSphereResourcePoolClient(meta)
@@ -392,7 +400,7 @@ func resourceVSphereResourcePoolApplyTags(d *schema.ResourceData, meta interface
 	}
 
 	log.Printf("[DEBUG] %s: Applying any pending tags", resourceVSphereResourcePoolIDString(d))
-	return processTagDiff(tagsClient, d, rp)
+	return processTagDiff(tagsClient, d, meta, rp)
 }
 
 // resourceVSphereResourcePoolReadTags reads the tags for a resource pool.
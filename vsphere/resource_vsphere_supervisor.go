@@ -12,15 +12,17 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/govmomi/vapi/namespace"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/provider"
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/structure"
 )
 
 func resourceVsphereSupervisor() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceVsphereSupervisorCreate,
-		Read:   resourceVsphereSupervisorRead,
-		Update: resourceVsphereSupervisorUpdate,
-		Delete: resourceVsphereSupervisorDelete,
+		Create:   resourceVsphereSupervisorCreate,
+		Read:     resourceVsphereSupervisorRead,
+		Update:   resourceVsphereSupervisorUpdate,
+		Delete:   resourceVsphereSupervisorDelete,
+		Timeouts: resourceTimeouts(schema.TimeoutCreate, schema.TimeoutDelete),
 		Schema: map[string]*schema.Schema{
 			"cluster": {
 				Type:         schema.TypeString,
@@ -242,7 +244,7 @@ func resourceVsphereSupervisorCreate(d *schema.ResourceData, meta interface{}) e
 
 	d.SetId(clusterID)
 
-	if err := waitForSupervisorEnable(m, d); err != nil {
+	if err := waitForSupervisorEnable(m, d, resourceTimeout(d, schema.TimeoutCreate, 0)); err != nil {
 		return err
 	}
 
@@ -326,7 +328,7 @@ func resourceVsphereSupervisorDelete(d *schema.ResourceData, meta interface{}) e
 		return err
 	}
 
-	return waitForSupervisorDisable(m, d)
+	return waitForSupervisorDisable(m, d, resourceTimeout(d, schema.TimeoutDelete, 0))
 }
 
 func buildClusterEnableSpec(d *schema.ResourceData) *namespace.EnableClusterSpec {
@@ -412,13 +414,19 @@ func getSizingHint(data interface{}) *namespace.SizingHint {
 	return &namespace.UndefinedSizingHint
 }
 
-func waitForSupervisorEnable(m *namespace.Manager, d *schema.ResourceData) error {
+// waitForSupervisorEnable waits for the supervisor to be running. A timeout of
+// 0 waits indefinitely.
+func waitForSupervisorEnable(m *namespace.Manager, d *schema.ResourceData, timeout time.Duration) error {
+	ctx, cancel := provider.WithTimeout(timeout)
+	defer cancel()
 	ticker := time.NewTicker(time.Minute * time.Duration(1))
+	defer ticker.Stop()
 	failureCount := 0
 
 	for {
 		select {
-		case <-context.Background().Done():
+		case <-ctx.Done():
+			return fmt.Errorf("timeout waiting for supervisor to be enabled on cluster %s", d.Id())
 		case <-ticker.C:
 			cluster := getClusterByID(m, d.Id())
 
@@ -445,12 +453,18 @@ func waitForSupervisorEnable(m *namespace.Manager, d *schema.ResourceData) error
 	}
 }
 
-func waitForSupervisorDisable(m *namespace.Manager, d *schema.ResourceData) error {
+// waitForSupervisorDisable waits for the supervisor to be removed. A timeout of
+// 0 waits indefinitely.
+func waitForSupervisorDisable(m *namespace.Manager, d *schema.ResourceData, timeout time.Duration) error {
+	ctx, cancel := provider.WithTimeout(timeout)
+	defer cancel()
 	ticker := time.NewTicker(time.Minute * time.Duration(1))
+	defer ticker.Stop()
 
 	for {
 		select {
-		case <-context.Background().Done():
+		case <-ctx.Done():
+			return fmt.Errorf("timeout waiting for supervisor to be disabled on cluster %s", d.Id())
 		case <-ticker.C:
 			cluster := getClusterByID(m, d.Id())
 
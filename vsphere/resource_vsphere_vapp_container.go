@@ -5,6 +5,7 @@
 package vsphere
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
@@ -118,13 +119,14 @@ func resourceVSphereVAppContainer() *schema.Resource {
 			Default:     -1,
 		},
 		vSphereTagAttributeKey:    tagsSchema(),
-		customattribute.ConfigKey: customattribute.ConfigSchema(),
+		customattribute.ConfigKey: customattribute.ConfigSchemaWithoutDefaults(),
 	}
 	return &schema.Resource{
-		Create: resourceVSphereVAppContainerCreate,
-		Read:   resourceVSphereVAppContainerRead,
-		Update: resourceVSphereVAppContainerUpdate,
-		Delete: resourceVSphereVAppContainerDelete,
+		Create:        resourceVSphereVAppContainerCreate,
+		Read:          resourceVSphereVAppContainerRead,
+		Update:        resourceVSphereVAppContainerUpdate,
+		Delete:        resourceVSphereVAppContainerDelete,
+		CustomizeDiff: resourceVSphereVAppContainerCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			State: resourceVSphereVAppContainerImport,
 		},
@@ -145,6 +147,11 @@ func resourceVSphereVAppContainerImport(d *schema.ResourceData, meta interface{}
 	return []*schema.ResourceData{d}, nil
 }
 
+// resourceVSphereVAppContainerCustomizeDiff merges default_tags into the planned tags.
+func resourceVSphereVAppContainerCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	return tagsCustomizeDiff(d, meta)
+}
+
 func resourceVSphereVAppContainerCreate(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[DEBUG] %s: Beginning create", resourceVSphereVAppContainerIDString(d))
 	client, err := resourceVSphereVAppContainerClient(meta)
@@ -368,7 +375,7 @@ func resourceVSphereVAppContainerApplyTags(d *schema.ResourceData, meta interfac
 	}
 
 	log.Printf("[DEBUG] %s: Applying any pending tags", resourceVSphereVAppContainerIDString(d))
-	return processTagDiff(tagsClient, d, va)
+	return processTagDiff(tagsClient, d, meta, va)
 }
 
 // resourceVSphereVAppContainerReadTags reads the tags for
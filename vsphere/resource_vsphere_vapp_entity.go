@@ -72,8 +72,8 @@ func resourceVSphereVAppEntity() *schema.Resource {
 			Optional:    true,
 			Default:     false,
 		},
-		vSphereTagAttributeKey:    tagsSchema(),
-		customattribute.ConfigKey: customattribute.ConfigSchema(),
+		vSphereTagAttributeKey:    tagsSchemaWithoutDefaults(),
+		customattribute.ConfigKey: customattribute.ConfigSchemaWithoutDefaults(),
 	}
 	return &schema.Resource{
 		Create: resourceVSphereVAppEntityCreate,
@@ -321,6 +321,7 @@ func resourceVSphereVirtualMachine() *schema.Resource {
 		Update:        resourceVSphereVirtualMachineUpdate,
 		Delete:        resourceVSphereVirtualMachineDelete,
 		CustomizeDiff: resourceVSphereVirtualMachineCustomizeDiff,
+		Timeouts:      resourceTimeouts(schema.TimeoutCreate, schema.TimeoutUpdate, schema.TimeoutDelete),
 		Importer: &schema.ResourceImporter{
 			State: resourceVSphereVirtualMachineImport,
 		},
@@ -337,7 +338,7 @@ func resourceVSphereVirtualMachineCreate(d *schema.ResourceData, meta interface{
 		return err
 	}
 	// Verify a proper vCenter before proceeding if custom attributes are defined
-	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d)
+	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d, meta.(*Client).defaultCustomAttributes)
 	if err != nil {
 		return err
 	}
@@ -362,7 +363,7 @@ func resourceVSphereVirtualMachineCreate(d *schema.ResourceData, meta interface{
 
 	// Tag the VM
 	if tagsClient != nil {
-		if err := processTagDiff(tagsClient, d, vm); err != nil {
+		if err := processTagDiff(tagsClient, d, meta, vm); err != nil {
 			return err
 		}
 	}
@@ -645,13 +646,13 @@ func resourceVSphereVirtualMachineRead(d *schema.ResourceData, meta interface{})
 func resourceVSphereVirtualMachineUpdate(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[DEBUG] %s: Performing update", resourceVSphereVirtualMachineIDString(d))
 	client := meta.(*Client).vimClient
-	timeout := meta.(*Client).timeout
+	timeout := resourceTimeout(d, schema.TimeoutUpdate, meta.(*Client).timeout)
 	tagsClient, err := tagsManagerIfDefined(d, meta)
 	if err != nil {
 		return err
 	}
 	// Verify a proper vCenter before proceeding if custom attributes are defined
-	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d)
+	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d, meta.(*Client).defaultCustomAttributes)
 	if err != nil {
 		return err
 	}
@@ -722,7 +723,7 @@ func resourceVSphereVirtualMachineUpdate(d *schema.ResourceData, meta interface{
 
 	// Apply any pending tags
 	if tagsClient != nil {
-		if err := processTagDiff(tagsClient, d, vm); err != nil {
+		if err := processTagDiff(tagsClient, d, meta, vm); err != nil {
 			return err
 		}
 	}
@@ -763,9 +764,9 @@ func resourceVSphereVirtualMachineUpdate(d *schema.ResourceData, meta interface{
 		// Check to see if we need to shutdown the VM for this process.
 		if d.Get("reboot_required").(bool) && vprops.Runtime.PowerState != types.VirtualMachinePowerStatePoweredOff {
 			// Attempt a graceful shutdown of this process. We wrap this in a VM helper.
-			timeout := d.Get("shutdown_wait_timeout").(int)
+			shutdownTimeout := d.Get("shutdown_wait_timeout").(int)
 			force := d.Get("force_power_off").(bool)
-			if err := virtualmachine.GracefulPowerOff(client, vm, timeout, force); err != nil {
+			if err := virtualmachine.GracefulPowerOff(client, vm, shutdownTimeout, timeout, force); err != nil {
 				return fmt.Errorf("error shutting down virtual machine: %s", err)
 			}
 		}
@@ -814,11 +815,12 @@ func resourceVSphereVirtualMachineUpdate(d *schema.ResourceData, meta interface{
 		}()
 
 		// Perform updates.
-		if _, ok := d.GetOk("datastore_cluster_id"); ok {
-			err = resourceVSphereVirtualMachineUpdateReconfigureWithSDRS(d, meta, vm, spec)
-		} else {
-			err = virtualmachine.Reconfigure(vm, spec, timeout)
-		}
+		err = meta.(*Client).runTask(resourceTimeout(d, schema.TimeoutUpdate, 0), taskClassReconfigure, resourceVSphereVirtualMachineIDString(d), func() error {
+			if _, ok := d.GetOk("datastore_cluster_id"); ok {
+				return resourceVSphereVirtualMachineUpdateReconfigureWithSDRS(d, meta, vm, spec)
+			}
+			return virtualmachine.Reconfigure(vm, spec, timeout)
+		})
 		if err != nil {
 			return err
 		}
@@ -898,7 +900,7 @@ func resourceVSphereVirtualMachineUpdateReconfigureWithSDRS(
 ) error {
 	// Check to see if we have any disk creation operations first, as sending an
 	// update through SDRS without any disk creation operations will fail.
-	timeout := meta.(*Client).timeout
+	timeout := resourceTimeout(d, schema.TimeoutUpdate, meta.(*Client).timeout)
 	if !storagepod.HasDiskCreationOperations(spec.DeviceChange) {
 		log.Printf("[DEBUG] No disk operations for reconfiguration of VM %q, deferring to standard API", vm.InventoryPath)
 		return virtualmachine.Reconfigure(vm, spec, timeout)
@@ -925,7 +927,7 @@ func resourceVSphereVirtualMachineUpdateReconfigureWithSDRS(
 func resourceVSphereVirtualMachineDelete(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[DEBUG] %s: Performing delete", resourceVSphereVirtualMachineIDString(d))
 	client := meta.(*Client).vimClient
-	timeout := meta.(*Client).timeout
+	timeout := resourceTimeout(d, schema.TimeoutDelete, meta.(*Client).timeout)
 	id := d.Id()
 	vm, err := virtualmachine.FromUUID(client, id)
 	if err != nil {
@@ -940,8 +942,8 @@ func resourceVSphereVirtualMachineDelete(d *schema.ResourceData, meta interface{
 	// need to retain on delete. However, we ignore the user-set force shutdown
 	// flag.
 	if vprops.Runtime.PowerState != types.VirtualMachinePowerStatePoweredOff {
-		timeout := d.Get("shutdown_wait_timeout").(int)
-		if err := virtualmachine.GracefulPowerOff(client, vm, timeout, true); err != nil {
+		shutdownTimeout := d.Get("shutdown_wait_timeout").(int)
+		if err := virtualmachine.GracefulPowerOff(client, vm, shutdownTimeout, timeout, true); err != nil {
 			return fmt.Errorf("error shutting down virtual machine: %s", err)
 		}
 	}
@@ -953,13 +955,16 @@ func resourceVSphereVirtualMachineDelete(d *schema.ResourceData, meta interface{
 	}
 	// Only run the reconfigure operation if there's actually disks in the spec.
 	if len(spec.DeviceChange) > 0 {
-		if err := virtualmachine.Reconfigure(vm, spec, timeout); err != nil {
+		err := meta.(*Client).runTask(resourceTimeout(d, schema.TimeoutDelete, 0), taskClassReconfigure, resourceVSphereVirtualMachineIDString(d), func() error {
+			return virtualmachine.Reconfigure(vm, spec, timeout)
+		})
+		if err != nil {
 			return fmt.Errorf("error detaching virtual disks: %s", err)
 		}
 	}
 
 	// The final operation here is to destroy the VM.
-	if err := virtualmachine.Destroy(vm); err != nil {
+	if err := virtualmachine.Destroy(vm, timeout); err != nil {
 		return fmt.Errorf("error destroying virtual machine: %s", err)
 	}
 	d.SetId("")
@@ -967,7 +972,7 @@ func resourceVSphereVirtualMachineDelete(d *schema.ResourceData, meta interface{
 	return nil
 }
 
-func resourceVSphereVirtualMachineCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+func resourceVSphereVirtualMachineCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
 	log.Printf("[DEBUG] %s: Performing diff customization and validation", resourceVSphereVirtualMachineIDString(d))
 	client := meta.(*Client).vimClient
 
@@ -1079,6 +1084,11 @@ func resourceVSphereVirtualMachineCustomizeDiff(_ context.Context, d *schema.Res
 		return err
 	}
 
+	// Merge default_tags and default_custom_attributes.
+	if err = tagsAndCustomAttributesCustomizeDiff(ctx, d, meta); err != nil {
+		return err
+	}
+
 	log.Printf("[DEBUG] %s: Diff customization and validation complete", resourceVSphereVirtualMachineIDString(d))
 	return nil
 }
@@ -1361,7 +1371,7 @@ func resourceVSphereVirtualMachineCreateBareWithSDRS(
 		return nil, fmt.Errorf("error getting datastore cluster: %s", err)
 	}
 
-	timeout := meta.(*Client).timeout
+	timeout := resourceTimeout(d, schema.TimeoutCreate, meta.(*Client).timeout)
 	vm, err := storagepod.CreateVM(client, fo, spec, pool, hs, pod, timeout)
 	if err != nil {
 		return nil, fmt.Errorf("error creating virtual machine on datastore cluster %q: %s", pod.Name(), err)
@@ -1391,7 +1401,7 @@ func resourceVSphereVirtualMachineCreateBareStandard(
 		VmPathName: fmt.Sprintf("[%s]", ds.Name()),
 	}
 
-	timeout := meta.(*Client).timeout
+	timeout := resourceTimeout(d, schema.TimeoutCreate, meta.(*Client).timeout)
 	vm, err := virtualmachine.Create(client, fo, spec, pool, hs, timeout)
 	if err != nil {
 		return nil, fmt.Errorf("error creating virtual machine: %s", err)
@@ -1402,9 +1412,10 @@ func resourceVSphereVirtualMachineCreateBareStandard(
 // Deploy vm from ovf/ova template
 func resourceVsphereMachineDeployOvfAndOva(d *schema.ResourceData, meta interface{}) (*object.VirtualMachine, error) {
 	client := meta.(*Client).vimClient
-	timeout := meta.(*Client).timeout
+	timeout := resourceTimeout(d, schema.TimeoutCreate, meta.(*Client).timeout)
 
 	ovfParams := NewOvfHelperParamsFromVMResource(d)
+	ovfParams.HTTPConfig = meta.(*Client).httpConfig
 	ovfHelper, err := ovfdeploy.NewOvfHelper(client, ovfParams)
 	if err != nil {
 		return nil, fmt.Errorf("while extracting OVF parameters: %s", err)
@@ -1416,7 +1427,9 @@ func resourceVsphereMachineDeployOvfAndOva(d *schema.ResourceData, meta interfac
 	}
 
 	log.Print(" [DEBUG] start deploying from ovf/ova Template")
-	err = ovfHelper.DeployOvf(client, ovfImportspec)
+	err = meta.(*Client).runTask(resourceTimeout(d, schema.TimeoutCreate, 0), taskClassOvfImport, resourceVSphereVirtualMachineIDString(d), func() error {
+		return ovfHelper.DeployOvf(client, ovfImportspec, resourceTimeout(d, schema.TimeoutCreate, 0))
+	})
 	if err != nil {
 		return nil, fmt.Errorf("error while importing ovf/ova template, %s", err)
 	}
@@ -1455,7 +1468,9 @@ func resourceVsphereMachineDeployOvfAndOva(d *schema.ResourceData, meta interfac
 		vmConfigSpec := types.VirtualMachineConfigSpec{
 			VAppConfig: vappConfig,
 		}
-		err = virtualmachine.Reconfigure(vm, vmConfigSpec, timeout)
+		err = meta.(*Client).runTask(resourceTimeout(d, schema.TimeoutCreate, 0), taskClassReconfigure, resourceVSphereVirtualMachineIDString(d), func() error {
+			return virtualmachine.Reconfigure(vm, vmConfigSpec, timeout)
+		})
 		if err != nil {
 			return nil, fmt.Errorf("error while applying vapp config %s", err)
 		}
@@ -1531,7 +1546,12 @@ func resourceVSphereVirtualMachineCreateClone(d *schema.ResourceData, meta inter
 		if err != nil {
 			return nil, err
 		}
-		vmoid, err := virtualmachine.Deploy(deploySpec)
+		var vmoid *types.ManagedObjectReference
+		err = meta.(*Client).runTask(resourceTimeout(d, schema.TimeoutCreate, 0), taskClassClone, resourceVSphereVirtualMachineIDString(d), func() error {
+			var err error
+			vmoid, err = virtualmachine.Deploy(deploySpec)
+			return err
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -1548,11 +1568,15 @@ func resourceVSphereVirtualMachineCreateClone(d *schema.ResourceData, meta inter
 		if err != nil {
 			return nil, err
 		}
-		if _, ok := d.GetOk("datastore_cluster_id"); ok {
-			vm, err = resourceVSphereVirtualMachineCreateCloneWithSDRS(d, meta, srcVM, fo, name, cloneSpec, timeout)
-		} else {
-			vm, err = virtualmachine.Clone(client, srcVM, fo, name, cloneSpec, timeout)
-		}
+		err = meta.(*Client).runTask(resourceTimeout(d, schema.TimeoutCreate, 0), taskClassClone, resourceVSphereVirtualMachineIDString(d), func() error {
+			var err error
+			if _, ok := d.GetOk("datastore_cluster_id"); ok {
+				vm, err = resourceVSphereVirtualMachineCreateCloneWithSDRS(d, meta, srcVM, fo, name, cloneSpec, timeout)
+			} else {
+				vm, err = virtualmachine.Clone(client, srcVM, fo, name, cloneSpec, timeout)
+			}
+			return err
+		})
 		if err != nil {
 			return nil, fmt.Errorf("error cloning virtual machine: %s", err)
 		}
@@ -1622,8 +1646,10 @@ func resourceVSphereVirtualMachinePostDeployChanges(d *schema.ResourceData, meta
 	}
 	storageControllercfgSpec.DeviceChange = virtualdevice.AppendDeviceChangeSpec(storageControllercfgSpec.DeviceChange, delta...)
 
-	timeout := meta.(*Client).timeout
-	err = virtualmachine.Reconfigure(vm, storageControllercfgSpec, timeout)
+	timeout := resourceTimeout(d, schema.TimeoutCreate, meta.(*Client).timeout)
+	err = meta.(*Client).runTask(resourceTimeout(d, schema.TimeoutCreate, 0), taskClassReconfigure, resourceVSphereVirtualMachineIDString(d), func() error {
+		return virtualmachine.Reconfigure(vm, storageControllercfgSpec, timeout)
+	})
 	if err != nil {
 		return resourceVSphereVirtualMachineRollbackCreate(
 			d,
@@ -1732,7 +1758,9 @@ func resourceVSphereVirtualMachinePostDeployChanges(d *schema.ResourceData, meta
 	log.Printf("[DEBUG] %s: Final device change cfgSpec: %s", resourceVSphereVirtualMachineIDString(d), virtualdevice.DeviceChangeString(cfgSpec.DeviceChange))
 
 	// Perform updates
-	err = virtualmachine.Reconfigure(vm, cfgSpec, timeout)
+	err = meta.(*Client).runTask(resourceCreateOrUpdateTimeout(d, 0), taskClassReconfigure, resourceVSphereVirtualMachineIDString(d), func() error {
+		return virtualmachine.Reconfigure(vm, cfgSpec, timeout)
+	})
 	if err != nil {
 		return resourceVSphereVirtualMachineRollbackCreate(
 			d,
@@ -1952,13 +1980,13 @@ func resourceVSphereVirtualMachineUpdateLocation(d *schema.ResourceData, meta in
 	spec.Disk = relocators
 
 	// Ready to perform migration
-	timeout := d.Get("migrate_wait_timeout").(int)
-	if _, ok := d.GetOk("datastore_cluster_id"); ok {
-		err = resourceVSphereVirtualMachineUpdateLocationRelocateWithSDRS(d, meta, vm, spec, timeout)
-	} else {
-		err = virtualmachine.Relocate(vm, spec, timeout)
-	}
-	return err
+	timeout := resourceCreateOrUpdateTimeout(d, time.Duration(d.Get("migrate_wait_timeout").(int))*time.Minute)
+	return meta.(*Client).runTask(resourceCreateOrUpdateTimeout(d, 0), taskClassRelocate, resourceVSphereVirtualMachineIDString(d), func() error {
+		if _, ok := d.GetOk("datastore_cluster_id"); ok {
+			return resourceVSphereVirtualMachineUpdateLocationRelocateWithSDRS(d, meta, vm, spec, timeout)
+		}
+		return virtualmachine.Relocate(vm, spec, timeout)
+	})
 }
 
 // resourceVSphereVirtualMachineUpdateLocationRelocateWithSDRS runs the storage vMotion
@@ -1970,7 +1998,7 @@ func resourceVSphereVirtualMachineUpdateLocationRelocateWithSDRS(
 	meta interface{},
 	vm *object.VirtualMachine,
 	spec types.VirtualMachineRelocateSpec,
-	timeout int,
+	timeout time.Duration,
 ) error {
 	client := meta.(*Client).vimClient
 	if err := viapi.ValidateVirtualCenter(client); err != nil {
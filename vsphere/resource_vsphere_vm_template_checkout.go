@@ -0,0 +1,246 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/govmomi/vim25/types"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/contentlibrary"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/folder"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/resourcepool"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/virtualmachine"
+)
+
+func resourceVSphereVMTemplateCheckout() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVSphereVMTemplateCheckoutCreate,
+		Read:   resourceVSphereVMTemplateCheckoutRead,
+		Update: resourceVSphereVMTemplateCheckoutUpdate,
+		Delete: resourceVSphereVMTemplateCheckoutDelete,
+		Schema: map[string]*schema.Schema{
+			"item_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the content library item containing the VM template to check out.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the virtual machine the VM template is checked out to.",
+			},
+			"resource_pool_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The managed object ID of the resource pool to place the checked out virtual machine in.",
+			},
+			"host_system_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The managed object ID of the host to place the checked out virtual machine on.",
+			},
+			"folder": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The name of the folder to place the checked out virtual machine in.",
+				StateFunc:   folder.NormalizePath,
+			},
+			"powered_on": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Power on the virtual machine after it is checked out.",
+			},
+			"check_in_message": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The message recorded in the version history of the VM template when the virtual machine is checked in on destroy.",
+			},
+			"discard_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Delete the checked out virtual machine on destroy instead of checking it in, discarding any changes.",
+			},
+			"uuid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The UUID of the checked out virtual machine.",
+			},
+			"version_history": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The version history of the VM template, most recent first.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The version of the VM template.",
+						},
+						"time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The date and time when the version was created.",
+						},
+						"user": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The user that created the version.",
+						},
+						"message": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The message recorded when the version was created.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceVSphereVMTemplateCheckoutCreate(d *schema.ResourceData, meta interface{}) error {
+	itemID := d.Get("item_id").(string)
+	log.Printf("[DEBUG] resourceVSphereVMTemplateCheckoutCreate : Checking out VM template (%s)", itemID)
+	rc := meta.(*Client).restClient
+	item, err := contentlibrary.ItemFromID(rc, itemID)
+	if err != nil {
+		return err
+	}
+	if item.Type != "vm-template" {
+		return fmt.Errorf("content library item %s is of type %q, only vm-template items can be checked out", itemID, item.Type)
+	}
+	if err := resourceVSphereVMTemplateCheckoutCheckOut(d, meta); err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] resourceVSphereVMTemplateCheckoutCreate : VM template (%s) checked out to virtual machine (%s)", itemID, d.Id())
+	return resourceVSphereVMTemplateCheckoutRead(d, meta)
+}
+
+func resourceVSphereVMTemplateCheckoutRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] resourceVSphereVMTemplateCheckoutRead : Reading checked out virtual machine (%s)", d.Id())
+	client := meta.(*Client).vimClient
+	rc := meta.(*Client).restClient
+	vm, err := virtualmachine.FromMOID(client, d.Id())
+	if err != nil {
+		if viapi.IsManagedObjectNotFoundError(err) {
+			log.Printf("[DEBUG] resourceVSphereVMTemplateCheckoutRead : Virtual machine (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	props, err := virtualmachine.Properties(vm)
+	if err != nil {
+		return err
+	}
+	if props.Config != nil {
+		_ = d.Set("uuid", props.Config.Uuid)
+	}
+	changes, err := contentlibrary.ItemChanges(rc, d.Get("item_id").(string))
+	if err != nil {
+		return err
+	}
+	if err := d.Set("version_history", flattenVMTemplateVersionHistory(changes)); err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] resourceVSphereVMTemplateCheckoutRead : Checked out virtual machine (%s) read is complete", d.Id())
+	return nil
+}
+
+func resourceVSphereVMTemplateCheckoutUpdate(d *schema.ResourceData, meta interface{}) error {
+	// check_in_message and discard_on_destroy are only used on destroy, so
+	// changes to them are saved to state without touching the checked out
+	// virtual machine.
+	return resourceVSphereVMTemplateCheckoutRead(d, meta)
+}
+
+func resourceVSphereVMTemplateCheckoutDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] resourceVSphereVMTemplateCheckoutDelete : Releasing checked out virtual machine (%s)", d.Id())
+	return resourceVSphereVMTemplateCheckoutRelease(d, meta, d.Get("discard_on_destroy").(bool))
+}
+
+// resourceVSphereVMTemplateCheckoutCheckOut checks out the VM template to a
+// new virtual machine and sets the ID of the resource to it.
+func resourceVSphereVMTemplateCheckoutCheckOut(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Client).vimClient
+	rc := meta.(*Client).restClient
+	pool, err := resourcepool.FromID(client, d.Get("resource_pool_id").(string))
+	if err != nil {
+		return fmt.Errorf("could not find resource pool ID %q: %s", d.Get("resource_pool_id").(string), err)
+	}
+	fo, err := folder.VirtualMachineFolderFromObject(client, pool, d.Get("folder").(string))
+	if err != nil {
+		return err
+	}
+	id, err := contentlibrary.CheckOutTemplate(rc, d.Get("item_id").(string), d.Get("name").(string), fo.Reference().Value, pool.Reference().Value, d.Get("host_system_id").(string), d.Get("powered_on").(bool))
+	if err != nil {
+		return err
+	}
+	d.SetId(id)
+	return nil
+}
+
+// resourceVSphereVMTemplateCheckoutRelease powers off the checked out virtual
+// machine and checks it in with check_in_message, or deletes it if discard is
+// set.
+func resourceVSphereVMTemplateCheckoutRelease(d *schema.ResourceData, meta interface{}, discard bool) error {
+	client := meta.(*Client).vimClient
+	rc := meta.(*Client).restClient
+	vm, err := virtualmachine.FromMOID(client, d.Id())
+	if err != nil {
+		if viapi.IsManagedObjectNotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+	props, err := virtualmachine.Properties(vm)
+	if err != nil {
+		return err
+	}
+	if props.Runtime.PowerState != types.VirtualMachinePowerStatePoweredOff {
+		if err := virtualmachine.PowerOff(vm, defaultAPITimeout); err != nil {
+			return fmt.Errorf("error powering off virtual machine: %s", err)
+		}
+	}
+	if discard {
+		log.Printf("[DEBUG] resourceVSphereVMTemplateCheckoutRelease : Discarding checked out virtual machine (%s)", d.Id())
+		return virtualmachine.Destroy(vm, defaultAPITimeout)
+	}
+	version, err := contentlibrary.CheckInTemplate(rc, d.Get("item_id").(string), d.Id(), d.Get("check_in_message").(string))
+	if err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] resourceVSphereVMTemplateCheckoutRelease : Virtual machine (%s) checked in as version %s", d.Id(), version)
+	return nil
+}
+
+func flattenVMTemplateVersionHistory(changes []contentlibrary.ItemChange) []interface{} {
+	var result []interface{}
+	for _, change := range changes {
+		var t string
+		if change.Time != nil {
+			t = change.Time.Format(time.RFC3339)
+		}
+		result = append(result, map[string]interface{}{
+			"version": change.Version,
+			"time":    t,
+			"user":    change.User,
+			"message": change.ShortMessage,
+		})
+	}
+	return result
+}
@@ -0,0 +1,82 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/testhelper"
+)
+
+func TestAccResourceVSphereVMTemplateCheckout_basic(t *testing.T) {
+	testAccSkipUnstable(t)
+	var vmID string
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			RunSweepers()
+			testAccPreCheck(t)
+			testAccResourceVSphereVMTemplateCheckoutPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereVMTemplateCheckoutConfig("TestAcc check-in"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(
+						"vsphere_vm_template_checkout.checkout", "id", regexp.MustCompile("^vm-"),
+					),
+					resource.TestCheckResourceAttrWith("vsphere_vm_template_checkout.checkout", "id", func(value string) error {
+						vmID = value
+						return nil
+					}),
+					resource.TestCheckResourceAttrSet("vsphere_vm_template_checkout.checkout", "uuid"),
+					resource.TestCheckResourceAttrSet("vsphere_vm_template_checkout.checkout", "version_history.0.version"),
+				),
+			},
+			{
+				Config: testAccResourceVSphereVMTemplateCheckoutConfig("TestAcc updated check-in"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vsphere_vm_template_checkout.checkout", "check_in_message", "TestAcc updated check-in"),
+					resource.TestCheckResourceAttrWith("vsphere_vm_template_checkout.checkout", "id", func(value string) error {
+						if value != vmID {
+							return fmt.Errorf("expected checked out virtual machine %s to be kept, got %s", vmID, value)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceVSphereVMTemplateCheckoutPreCheck(t *testing.T) {
+	if os.Getenv("TF_VAR_VSPHERE_VMTX_ITEM_ID") == "" {
+		t.Skip("set TF_VAR_VSPHERE_VMTX_ITEM_ID to run vsphere_vm_template_checkout acceptance tests")
+	}
+}
+
+func testAccResourceVSphereVMTemplateCheckoutConfig(message string) string {
+	return fmt.Sprintf(`
+%s
+
+variable "item_id" {
+  default = "%s"
+}
+
+resource "vsphere_vm_template_checkout" "checkout" {
+  item_id          = var.item_id
+  name             = "testacc-template-checkout"
+  resource_pool_id = data.vsphere_compute_cluster.rootcompute_cluster1.resource_pool_id
+  check_in_message = "%s"
+}
+`, testhelper.CombineConfigs(testhelper.ConfigDataRootDC1(), testhelper.ConfigDataRootComputeCluster1()),
+		os.Getenv("TF_VAR_VSPHERE_VMTX_ITEM_ID"),
+		message,
+	)
+}
@@ -94,7 +94,8 @@ func resourceVSphereVmfsDatastore() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceVSphereVmfsDatastoreImport,
 		},
-		Schema: s,
+		Timeouts: resourceTimeouts(schema.TimeoutCreate, schema.TimeoutUpdate, schema.TimeoutDelete),
+		Schema:   s,
 	}
 }
 
@@ -108,7 +109,7 @@ func resourceVSphereVmfsDatastoreCreate(d *schema.ResourceData, meta interface{}
 		return err
 	}
 	// Verify a proper vCenter before proceeding if custom attributes are defined
-	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d)
+	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d, meta.(*Client).defaultCustomAttributes)
 	if err != nil {
 		return err
 	}
@@ -129,7 +130,8 @@ func resourceVSphereVmfsDatastoreCreate(d *schema.ResourceData, meta interface{}
 		return err
 	}
 	spec.Vmfs.VolumeName = d.Get("name").(string)
-	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	timeout := resourceTimeout(d, schema.TimeoutCreate, defaultAPITimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 	ds, err := dss.CreateVmfsDatastore(ctx, *spec)
 	if err != nil {
@@ -150,7 +152,7 @@ func resourceVSphereVmfsDatastoreCreate(d *schema.ResourceData, meta interface{}
 			}
 			return fmt.Errorf("error fetching datastore extend spec for disk %q: %s", disk, err)
 		}
-		ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
 		_, extendErr := extendVmfsDatastore(ctx, dss, ds, *extendSpec)
 		cancel()
 		if extendErr != nil {
@@ -181,7 +183,7 @@ func resourceVSphereVmfsDatastoreCreate(d *schema.ResourceData, meta interface{}
 
 	// Apply any pending tags now
 	if tagsClient != nil {
-		if err := processTagDiff(tagsClient, d, ds); err != nil {
+		if err := processTagDiff(tagsClient, d, meta, ds); err != nil {
 			return err
 		}
 	}
@@ -251,7 +253,7 @@ func resourceVSphereVmfsDatastoreUpdate(d *schema.ResourceData, meta interface{}
 		return err
 	}
 	// Verify a proper vCenter before proceeding if custom attributes are defined
-	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d)
+	attrsProcessor, err := customattribute.GetDiffProcessorIfAttributesDefined(client, d, meta.(*Client).defaultCustomAttributes)
 	if err != nil {
 		return err
 	}
@@ -288,7 +290,7 @@ func resourceVSphereVmfsDatastoreUpdate(d *schema.ResourceData, meta interface{}
 
 	// Apply any pending tags now
 	if tagsClient != nil {
-		if err := processTagDiff(tagsClient, d, ds); err != nil {
+		if err := processTagDiff(tagsClient, d, meta, ds); err != nil {
 			return err
 		}
 	}
@@ -330,7 +332,7 @@ func resourceVSphereVmfsDatastoreUpdate(d *schema.ResourceData, meta interface{}
 			if err != nil {
 				return err
 			}
-			ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+			ctx, cancel := context.WithTimeout(context.Background(), resourceTimeout(d, schema.TimeoutUpdate, defaultAPITimeout))
 			_, extendErr := extendVmfsDatastore(ctx, dss, ds, *spec)
 			cancel()
 			if extendErr != nil {
@@ -383,7 +385,7 @@ func resourceVSphereVmfsDatastoreDelete(d *schema.ResourceData, meta interface{}
 		Pending:    []string{retryDeletePending},
 		Target:     []string{retryDeleteCompleted},
 		Refresh:    deleteRetryFunc,
-		Timeout:    defaultAPITimeout,
+		Timeout:    resourceTimeout(d, schema.TimeoutDelete, defaultAPITimeout),
 		MinTimeout: 2 * time.Second,
 		Delay:      2 * time.Second,
 	}
@@ -413,7 +415,7 @@ func resourceVSphereVmfsDatastoreDelete(d *schema.ResourceData, meta interface{}
 		Pending:        []string{waitForDeletePending},
 		Target:         []string{waitForDeleteCompleted},
 		Refresh:        waitForDeleteFunc,
-		Timeout:        defaultAPITimeout,
+		Timeout:        resourceTimeout(d, schema.TimeoutDelete, defaultAPITimeout),
 		MinTimeout:     2 * time.Second,
 		Delay:          1 * time.Second,
 		NotFoundChecks: 35,
@@ -427,7 +429,7 @@ func resourceVSphereVmfsDatastoreDelete(d *schema.ResourceData, meta interface{}
 	return nil
 }
 
-func resourceVSphereVmfsDatastoreCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+func resourceVSphereVmfsDatastoreCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
 	// Check all disks and make sure that the entries are not nil, empty, or duplicates.
 	disks := make(map[string]struct{})
 	for i, v := range d.Get("disks").([]interface{}) {
@@ -439,7 +441,7 @@ func resourceVSphereVmfsDatastoreCustomizeDiff(_ context.Context, d *schema.Reso
 		}
 		disks[v.(string)] = struct{}{}
 	}
-	return nil
+	return tagsAndCustomAttributesCustomizeDiff(ctx, d, meta)
 }
 
 func resourceVSphereVmfsDatastoreImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
@@ -0,0 +1,203 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// sessionFileMagic prefixes session files encrypted by the provider, to tell
+// them apart from plaintext session files, such as those saved by govc or by
+// earlier versions of the provider.
+var sessionFileMagic = []byte("VSPHERE-SESSION-2\n")
+
+// sessionKeySaltSize is the size of the random salt stored in each encrypted
+// session file, from which the key of the file is derived.
+const sessionKeySaltSize = 16
+
+// sessionEncryptionKey returns the passphrase for session files, from
+// session_encryption_key or the contents of session_encryption_key_file. A nil
+// passphrase means that session files are not encrypted.
+func (c *Config) sessionEncryptionKey() ([]byte, error) {
+	k := c.SessionEncryptionKey
+	if c.SessionEncryptionKeyFile != "" {
+		b, err := os.ReadFile(filepath.Clean(c.SessionEncryptionKeyFile))
+		if err != nil {
+			return nil, fmt.Errorf("error reading session encryption key file: %s", err)
+		}
+		k = strings.TrimSpace(string(b))
+		if k == "" {
+			return nil, fmt.Errorf("session encryption key file %q is empty", c.SessionEncryptionKeyFile)
+		}
+	}
+	if k == "" {
+		return nil, nil
+	}
+	return []byte(k), nil
+}
+
+// deriveSessionKey derives the AES-256 key of a session file from the
+// passphrase and the salt of the file with scrypt, as the passphrase is
+// supplied by the user and may have little entropy.
+func deriveSessionKey(passphrase, salt []byte) ([]byte, error) {
+	return scrypt.Key(passphrase, salt, 1<<15, 8, 1, 32)
+}
+
+// readSessionFile returns the contents of the session file at p, decrypted if
+// session encryption is enabled. false is returned if the session cannot be
+// used and a new session is necessary, that is if the file does not exist, is
+// accessible by other users, is older than session_max_age, or cannot be
+// decrypted with the configured key.
+//
+// Windows does not report access by other users in the file mode, so the mode
+// is not checked there.
+func (c *Config) readSessionFile(p string) ([]byte, bool, error) {
+	fi, err := os.Stat(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			log.Printf("[DEBUG] Session data not found in %q", p)
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("error opening session file: %s", err)
+	}
+	if runtime.GOOS != "windows" && fi.Mode().Perm()&0077 != 0 {
+		log.Printf("[WARN] Ignoring session file %q, which is accessible by other users (mode %04o)", p, fi.Mode().Perm())
+		return nil, false, nil
+	}
+	if c.SessionMaxAge > 0 && time.Since(fi.ModTime()) > c.SessionMaxAge {
+		log.Printf("[DEBUG] Session data in %q is older than %s, new session necessary", p, c.SessionMaxAge)
+		return nil, false, nil
+	}
+
+	b, err := os.ReadFile(filepath.Clean(p))
+	if err != nil {
+		return nil, false, fmt.Errorf("error reading session file: %s", err)
+	}
+
+	key, err := c.sessionEncryptionKey()
+	if err != nil {
+		return nil, false, err
+	}
+	encrypted := bytes.HasPrefix(b, sessionFileMagic)
+	switch {
+	case key == nil && !encrypted:
+		return b, true, nil
+	case key == nil:
+		log.Printf("[DEBUG] Session data in %q is encrypted and no session encryption key is set, new session necessary", p)
+		return nil, false, nil
+	case !encrypted:
+		log.Printf("[DEBUG] Session data in %q is not encrypted, new session necessary", p)
+		return nil, false, nil
+	}
+
+	b, err = decryptSession(key, b[len(sessionFileMagic):], filepath.Base(p))
+	if err != nil {
+		log.Printf("[DEBUG] Could not decrypt session data in %q, new session necessary: %s", p, err)
+		return nil, false, nil
+	}
+	return b, true, nil
+}
+
+// writeSessionFile writes the session data b to the session file at p,
+// encrypted if session encryption is enabled. The file is replaced atomically
+// and is only accessible by the current user.
+func (c *Config) writeSessionFile(p string, b []byte) error {
+	key, err := c.sessionEncryptionKey()
+	if err != nil {
+		return err
+	}
+	if key != nil {
+		ct, err := encryptSession(key, b, filepath.Base(p))
+		if err != nil {
+			return err
+		}
+		b = append(append([]byte{}, sessionFileMagic...), ct...)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(p), filepath.Base(p)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(f.Name())
+	}()
+	if err := f.Chmod(0600); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), p)
+}
+
+// encryptSession encrypts a session with AES-GCM, using a key derived from the
+// passphrase and a random salt. The salt and nonce are prepended to the
+// ciphertext. The name of the session file is authenticated, so that a session
+// cannot be swapped for another.
+func encryptSession(passphrase, plaintext []byte, name string) ([]byte, error) {
+	salt := make([]byte, sessionKeySaltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	gcm, err := sessionCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(append(salt, nonce...), nonce, plaintext, []byte(name)), nil
+}
+
+// decryptSession decrypts a session encrypted by encryptSession.
+func decryptSession(passphrase, ciphertext []byte, name string) ([]byte, error) {
+	if len(ciphertext) < sessionKeySaltSize {
+		return nil, fmt.Errorf("session data is truncated")
+	}
+	salt, ciphertext := ciphertext[:sessionKeySaltSize], ciphertext[sessionKeySaltSize:]
+	gcm, err := sessionCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, fmt.Errorf("session data is truncated")
+	}
+	nonce, ciphertext := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, []byte(name))
+}
+
+func sessionCipher(passphrase, salt []byte) (cipher.AEAD, error) {
+	key, err := deriveSessionKey(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,154 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestSessionFile(t *testing.T) {
+	session := []byte(`{"SoapClient":{"Cookie":"vmware_soap_session=secret"}}`)
+	keyFile := filepath.Join(t.TempDir(), "key")
+	if err := os.WriteFile(keyFile, []byte("file key\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name          string
+		write         *Config
+		read          *Config
+		modify        func(t *testing.T, p string)
+		expected      bool
+		skipOnWindows bool
+	}{
+		{
+			name:     "plaintext",
+			write:    &Config{},
+			read:     &Config{},
+			expected: true,
+		},
+		{
+			name:     "encrypted",
+			write:    &Config{SessionEncryptionKey: "key"},
+			read:     &Config{SessionEncryptionKey: "key"},
+			expected: true,
+		},
+		{
+			name:     "key file",
+			write:    &Config{SessionEncryptionKeyFile: keyFile},
+			read:     &Config{SessionEncryptionKey: "file key"},
+			expected: true,
+		},
+		{
+			name:     "wrong key",
+			write:    &Config{SessionEncryptionKey: "key"},
+			read:     &Config{SessionEncryptionKey: "other key"},
+			expected: false,
+		},
+		{
+			name:     "key removed",
+			write:    &Config{SessionEncryptionKey: "key"},
+			read:     &Config{},
+			expected: false,
+		},
+		{
+			name:     "key added",
+			write:    &Config{},
+			read:     &Config{SessionEncryptionKey: "key"},
+			expected: false,
+		},
+		{
+			name:  "tampered",
+			write: &Config{SessionEncryptionKey: "key"},
+			read:  &Config{SessionEncryptionKey: "key"},
+			modify: func(t *testing.T, p string) {
+				b, err := os.ReadFile(p)
+				if err != nil {
+					t.Fatal(err)
+				}
+				b[len(b)-1] ^= 0xff
+				if err := os.WriteFile(p, b, 0600); err != nil {
+					t.Fatal(err)
+				}
+			},
+			expected: false,
+		},
+		{
+			name:  "accessible by others",
+			write: &Config{},
+			read:  &Config{},
+			modify: func(t *testing.T, p string) {
+				if err := os.Chmod(p, 0644); err != nil {
+					t.Fatal(err)
+				}
+			},
+			expected:      false,
+			skipOnWindows: true,
+		},
+		{
+			name:  "expired",
+			write: &Config{},
+			read:  &Config{SessionMaxAge: time.Hour},
+			modify: func(t *testing.T, p string) {
+				old := time.Now().Add(-2 * time.Hour)
+				if err := os.Chtimes(p, old, old); err != nil {
+					t.Fatal(err)
+				}
+			},
+			expected: false,
+		},
+		{
+			name:     "not expired",
+			write:    &Config{},
+			read:     &Config{SessionMaxAge: time.Hour},
+			expected: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skipOnWindows && runtime.GOOS == "windows" {
+				t.Skip("file modes are not checked on Windows")
+			}
+			p := filepath.Join(t.TempDir(), "sessions", "session")
+			if err := tc.write.writeSessionFile(p, session); err != nil {
+				t.Fatalf("error writing session file: %s", err)
+			}
+			fi, err := os.Stat(p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if runtime.GOOS != "windows" && fi.Mode().Perm() != 0600 {
+				t.Fatalf("expected session file mode 0600, got %04o", fi.Mode().Perm())
+			}
+			b, err := os.ReadFile(p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if encrypted := tc.write.SessionEncryptionKey != "" || tc.write.SessionEncryptionKeyFile != ""; encrypted == bytes.Contains(b, session) {
+				t.Fatalf("expected session data encrypted: %t, got %q", encrypted, b)
+			}
+			if tc.modify != nil {
+				tc.modify(t, p)
+			}
+
+			actual, ok, err := tc.read.readSessionFile(p)
+			if err != nil {
+				t.Fatalf("error reading session file: %s", err)
+			}
+			if ok != tc.expected {
+				t.Fatalf("expected session usable: %t, got %t", tc.expected, ok)
+			}
+			if ok && !bytes.Equal(actual, session) {
+				t.Fatalf("expected %q, got %q", session, actual)
+			}
+		})
+	}
+}
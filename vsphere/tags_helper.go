@@ -9,11 +9,14 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"sync"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/govmomi"
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/vapi/tags"
+	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/customattribute"
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/structure"
 	"github.com/vmware/terraform-provider-vsphere/vsphere/internal/helper/viapi"
 )
@@ -164,15 +167,172 @@ func tagByName(tm *tags.Manager, name, categoryID string) (string, error) {
 //
 // The key is usually "tags" and should be a list of tag IDs to associate with
 // this resource.
+//
+// The attribute is computed, as the planned value is the configuration merged
+// with default_tags. Resources that use it must call tagsCustomizeDiff during
+// diff customization.
 func tagsSchema() *schema.Schema {
 	return &schema.Schema{
 		Type:        schema.TypeSet,
 		Description: "A list of tag IDs to apply to this object.",
 		Optional:    true,
+		Computed:    true,
 		Elem:        &schema.Schema{Type: schema.TypeString},
 	}
 }
 
+// tagsSchemaWithoutDefaults returns the schema for the tags configuration
+// attribute of resources that default_tags do not apply to. Unlike tagsSchema,
+// the attribute is not computed, so the planned value is the configuration.
+func tagsSchemaWithoutDefaults() *schema.Schema {
+	s := tagsSchema()
+	s.Computed = false
+	return s
+}
+
+// configuredTagIDs returns the tag IDs in the configuration of a resource, and
+// false if they are not known yet.
+func configuredTagIDs(d structure.RawConfigReader) ([]string, bool) {
+	var ids []string
+	v, diags := d.GetRawConfigAt(cty.GetAttrPath(vSphereTagAttributeKey))
+	if diags.HasError() || v.IsNull() {
+		return ids, true
+	}
+	if !v.IsWhollyKnown() {
+		return nil, false
+	}
+	for it := v.ElementIterator(); it.Next(); {
+		_, e := it.Element()
+		if !e.IsNull() {
+			ids = append(ids, e.AsString())
+		}
+	}
+	return ids, true
+}
+
+// tagCategoryCache caches the category IDs of tags for merging default_tags,
+// so that each tag is looked up once rather than on every plan. The category
+// of a tag cannot be changed, so entries never go stale.
+type tagCategoryCache struct {
+	mu         sync.Mutex
+	categories map[string]string
+}
+
+func newTagCategoryCache() *tagCategoryCache {
+	return &tagCategoryCache{
+		categories: make(map[string]string),
+	}
+}
+
+// lookup returns a function that returns the category ID of a tag, from the
+// cache or else from fetch. A nil cache calls fetch every time.
+func (c *tagCategoryCache) lookup(fetch func(string) (string, error)) func(string) (string, error) {
+	if c == nil {
+		return fetch
+	}
+	return func(id string) (string, error) {
+		c.mu.Lock()
+		categoryID, ok := c.categories[id]
+		c.mu.Unlock()
+		if ok {
+			return categoryID, nil
+		}
+		categoryID, err := fetch(id)
+		if err != nil {
+			return "", err
+		}
+		c.mu.Lock()
+		c.categories[id] = categoryID
+		c.mu.Unlock()
+		return categoryID, nil
+	}
+}
+
+// tagCategoryFetcher returns a function that looks up the category ID of a tag
+// with the tags manager.
+func tagCategoryFetcher(tm *tags.Manager) func(string) (string, error) {
+	return func(id string) (string, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+		defer cancel()
+		tag, err := tm.GetTag(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		return tag.CategoryID, nil
+	}
+}
+
+// mergeDefaultTags returns the tag IDs of a resource merged with the default
+// tags. A default tag is left out if the resource has a tag in the same
+// category, so that resources can override a default. categoryID returns the
+// category ID of a tag.
+func mergeDefaultTags(categoryID func(string) (string, error), tagIDs, defaults []string) ([]string, error) {
+	if len(defaults) < 1 {
+		return tagIDs, nil
+	}
+
+	ids := make(map[string]bool)
+	categories := make(map[string]bool)
+	for _, id := range tagIDs {
+		category, err := categoryID(id)
+		if err != nil {
+			return nil, fmt.Errorf("could not locate tag with id %q: %s", id, err)
+		}
+		ids[id] = true
+		categories[category] = true
+	}
+	merged := append([]string{}, tagIDs...)
+	for _, id := range defaults {
+		if ids[id] {
+			continue
+		}
+		category, err := categoryID(id)
+		if err != nil {
+			return nil, fmt.Errorf("could not locate default tag with id %q: %s", id, err)
+		}
+		if categories[category] {
+			log.Printf("[DEBUG] Default tag %q overridden by a tag in category %q", id, category)
+			continue
+		}
+		merged = append(merged, id)
+	}
+	return merged, nil
+}
+
+// tagsCustomizeDiff plans the tags of a resource as the tags in its
+// configuration merged with default_tags. The tags are marked as computed if
+// the configuration is not known yet.
+func tagsCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	ids, ok := configuredTagIDs(d)
+	if !ok {
+		return d.SetNewComputed(vSphereTagAttributeKey)
+	}
+	if client, ok := meta.(*Client); ok && len(client.defaultTags) > 0 {
+		tm, err := client.TagsManager()
+		if err != nil {
+			return fmt.Errorf("error loading tagging client for default_tags: %s", err)
+		}
+		if ids, err = mergeDefaultTags(client.tagCategories.lookup(tagCategoryFetcher(tm)), ids, client.defaultTags); err != nil {
+			return err
+		}
+	}
+	return d.SetNew(vSphereTagAttributeKey, ids)
+}
+
+// tagsAndCustomAttributesCustomizeDiff is the diff customization for resources
+// that support tags and custom attributes, which merges default_tags and
+// default_custom_attributes into their planned values.
+func tagsAndCustomAttributesCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if err := tagsCustomizeDiff(d, meta); err != nil {
+		return err
+	}
+	var defaults map[string]string
+	if client, ok := meta.(*Client); ok {
+		defaults = client.defaultCustomAttributes
+	}
+	return customattribute.DiffWithDefaults(d, defaults)
+}
+
 // readTagsForResource reads the tags for a given reference and saves the list
 // in the supplied ResourceData. It returns an error if there was an issue
 // reading the tags.
@@ -283,7 +443,7 @@ func (p *tagDiffProcessor) processDetachOperations() error {
 // client should be checked for nil before passing it to processTagDiff.
 func tagsManagerIfDefined(d *schema.ResourceData, meta interface{}) (*tags.Manager, error) {
 	old, newValue := d.GetChange(vSphereTagAttributeKey)
-	if len(old.(*schema.Set).List()) > 0 || len(newValue.(*schema.Set).List()) > 0 {
+	if len(old.(*schema.Set).List()) > 0 || len(newValue.(*schema.Set).List()) > 0 || len(meta.(*Client).defaultTags) > 0 {
 		log.Printf("[DEBUG] tagsClientIfDefined: Loading tagging client")
 		tm, err := meta.(*Client).TagsManager()
 		if err != nil {
@@ -297,14 +457,23 @@ func tagsManagerIfDefined(d *schema.ResourceData, meta interface{}) (*tags.Manag
 
 // processTagDiff wraps the whole tag diffing operation into a nice clean
 // function that resources can use.
-func processTagDiff(tm *tags.Manager, d *schema.ResourceData, obj object.Reference) error {
+func processTagDiff(tm *tags.Manager, d *schema.ResourceData, meta interface{}, obj object.Reference) error {
 	log.Printf("[DEBUG] Processing tags for object %q", obj.Reference().Value)
 	old, newValue := d.GetChange(vSphereTagAttributeKey)
+	newTagIDs := structure.SliceInterfacesToStrings(newValue.(*schema.Set).List())
+	// The planned value is not known if the configuration was not known at
+	// plan time, in which case default_tags are merged here.
+	if ids, ok := configuredTagIDs(d); ok {
+		var err error
+		if newTagIDs, err = mergeDefaultTags(meta.(*Client).tagCategories.lookup(tagCategoryFetcher(tm)), ids, meta.(*Client).defaultTags); err != nil {
+			return err
+		}
+	}
 	tdp := &tagDiffProcessor{
 		manager:   tm,
 		subject:   obj,
 		oldTagIDs: structure.SliceInterfacesToStrings(old.(*schema.Set).List()),
-		newTagIDs: structure.SliceInterfacesToStrings(newValue.(*schema.Set).List()),
+		newTagIDs: newTagIDs,
 	}
 	if err := tdp.processDetachOperations(); err != nil {
 		return fmt.Errorf("error detaching tags to object ID %q: %s", obj.Reference().Value, err)
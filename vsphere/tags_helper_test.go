@@ -0,0 +1,198 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// testTagCategories maps the tag IDs used in the tests below to their
+// category IDs.
+var testTagCategories = map[string]string{
+	"tag-cost-default":  "cat-cost",
+	"tag-cost-research": "cat-cost",
+	"tag-owner-default": "cat-owner",
+	"tag-env-prod":      "cat-env",
+}
+
+func testTagCategory(id string) (string, error) {
+	if c, ok := testTagCategories[id]; ok {
+		return c, nil
+	}
+	return "", errors.New("tag not found")
+}
+
+func TestMergeDefaultTags(t *testing.T) {
+	cases := []struct {
+		name        string
+		tagIDs      []string
+		defaults    []string
+		expected    []string
+		expectError bool
+	}{
+		{
+			name:     "no defaults",
+			tagIDs:   []string{"tag-env-prod"},
+			expected: []string{"tag-env-prod"},
+		},
+		{
+			name:     "defaults only",
+			defaults: []string{"tag-cost-default", "tag-owner-default"},
+			expected: []string{"tag-cost-default", "tag-owner-default"},
+		},
+		{
+			name:     "explicit tag in other category",
+			tagIDs:   []string{"tag-env-prod"},
+			defaults: []string{"tag-cost-default"},
+			expected: []string{"tag-env-prod", "tag-cost-default"},
+		},
+		{
+			name:     "explicit tag overrides default in same category",
+			tagIDs:   []string{"tag-cost-research"},
+			defaults: []string{"tag-cost-default", "tag-owner-default"},
+			expected: []string{"tag-cost-research", "tag-owner-default"},
+		},
+		{
+			name:     "explicit tag equal to default",
+			tagIDs:   []string{"tag-cost-default"},
+			defaults: []string{"tag-cost-default"},
+			expected: []string{"tag-cost-default"},
+		},
+		{
+			name:        "unknown explicit tag",
+			tagIDs:      []string{"tag-missing"},
+			defaults:    []string{"tag-cost-default"},
+			expectError: true,
+		},
+		{
+			name:        "unknown default tag",
+			defaults:    []string{"tag-missing"},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := mergeDefaultTags(testTagCategory, tc.tagIDs, tc.defaults)
+			if tc.expectError {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestTagCategoryCache(t *testing.T) {
+	fetches := 0
+	fetch := func(id string) (string, error) {
+		fetches++
+		return testTagCategory(id)
+	}
+
+	lookup := newTagCategoryCache().lookup(fetch)
+	for i := 0; i < 3; i++ {
+		if _, err := mergeDefaultTags(lookup, []string{"tag-cost-research"}, []string{"tag-cost-default", "tag-owner-default"}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	if fetches != 3 {
+		t.Fatalf("expected each tag to be fetched once, got %d fetches", fetches)
+	}
+
+	fetches = 0
+	for i := 0; i < 2; i++ {
+		if _, err := lookup("tag-missing"); err == nil {
+			t.Fatal("expected an error, got none")
+		}
+	}
+	if fetches != 2 {
+		t.Fatalf("expected failed lookups not to be cached, got %d fetches", fetches)
+	}
+
+	fetches = 0
+	var nilCache *tagCategoryCache
+	lookup = nilCache.lookup(fetch)
+	for i := 0; i < 2; i++ {
+		if _, err := lookup("tag-env-prod"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	if fetches != 2 {
+		t.Fatalf("expected a nil cache to fetch every time, got %d fetches", fetches)
+	}
+}
+
+// testTagsRawConfig is a RawConfigReader over a configuration with the
+// supplied tags value.
+type testTagsRawConfig struct {
+	tags cty.Value
+}
+
+func (c testTagsRawConfig) GetRawConfigAt(p cty.Path) (cty.Value, diag.Diagnostics) {
+	v, err := p.Apply(cty.ObjectVal(map[string]cty.Value{vSphereTagAttributeKey: c.tags}))
+	if err != nil {
+		return cty.DynamicVal, diag.FromErr(err)
+	}
+	return v, nil
+}
+
+func TestConfiguredTagIDs(t *testing.T) {
+	cases := []struct {
+		name          string
+		tags          cty.Value
+		expected      []string
+		expectedKnown bool
+	}{
+		{
+			name:          "not set",
+			tags:          cty.NullVal(cty.Set(cty.String)),
+			expected:      nil,
+			expectedKnown: true,
+		},
+		{
+			name:          "set explicitly",
+			tags:          cty.SetVal([]cty.Value{cty.StringVal("tag-env-prod")}),
+			expected:      []string{"tag-env-prod"},
+			expectedKnown: true,
+		},
+		{
+			name:          "unknown",
+			tags:          cty.UnknownVal(cty.Set(cty.String)),
+			expected:      nil,
+			expectedKnown: false,
+		},
+		{
+			name:          "unknown element",
+			tags:          cty.SetVal([]cty.Value{cty.UnknownVal(cty.String)}),
+			expected:      nil,
+			expectedKnown: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, known := configuredTagIDs(testTagsRawConfig{tags: tc.tags})
+			if known != tc.expectedKnown {
+				t.Fatalf("expected known to be %t, got %t", tc.expectedKnown, known)
+			}
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// taskClass is a class of long-running operations that can be limited
+// separately with the max_concurrent_*_tasks provider settings.
+type taskClass string
+
+const (
+	taskClassClone       taskClass = "clone"
+	taskClassRelocate    taskClass = "relocate"
+	taskClassReconfigure taskClass = "reconfigure"
+	taskClassOvfImport   taskClass = "OVF import"
+)
+
+// taskLimiter caps the number of concurrent tasks submitted by the provider,
+// both globally and per task class. Operations over the limit wait for a free
+// slot instead of being submitted to vCenter Server, where they would queue
+// up and count against the API timeout.
+//
+// A nil channel means the corresponding limit is disabled.
+type taskLimiter struct {
+	global  chan struct{}
+	classes map[taskClass]chan struct{}
+}
+
+// newTaskLimiter returns a taskLimiter with the supplied global and per class
+// limits. A limit of 0 disables that limit.
+func newTaskLimiter(global int, classes map[taskClass]int) *taskLimiter {
+	l := &taskLimiter{
+		global:  newTaskSlots(global),
+		classes: make(map[taskClass]chan struct{}),
+	}
+	for class, limit := range classes {
+		l.classes[class] = newTaskSlots(limit)
+	}
+	return l
+}
+
+func newTaskSlots(limit int) chan struct{} {
+	if limit < 1 {
+		return nil
+	}
+	return make(chan struct{}, limit)
+}
+
+// run runs f once a slot is free for the task class and globally. name
+// describes the operation in log messages. Waiting for a slot is abandoned
+// with an error when ctx is done. A nil taskLimiter runs f immediately.
+func (l *taskLimiter) run(ctx context.Context, class taskClass, name string, f func() error) error {
+	if l == nil {
+		return f()
+	}
+	// The class slot is acquired first so that an operation waiting on its
+	// class does not hold up operations of other classes.
+	release, err := l.acquire(ctx, l.classes[class], name, string(class))
+	if err != nil {
+		return err
+	}
+	defer release()
+	releaseGlobal, err := l.acquire(ctx, l.global, name, "global")
+	if err != nil {
+		return err
+	}
+	defer releaseGlobal()
+	return f()
+}
+
+func (l *taskLimiter) acquire(ctx context.Context, slots chan struct{}, name, limit string) (func(), error) {
+	if slots == nil {
+		return func() {}, nil
+	}
+	select {
+	case slots <- struct{}{}:
+	default:
+		log.Printf("[INFO] %s: %s task limit of %d reached, queueing operation", name, limit, cap(slots))
+		start := time.Now()
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("error waiting for a free %s task slot: %s", limit, ctx.Err())
+		}
+		log.Printf("[INFO] %s: operation dequeued after %s", name, time.Since(start).Round(time.Second))
+	}
+	return func() { <-slots }, nil
+}
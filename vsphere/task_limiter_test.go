@@ -0,0 +1,114 @@
+// © Broadcom. All Rights Reserved.
+// The term "Broadcom" refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vsphere
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTaskLimiter(t *testing.T) {
+	cases := []struct {
+		name     string
+		limiter  *taskLimiter
+		class    taskClass
+		expected int32
+	}{
+		{
+			name:     "unlimited",
+			limiter:  nil,
+			class:    taskClassClone,
+			expected: 8,
+		},
+		{
+			name:     "global",
+			limiter:  newTaskLimiter(3, nil),
+			class:    taskClassClone,
+			expected: 3,
+		},
+		{
+			name:     "class",
+			limiter:  newTaskLimiter(0, map[taskClass]int{taskClassClone: 2, taskClassRelocate: 5}),
+			class:    taskClassClone,
+			expected: 2,
+		},
+		{
+			name:     "global below class",
+			limiter:  newTaskLimiter(1, map[taskClass]int{taskClassClone: 2}),
+			class:    taskClassClone,
+			expected: 1,
+		},
+		{
+			name:     "other class",
+			limiter:  newTaskLimiter(0, map[taskClass]int{taskClassRelocate: 1}),
+			class:    taskClassClone,
+			expected: 8,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var running, peak int32
+			var wg sync.WaitGroup
+			start := make(chan struct{})
+			for i := 0; i < 8; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					<-start
+					_ = tc.limiter.run(context.Background(), tc.class, tc.name, func() error {
+						n := atomic.AddInt32(&running, 1)
+						for {
+							p := atomic.LoadInt32(&peak)
+							if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+								break
+							}
+						}
+						time.Sleep(100 * time.Millisecond)
+						atomic.AddInt32(&running, -1)
+						return nil
+					})
+				}()
+			}
+			close(start)
+			wg.Wait()
+			if peak != tc.expected {
+				t.Fatalf("expected at most %d concurrent tasks, got %d", tc.expected, peak)
+			}
+		})
+	}
+}
+
+func TestTaskLimiterTimeout(t *testing.T) {
+	limiter := newTaskLimiter(1, nil)
+	hold := make(chan struct{})
+	started := make(chan struct{})
+	go func() {
+		_ = limiter.run(context.Background(), taskClassClone, "holder", func() error {
+			close(started)
+			<-hold
+			return nil
+		})
+	}()
+	<-started
+	defer close(hold)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	ran := false
+	err := limiter.run(ctx, taskClassClone, "waiter", func() error {
+		ran = true
+		return nil
+	})
+	if err == nil {
+		t.Fatal("expected an error waiting for a task slot, got none")
+	}
+	if ran {
+		t.Fatal("expected the queued operation not to run")
+	}
+}